@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BigNumberCodec is the Codec used for big.Float and big.Rat values. Values are encoded as BSON Decimal128 and can be
+// decoded from BSON Decimal128, double, int32 and int64 values.
+//
+// BigNumberCodec is not registered by default. Use RegisterBigNumberCodec to register it with a RegistryBuilder.
+type BigNumberCodec struct {
+	Precision    uint
+	RoundingMode big.RoundingMode
+}
+
+var (
+	_ ValueCodec  = &BigNumberCodec{}
+	_ typeDecoder = &BigNumberCodec{}
+)
+
+// NewBigNumberCodec returns a BigNumberCodec with options opts.
+func NewBigNumberCodec(opts ...*bsonoptions.BigNumberCodecOptions) *BigNumberCodec {
+	bigOpt := bsonoptions.MergeBigNumberCodecOptions(opts...)
+
+	codec := BigNumberCodec{RoundingMode: big.ToNearestEven}
+	if bigOpt.Precision != nil {
+		codec.Precision = *bigOpt.Precision
+	}
+	if bigOpt.RoundingMode != nil {
+		codec.RoundingMode = *bigOpt.RoundingMode
+	}
+	return &codec
+}
+
+// RegisterBigNumberCodec registers a BigNumberCodec with options opts as the encoder and decoder for big.Float and
+// big.Rat on rb. Pointers to these types are handled by the PointerCodec.
+func RegisterBigNumberCodec(rb *RegistryBuilder, opts ...*bsonoptions.BigNumberCodecOptions) *RegistryBuilder {
+	codec := NewBigNumberCodec(opts...)
+	return rb.RegisterTypeEncoder(tBigFloat, codec).
+		RegisterTypeEncoder(tBigRat, codec).
+		RegisterTypeDecoder(tBigFloat, codec).
+		RegisterTypeDecoder(tBigRat, codec)
+}
+
+func (bnc *BigNumberCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t reflect.Type) (reflect.Value, error) {
+	if t != tBigFloat && t != tBigRat {
+		return emptyValue, ValueDecoderError{
+			Name:     "BigNumberDecodeValue",
+			Types:    []reflect.Type{tBigFloat, tBigRat},
+			Received: reflect.Zero(t),
+		}
+	}
+
+	// Decode every numeric type through a *big.Float or a *big.Rat so only the final conversion differs.
+	var f *big.Float
+	var r *big.Rat
+	switch vrType := vr.Type(); vrType {
+	case bsontype.Decimal128:
+		d128, err := vr.ReadDecimal128()
+		if err != nil {
+			return emptyValue, err
+		}
+		if t == tBigFloat {
+			f, err = d128.BigFloat(bnc.Precision)
+		} else {
+			r, err = d128.Rat()
+		}
+		if err != nil {
+			return emptyValue, err
+		}
+	case bsontype.Double:
+		f64, err := vr.ReadDouble()
+		if err != nil {
+			return emptyValue, err
+		}
+		if math.IsNaN(f64) || (t == tBigRat && math.IsInf(f64, 0)) {
+			return emptyValue, fmt.Errorf("cannot decode %v into a %v", f64, t)
+		}
+		f = new(big.Float).SetPrec(bnc.Precision).SetFloat64(f64)
+	case bsontype.Int32:
+		i32, err := vr.ReadInt32()
+		if err != nil {
+			return emptyValue, err
+		}
+		f = new(big.Float).SetPrec(bnc.Precision).SetInt64(int64(i32))
+	case bsontype.Int64:
+		i64, err := vr.ReadInt64()
+		if err != nil {
+			return emptyValue, err
+		}
+		f = new(big.Float).SetPrec(bnc.Precision).SetInt64(i64)
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return emptyValue, err
+		}
+		return reflect.Zero(t), nil
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return emptyValue, err
+		}
+		return reflect.Zero(t), nil
+	default:
+		return emptyValue, fmt.Errorf("cannot decode %v into a %v", vrType, t)
+	}
+
+	if t == tBigFloat {
+		return reflect.ValueOf(f).Elem(), nil
+	}
+	if r == nil {
+		// The value came from a finite double or an integer, so it is exact.
+		r, _ = f.Rat(nil)
+	}
+	return reflect.ValueOf(r).Elem(), nil
+}
+
+// DecodeValue is the ValueDecoderFunc for big.Float and big.Rat.
+func (bnc *BigNumberCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != tBigFloat && val.Type() != tBigRat) {
+		return ValueDecoderError{Name: "BigNumberDecodeValue", Types: []reflect.Type{tBigFloat, tBigRat}, Received: val}
+	}
+
+	elem, err := bnc.decodeType(dc, vr, val.Type())
+	if err != nil {
+		return err
+	}
+
+	val.Set(elem)
+	return nil
+}
+
+// EncodeValue is the ValueEncoderFunc for big.Float and big.Rat.
+func (bnc *BigNumberCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || (val.Type() != tBigFloat && val.Type() != tBigRat) {
+		return ValueEncoderError{Name: "BigNumberEncodeValue", Types: []reflect.Type{tBigFloat, tBigRat}, Received: val}
+	}
+
+	var d128 primitive.Decimal128
+	switch v := val.Interface().(type) {
+	case big.Float:
+		d128, _ = primitive.ParseDecimal128FromBigFloat(&v, bnc.RoundingMode)
+	case big.Rat:
+		d128, _ = primitive.ParseDecimal128FromRat(&v, bnc.RoundingMode)
+	}
+	return vw.WriteDecimal128(d128)
+}
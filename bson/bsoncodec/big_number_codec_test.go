@@ -0,0 +1,153 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsonrw/bsonrwtest"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestBigNumberCodec(t *testing.T) {
+	d128 := func(s string) primitive.Decimal128 {
+		d, err := primitive.ParseDecimal128(s)
+		assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+		return d
+	}
+
+	t.Run("DecodeValue", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			reader   *bsonrwtest.ValueReaderWriter
+			float    string
+			rat      string
+			hasError bool
+		}{
+			{"decimal128", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Decimal128, Return: d128("12.50")}, "12.5",
+				"25/2", false},
+			{"decimal128 fraction", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Decimal128, Return: d128("0.1")},
+				"0.1", "1/10", false},
+			{"double", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Double, Return: float64(-0.25)}, "-0.25", "-1/4",
+				false},
+			{"int32", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Int32, Return: int32(42)}, "42", "42", false},
+			{"int64", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Int64, Return: int64(math.MaxInt64)},
+				"9223372036854775807", "9223372036854775807", false},
+			{"null", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Null}, "0", "0", false},
+			{"decimal128 NaN", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Decimal128, Return: d128("NaN")}, "", "",
+				true},
+			{"double NaN", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Double, Return: math.NaN()}, "", "", true},
+			{"string", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.String, Return: "1"}, "", "", true},
+		}
+		codec := NewBigNumberCodec()
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				f := reflect.New(tBigFloat).Elem()
+				err := codec.DecodeValue(DecodeContext{}, tc.reader, f)
+				if tc.hasError {
+					assert.NotNil(t, err, "expected error decoding into big.Float, got nil")
+				} else {
+					assert.Nil(t, err, "DecodeValue error: %v", err)
+					got := f.Addr().Interface().(*big.Float)
+					assert.Equal(t, tc.float, got.Text('f', -1), "expected %v, got %v", tc.float, got)
+				}
+
+				r := reflect.New(tBigRat).Elem()
+				err = codec.DecodeValue(DecodeContext{}, tc.reader, r)
+				if tc.hasError {
+					assert.NotNil(t, err, "expected error decoding into big.Rat, got nil")
+				} else {
+					assert.Nil(t, err, "DecodeValue error: %v", err)
+					got := r.Addr().Interface().(*big.Rat)
+					assert.Equal(t, tc.rat, got.RatString(), "expected %v, got %v", tc.rat, got)
+				}
+			})
+		}
+	})
+	t.Run("Precision", func(t *testing.T) {
+		reader := &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Decimal128, Return: d128("0.1")}
+		testCases := []struct {
+			name     string
+			opts     *bsonoptions.BigNumberCodecOptions
+			expected uint
+		}{
+			{"default", bsonoptions.BigNumberCodec(), 128},
+			{"set", bsonoptions.BigNumberCodec().SetPrecision(256), 256},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				f := reflect.New(tBigFloat).Elem()
+				err := NewBigNumberCodec(tc.opts).DecodeValue(DecodeContext{}, reader, f)
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				got := f.Addr().Interface().(*big.Float).Prec()
+				assert.Equal(t, tc.expected, got, "expected precision %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("EncodeValue", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			opts     *bsonoptions.BigNumberCodecOptions
+			val      interface{}
+			expected string
+		}{
+			{"big.Float", nil, *big.NewFloat(2.5), "2.5"},
+			{"big.Float infinity", nil, *new(big.Float).SetInf(true), "-Infinity"},
+			{"big.Rat", nil, *big.NewRat(-3, 8), "-0.375"},
+			{"big.Rat rounded", nil, *big.NewRat(2, 3), "0.6666666666666666666666666666666667"},
+			{"big.Rat rounding mode", bsonoptions.BigNumberCodec().SetRoundingMode(big.ToZero), *big.NewRat(2, 3),
+				"0.6666666666666666666666666666666666"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				buf := new(bytes.Buffer)
+				vw, err := bsonrw.NewBSONValueWriter(buf)
+				assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+				dw, err := vw.WriteDocument()
+				assert.Nil(t, err, "WriteDocument error: %v", err)
+				evw, err := dw.WriteDocumentElement("d")
+				assert.Nil(t, err, "WriteDocumentElement error: %v", err)
+
+				err = NewBigNumberCodec(tc.opts).EncodeValue(EncodeContext{}, evw, reflect.ValueOf(tc.val))
+				assert.Nil(t, err, "EncodeValue error: %v", err)
+				err = dw.WriteDocumentEnd()
+				assert.Nil(t, err, "WriteDocumentEnd error: %v", err)
+
+				got, ok := bsoncore.Document(buf.Bytes()).Lookup("d").Decimal128OK()
+				assert.True(t, ok, "expected a Decimal128 to be written")
+				assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("RegisterBigNumberCodec", func(t *testing.T) {
+		rb := NewRegistryBuilder()
+		DefaultValueEncoders{}.RegisterDefaultEncoders(rb)
+		DefaultValueDecoders{}.RegisterDefaultDecoders(rb)
+		reg := RegisterBigNumberCodec(rb).Build()
+
+		for _, typ := range []reflect.Type{tBigFloat, tBigRat} {
+			enc, err := reg.LookupEncoder(typ)
+			assert.Nil(t, err, "LookupEncoder error: %v", err)
+			_, ok := enc.(*BigNumberCodec)
+			assert.True(t, ok, "expected a *BigNumberCodec encoder for %v, got %T", typ, enc)
+
+			dec, err := reg.LookupDecoder(typ)
+			assert.Nil(t, err, "LookupDecoder error: %v", err)
+			_, ok = dec.(*BigNumberCodec)
+			assert.True(t, ok, "expected a *BigNumberCodec decoder for %v, got %T", typ, dec)
+		}
+	})
+}
@@ -123,6 +123,9 @@ type DecodeContext struct {
 	// Ancestor is a bson.M, BSON embedded document values being decoded into an empty interface
 	// will be decoded into a bson.M.
 	Ancestor reflect.Type
+	// DisallowUnknownFields causes the StructCodec to return an error when decoding a document with a key that doesn't
+	// match any field of the struct being decoded into, as if its DisallowUnknownFields field were set.
+	DisallowUnknownFields bool
 }
 
 // ValueCodec is the interface that groups the methods to encode and decode
@@ -69,6 +69,7 @@ func (dve DefaultValueEncoders) RegisterDefaultEncoders(rb *RegistryBuilder) {
 	rb.
 		RegisterTypeEncoder(tByteSlice, defaultByteSliceCodec).
 		RegisterTypeEncoder(tTime, defaultTimeCodec).
+		RegisterTypeEncoder(tUUID, defaultUUIDCodec).
 		RegisterTypeEncoder(tEmpty, defaultEmptyInterfaceCodec).
 		RegisterTypeEncoder(tCoreArray, defaultArrayCodec).
 		RegisterTypeEncoder(tOID, ValueEncoderFunc(dve.ObjectIDEncodeValue)).
@@ -0,0 +1,190 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// DefaultDiscriminatorKey is the key of the discriminator field written for interface types registered with
+// RegistryBuilder.RegisterDiscriminatedType if no other key has been set with RegistryBuilder.RegisterDiscriminatorKey.
+const DefaultDiscriminatorKey = "_t"
+
+// discriminator holds the concrete types registered for an interface type and the values identifying them.
+type discriminator struct {
+	key    string
+	types  map[string]reflect.Type
+	values map[reflect.Type]string
+}
+
+func newDiscriminator() *discriminator {
+	return &discriminator{
+		key:    DefaultDiscriminatorKey,
+		types:  make(map[string]reflect.Type),
+		values: make(map[reflect.Type]string),
+	}
+}
+
+func (d *discriminator) copy() *discriminator {
+	c := &discriminator{
+		key:    d.key,
+		types:  make(map[string]reflect.Type, len(d.types)),
+		values: make(map[reflect.Type]string, len(d.values)),
+	}
+	for value, rt := range d.types {
+		c.types[value] = rt
+	}
+	for rt, value := range d.values {
+		c.values[rt] = value
+	}
+	return c
+}
+
+// discriminatorCodec is the Codec used for interface types with registered discriminated types. Values are encoded as
+// documents starting with a discriminator field that identifies their concrete type, and documents are decoded into the
+// concrete type registered for the value of their discriminator field.
+type discriminatorCodec struct {
+	iface reflect.Type
+	*discriminator
+}
+
+var _ ValueCodec = &discriminatorCodec{}
+
+// EncodeValue is the ValueEncoder for interface types with registered discriminated types.
+func (dc *discriminatorCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != dc.iface {
+		return ValueEncoderError{Name: "DiscriminatorEncodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+	elem := val.Elem()
+	if elem.Kind() == reflect.Ptr && elem.IsNil() {
+		return vw.WriteNull()
+	}
+
+	value, ok := dc.values[elem.Type()]
+	if !ok {
+		return fmt.Errorf("no discriminator value registered for %s in %s", elem.Type(), dc.iface)
+	}
+	encoder, err := ec.LookupEncoder(elem.Type())
+	if err != nil {
+		return err
+	}
+
+	dw := &discriminatorWriter{ValueWriter: vw, key: dc.key, value: value}
+	err = encoder.EncodeValue(ec, dw, elem)
+	if err != nil {
+		return err
+	}
+	if !dw.written {
+		return fmt.Errorf("cannot write discriminator for %s: value is not encoded as a document", elem.Type())
+	}
+	return nil
+}
+
+// DecodeValue is the ValueDecoder for interface types with registered discriminated types.
+func (dc *discriminatorCodec) DecodeValue(dctx DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != dc.iface {
+		return ValueDecoderError{Name: "DiscriminatorDecodeValue", Types: []reflect.Type{dc.iface}, Received: val}
+	}
+
+	switch vr.Type() {
+	case bsontype.EmbeddedDocument, bsontype.Type(0):
+	case bsontype.Null:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadUndefined()
+	default:
+		return fmt.Errorf("cannot decode %v into %s", vr.Type(), dc.iface)
+	}
+
+	doc, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
+	if err != nil {
+		return err
+	}
+	elem, err := bsoncore.Document(doc).LookupErr(dc.key)
+	if err != nil {
+		return fmt.Errorf("cannot decode document into %s: missing discriminator %q", dc.iface, dc.key)
+	}
+	value, ok := elem.StringValueOK()
+	if !ok {
+		return fmt.Errorf("cannot decode document into %s: discriminator %q must be a string, got %v", dc.iface,
+			dc.key, elem.Type)
+	}
+	rt, ok := dc.types[value]
+	if !ok {
+		return fmt.Errorf("cannot decode document into %s: unknown discriminator value %q", dc.iface, value)
+	}
+
+	// The discriminator field is removed so that it doesn't end up in an inline map of the concrete type.
+	doc, err = removeDocumentElement(doc, dc.key)
+	if err != nil {
+		return err
+	}
+	decoder, err := dctx.LookupDecoder(rt)
+	if err != nil {
+		return err
+	}
+	concrete, err := decodeTypeOrValue(decoder, dctx, bsonrw.NewBSONDocumentReader(doc), rt)
+	if err != nil {
+		return err
+	}
+
+	val.Set(concrete)
+	return nil
+}
+
+// removeDocumentElement returns a copy of doc without the elements with the given key.
+func removeDocumentElement(doc bsoncore.Document, key string) (bsoncore.Document, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(make([]byte, 0, len(doc)))
+	for _, elem := range elems {
+		if elem.Key() != key {
+			dst = append(dst, elem...)
+		}
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+// discriminatorWriter is a ValueWriter that writes a discriminator field at the start of the document written to it.
+type discriminatorWriter struct {
+	bsonrw.ValueWriter
+	key     string
+	value   string
+	written bool
+}
+
+func (dw *discriminatorWriter) WriteDocument() (bsonrw.DocumentWriter, error) {
+	docw, err := dw.ValueWriter.WriteDocument()
+	if err != nil {
+		return nil, err
+	}
+	vw, err := docw.WriteDocumentElement(dw.key)
+	if err != nil {
+		return nil, err
+	}
+	err = vw.WriteString(dw.value)
+	if err != nil {
+		return nil, err
+	}
+
+	dw.written = true
+	return docw, nil
+}
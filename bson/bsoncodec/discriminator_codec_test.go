@@ -0,0 +1,186 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+type testEvent interface {
+	eventName() string
+}
+
+type orderPlaced struct {
+	Order int
+	Extra map[string]interface{} `bson:",inline"`
+}
+
+func (orderPlaced) eventName() string { return "placed" }
+
+type orderShipped struct {
+	Order   int
+	Carrier string
+}
+
+func (*orderShipped) eventName() string { return "shipped" }
+
+type eventLog struct {
+	Last   testEvent
+	Events []testEvent
+	ByID   map[string]testEvent
+}
+
+func TestDiscriminatorCodec(t *testing.T) {
+	tEvent := reflect.TypeOf((*testEvent)(nil)).Elem()
+	newRegistryBuilder := func() *RegistryBuilder {
+		rb := NewRegistryBuilder()
+		defaultValueEncoders.RegisterDefaultEncoders(rb)
+		defaultValueDecoders.RegisterDefaultDecoders(rb)
+		return rb.
+			RegisterDiscriminatedType(tEvent, "OrderPlaced", reflect.TypeOf(orderPlaced{})).
+			RegisterDiscriminatedType(tEvent, "OrderShipped", reflect.TypeOf(&orderShipped{}))
+	}
+	encode := func(t *testing.T, reg *Registry, val interface{}) bsoncore.Document {
+		t.Helper()
+
+		var buf bytes.Buffer
+		vw, err := bsonrw.NewBSONValueWriter(&buf)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		enc, err := reg.LookupEncoder(reflect.TypeOf(val))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(val))
+		assert.Nil(t, err, "EncodeValue error: %v", err)
+		return buf.Bytes()
+	}
+	decode := func(reg *Registry, doc bsoncore.Document, val interface{}) error {
+		rv := reflect.ValueOf(val).Elem()
+		dec, err := reg.LookupDecoder(rv.Type())
+		if err != nil {
+			return err
+		}
+		return dec.DecodeValue(DecodeContext{Registry: reg}, bsonrw.NewBSONDocumentReader(doc), rv)
+	}
+	placed := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendStringElement(nil, "_t", "OrderPlaced"),
+		bsoncore.AppendInt32Element(nil, "order", 1),
+	)
+	shipped := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendStringElement(nil, "_t", "OrderShipped"),
+		bsoncore.AppendInt32Element(nil, "order", 2),
+		bsoncore.AppendStringElement(nil, "carrier", "ups"),
+	)
+
+	t.Run("round trip", func(t *testing.T) {
+		reg := newRegistryBuilder().Build()
+		log := eventLog{
+			Last:   &orderShipped{Order: 2, Carrier: "ups"},
+			Events: []testEvent{orderPlaced{Order: 1}, &orderShipped{Order: 2, Carrier: "ups"}, nil},
+			ByID:   map[string]testEvent{"a": orderPlaced{Order: 1}},
+		}
+		doc := encode(t, reg, log)
+
+		assert.Equal(t, bsoncore.Document(shipped), doc.Lookup("last").Document(), "expected %v, got %v",
+			bsoncore.Document(shipped), doc.Lookup("last"))
+		events := doc.Lookup("events").Array()
+		vals, err := events.Values()
+		assert.Nil(t, err, "Values error: %v", err)
+		assert.Equal(t, 3, len(vals), "expected 3 events, got %d", len(vals))
+		assert.Equal(t, bsoncore.Document(placed), vals[0].Document(), "expected %v, got %v",
+			bsoncore.Document(placed), vals[0])
+		assert.Equal(t, bsoncore.Document(shipped), vals[1].Document(), "expected %v, got %v",
+			bsoncore.Document(shipped), vals[1])
+		assert.Equal(t, bsoncore.Document(placed), doc.Lookup("byid", "a").Document(), "expected %v, got %v",
+			bsoncore.Document(placed), doc.Lookup("byid", "a"))
+
+		var got eventLog
+		err = decode(reg, doc, &got)
+		assert.Nil(t, err, "decode error: %v", err)
+		assert.Equal(t, log, got, "expected %v, got %v", log, got)
+	})
+	t.Run("discriminator is not decoded into inline map", func(t *testing.T) {
+		reg := newRegistryBuilder().Build()
+		var got testEvent
+		err := decode(reg, placed, &got)
+		assert.Nil(t, err, "decode error: %v", err)
+		want := orderPlaced{Order: 1}
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+	})
+	t.Run("custom key", func(t *testing.T) {
+		reg := newRegistryBuilder().RegisterDiscriminatorKey(tEvent, "type").Build()
+		doc := encode(t, reg, eventLog{Last: orderPlaced{Order: 1}})
+		want := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "type", "OrderPlaced"),
+			bsoncore.AppendInt32Element(nil, "order", 1),
+		)
+		assert.Equal(t, bsoncore.Document(want), doc.Lookup("last").Document(), "expected %v, got %v",
+			bsoncore.Document(want), doc.Lookup("last"))
+	})
+	t.Run("builder changes after Build", func(t *testing.T) {
+		rb := newRegistryBuilder()
+		reg := rb.Build()
+		rb.RegisterDiscriminatorKey(tEvent, "type")
+		doc := encode(t, reg, eventLog{Last: orderPlaced{Order: 1}})
+		assert.Equal(t, bsoncore.Document(placed), doc.Lookup("last").Document(), "expected %v, got %v",
+			bsoncore.Document(placed), doc.Lookup("last"))
+	})
+	t.Run("unregistered type", func(t *testing.T) {
+		reg := newRegistryBuilder().Build()
+		var buf bytes.Buffer
+		vw, err := bsonrw.NewBSONValueWriter(&buf)
+		assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+		enc, err := reg.LookupEncoder(reflect.TypeOf(eventLog{}))
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		err = enc.EncodeValue(EncodeContext{Registry: reg}, vw, reflect.ValueOf(eventLog{Last: &orderPlaced{}}))
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.True(t, strings.Contains(err.Error(), "no discriminator value registered for *bsoncodec.orderPlaced"),
+			"unexpected error %v", err)
+	})
+	t.Run("decode errors", func(t *testing.T) {
+		last := func(elems ...[]byte) bsoncore.Document {
+			return bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "last", bsoncore.BuildDocumentFromElements(nil, elems...)))
+		}
+		testCases := []struct {
+			name   string
+			doc    bsoncore.Document
+			errMsg string
+		}{
+			{"missing discriminator", last(bsoncore.AppendInt32Element(nil, "order", 1)),
+				`missing discriminator "_t"`},
+			{"unknown discriminator", last(bsoncore.AppendStringElement(nil, "_t", "OrderCancelled")),
+				`unknown discriminator value "OrderCancelled"`},
+			{"discriminator not a string", last(bsoncore.AppendInt32Element(nil, "_t", 1)),
+				`discriminator "_t" must be a string`},
+			{"not a document", bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "last", 1)),
+				"cannot decode 32-bit integer into bsoncodec.testEvent"},
+		}
+		reg := newRegistryBuilder().Build()
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var got eventLog
+				err := decode(reg, tc.doc, &got)
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		reg := newRegistryBuilder().Build()
+		got := eventLog{Last: orderPlaced{}}
+		err := decode(reg, encode(t, reg, eventLog{}), &got)
+		assert.Nil(t, err, "decode error: %v", err)
+		assert.Nil(t, got.Last, "expected nil event, got %v", got.Last)
+	})
+}
@@ -29,7 +29,7 @@
 //
 // A Registry is an immutable store for ValueEncoders, ValueDecoders, and a type map. See the Registry type
 // documentation for examples of registering various custom encoders and decoders. A Registry can be constructed using a
-// RegistryBuilder, which handles three main types of codecs:
+// RegistryBuilder, which handles these main types of codecs:
 //
 // 1. Type encoders/decoders - These can be registered using the RegisterTypeEncoder and RegisterTypeDecoder methods.
 // The registered codec will be invoked when encoding/decoding a value whose type matches the registered type exactly.
@@ -55,6 +55,16 @@
 // registered reflect.Kind as long as the value's type doesn't match a registered type or hook encoder/decoder first.
 // These methods should be used to change the behavior for all values for a specific kind.
 //
+// 5. Discriminated types - These can be registered for an interface type using the RegisterDiscriminatedType method.
+// Values whose type is the interface are encoded as documents with a discriminator field identifying their concrete
+// type, and documents are decoded into the concrete type registered for their discriminator value. For example, the
+// following code would allow storing and loading different event types in an []Event field:
+//
+//		eventType := reflect.TypeOf((*Event)(nil)).Elem()
+//		registryBuilder.
+//			RegisterDiscriminatedType(eventType, "OrderPlaced", reflect.TypeOf(OrderPlaced{})).
+//			RegisterDiscriminatedType(eventType, "OrderShipped", reflect.TypeOf(OrderShipped{}))
+//
 // Registry Lookup Procedure
 //
 // When looking up an encoder in a Registry, the precedence rules are as follows:
@@ -66,6 +66,8 @@ type RegistryBuilder struct {
 	kindDecoders      map[reflect.Kind]ValueDecoder
 
 	typeMap map[bsontype.Type]reflect.Type
+
+	discriminators map[reflect.Type]*discriminator
 }
 
 // A Registry is used to store and retrieve codecs for types and interfaces. This type is the main
@@ -98,6 +100,8 @@ func NewRegistryBuilder() *RegistryBuilder {
 		kindDecoders: make(map[reflect.Kind]ValueDecoder),
 
 		typeMap: make(map[bsontype.Type]reflect.Type),
+
+		discriminators: make(map[reflect.Type]*discriminator),
 	}
 }
 
@@ -260,6 +264,59 @@ func (rb *RegistryBuilder) RegisterTypeMapEntry(bt bsontype.Type, rt reflect.Typ
 	return rb
 }
 
+// RegisterDiscriminatedType will register the concrete type rt under the discriminator value for the interface type
+// iface. Values of type iface are encoded as documents whose first field is a discriminator field holding the value
+// registered for their concrete type, and documents are decoded into values of iface by creating a value of the type
+// registered for their discriminator value. This applies wherever iface is used, including struct fields, slices and
+// maps with iface elements.
+//
+// The key of the discriminator field is DefaultDiscriminatorKey unless another key has been set with
+// RegisterDiscriminatorKey. Values of rt must be encoded as documents that don't have a field with the same key. A value
+// and a pointer to it are different concrete types, so to store pointers in iface, rt must be the pointer type.
+//
+// Registering discriminated types for iface replaces any encoder and decoder registered for it with RegisterTypeEncoder
+// and RegisterTypeDecoder. If iface is not an interface or rt doesn't implement it, this method will panic.
+func (rb *RegistryBuilder) RegisterDiscriminatedType(iface reflect.Type, value string, rt reflect.Type) *RegistryBuilder {
+	if iface.Kind() != reflect.Interface {
+		panicStr := fmt.Sprintf("RegisterDiscriminatedType expects a type with kind reflect.Interface, "+
+			"got type %s with kind %s", iface, iface.Kind())
+		panic(panicStr)
+	}
+	if !rt.Implements(iface) {
+		panic(fmt.Sprintf("RegisterDiscriminatedType expects a type implementing %s, got type %s", iface, rt))
+	}
+
+	d := rb.discriminator(iface)
+	if old, ok := d.types[value]; ok && d.values[old] == value {
+		delete(d.values, old)
+	}
+	d.types[value] = rt
+	d.values[rt] = value
+	return rb
+}
+
+// RegisterDiscriminatorKey will set the key of the discriminator field for the types registered for the interface type
+// iface with RegisterDiscriminatedType. If iface is not an interface, this method will panic.
+func (rb *RegistryBuilder) RegisterDiscriminatorKey(iface reflect.Type, key string) *RegistryBuilder {
+	if iface.Kind() != reflect.Interface {
+		panicStr := fmt.Sprintf("RegisterDiscriminatorKey expects a type with kind reflect.Interface, "+
+			"got type %s with kind %s", iface, iface.Kind())
+		panic(panicStr)
+	}
+
+	rb.discriminator(iface).key = key
+	return rb
+}
+
+func (rb *RegistryBuilder) discriminator(iface reflect.Type) *discriminator {
+	d, ok := rb.discriminators[iface]
+	if !ok {
+		d = newDiscriminator()
+		rb.discriminators[iface] = d
+	}
+	return d
+}
+
 // Build creates a Registry from the current state of this RegistryBuilder.
 func (rb *RegistryBuilder) Build() *Registry {
 	registry := new(Registry)
@@ -274,6 +331,12 @@ func (rb *RegistryBuilder) Build() *Registry {
 		registry.typeDecoders[t] = dec
 	}
 
+	for iface, d := range rb.discriminators {
+		codec := &discriminatorCodec{iface: iface, discriminator: d.copy()}
+		registry.typeEncoders[iface] = codec
+		registry.typeDecoders[iface] = codec
+	}
+
 	registry.interfaceEncoders = make([]interfaceValueEncoder, len(rb.interfaceEncoders))
 	copy(registry.interfaceEncoders, rb.interfaceEncoders)
 
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,6 +21,14 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
+// ErrUnknownField is the error wrapped by the DecodeError returned by the StructCodec when a document has a key that
+// doesn't match any field of the struct being decoded into and unknown fields are disallowed.
+var ErrUnknownField = errors.New("unknown field")
+
+// ErrMissingRequiredField is the error wrapped by the DecodeError returned by the StructCodec when a document doesn't
+// have the key of a struct field with the required struct tag option.
+var ErrMissingRequiredField = errors.New("missing required field")
+
 // DecodeError represents an error that occurs when unmarshalling BSON bytes into a native Go type.
 type DecodeError struct {
 	keys    []string
@@ -68,12 +77,19 @@ type StructCodec struct {
 	EncodeOmitDefaultStruct          bool
 	AllowUnexportedFields            bool
 	OverwriteDuplicatedInlinedFields bool
+	DisallowUnknownFields            bool
+	FieldNamer                       func(string) string
+	UseJSONStructTags                bool
 }
 
 var _ ValueEncoder = &StructCodec{}
 var _ ValueDecoder = &StructCodec{}
 
 // NewStructCodec returns a StructCodec that uses p for struct tag parsing.
+//
+// If the UseJSONStructTags option is set, the json struct tag of a field without a bson struct tag is given to p as its
+// bson struct tag. If the FieldNamer option is set, it names the fields whose key p derives from the field name, such
+// as fields whose struct tag has no key; keys that p takes from a struct tag are kept.
 func NewStructCodec(p StructTagParser, opts ...*bsonoptions.StructCodecOptions) (*StructCodec, error) {
 	if p == nil {
 		return nil, errors.New("a StructTagParser must be provided to NewStructCodec")
@@ -101,6 +117,15 @@ func NewStructCodec(p StructTagParser, opts ...*bsonoptions.StructCodecOptions)
 	if structOpt.AllowUnexportedFields != nil {
 		codec.AllowUnexportedFields = *structOpt.AllowUnexportedFields
 	}
+	if structOpt.DisallowUnknownFields != nil {
+		codec.DisallowUnknownFields = *structOpt.DisallowUnknownFields
+	}
+	if structOpt.FieldNamer != nil {
+		codec.FieldNamer = structOpt.FieldNamer
+	}
+	if structOpt.UseJSONStructTags != nil {
+		codec.UseJSONStructTags = *structOpt.UseJSONStructTags
+	}
 
 	return codec, nil
 }
@@ -265,6 +290,11 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		return err
 	}
 
+	var found map[string]bool
+	if len(sd.required) > 0 {
+		found = make(map[string]bool, len(sd.required))
+	}
+
 	for {
 		name, vr, err := dr.ReadElement()
 		if err == bsonrw.ErrEOD {
@@ -284,8 +314,9 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 
 		if !exists {
 			if sd.inlineMap < 0 {
-				// The encoding/json package requires a flag to return on error for non-existent fields.
-				// This functionality seems appropriate for the struct codec.
+				if sc.DisallowUnknownFields || r.DisallowUnknownFields {
+					return newDecodeError(name, ErrUnknownField)
+				}
 				err = vr.Skip()
 				if err != nil {
 					return err
@@ -326,7 +357,11 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		}
 		field = field.Addr()
 
-		dctx := DecodeContext{Registry: r.Registry, Truncate: fd.truncate || r.Truncate}
+		dctx := DecodeContext{
+			Registry:              r.Registry,
+			Truncate:              fd.truncate || r.Truncate,
+			DisallowUnknownFields: r.DisallowUnknownFields,
+		}
 		if fd.decoder == nil {
 			return newDecodeError(fd.name, ErrNoDecoder{Type: field.Elem().Type()})
 		}
@@ -335,6 +370,15 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, val r
 		if err != nil {
 			return newDecodeError(fd.name, err)
 		}
+		if fd.required {
+			found[fd.name] = true
+		}
+	}
+
+	for _, name := range sd.required {
+		if !found[name] {
+			return newDecodeError(name, ErrMissingRequiredField)
+		}
 	}
 
 	return nil
@@ -392,6 +436,7 @@ type structDescription struct {
 	fl        []fieldDescription
 	inlineMap int
 	inline    bool
+	required  []string // BSON key names of the required fields
 }
 
 type fieldDescription struct {
@@ -401,6 +446,7 @@ type fieldDescription struct {
 	omitEmpty bool
 	minSize   bool
 	truncate  bool
+	required  bool
 	inline    []int
 	encoder   ValueEncoder
 	decoder   ValueDecoder
@@ -477,7 +523,7 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 			decoder:   decoder,
 		}
 
-		stags, err := sc.parser.ParseStructTags(sf)
+		stags, err := sc.parseStructTags(sf)
 		if err != nil {
 			return nil, err
 		}
@@ -488,6 +534,7 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 		description.omitEmpty = stags.OmitEmpty
 		description.minSize = stags.MinSize
 		description.truncate = stags.Truncate
+		description.required = stags.Required
 
 		if stags.Inline {
 			sd.inline = true
@@ -567,6 +614,11 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 	}
 
 	sort.Sort(byIndex(sd.fl))
+	for _, fd := range sd.fl {
+		if fd.required {
+			sd.required = append(sd.required, fd.name)
+		}
+	}
 
 	sc.l.Lock()
 	sc.cache[t] = sd
@@ -575,6 +627,35 @@ func (sc *StructCodec) describeStruct(r *Registry, t reflect.Type) (*structDescr
 	return sd, nil
 }
 
+// parseStructTags returns the struct tags of sf parsed by the parser of sc, with the UseJSONStructTags and FieldNamer
+// options applied.
+func (sc *StructCodec) parseStructTags(sf reflect.StructField) (StructTags, error) {
+	if sc.UseJSONStructTags {
+		if _, ok := sf.Tag.Lookup("bson"); !ok {
+			if tag, ok := sf.Tag.Lookup("json"); ok {
+				sf.Tag = reflect.StructTag("bson:" + strconv.Quote(tag) + " " + string(sf.Tag))
+			}
+		}
+	}
+
+	stags, err := sc.parser.ParseStructTags(sf)
+	if err != nil || stags.Skip || sc.FieldNamer == nil {
+		return stags, err
+	}
+
+	// The parser derived the key from the field name if the key changes along with the name.
+	renamed := sf
+	renamed.Name += "_"
+	rtags, err := sc.parser.ParseStructTags(renamed)
+	if err != nil {
+		return StructTags{}, err
+	}
+	if rtags.Name != stags.Name {
+		stags.Name = sc.FieldNamer(sf.Name)
+	}
+	return stags, nil
+}
+
 // dominantField looks through the fields, all of which are known to
 // have the same name, to find the single field that dominates the
 // others using Go's inlining rules. If there are multiple top-level
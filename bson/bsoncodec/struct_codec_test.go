@@ -7,10 +7,13 @@
 package bsoncodec
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 )
 
 func TestZeoerInterfaceUsedByDecoder(t *testing.T) {
@@ -45,3 +48,123 @@ func TestZeoerInterfaceUsedByDecoder(t *testing.T) {
 	var zp *zeroTest
 	assert.True(t, enc.isZero(zp))
 }
+
+func TestStructCodecFieldNaming(t *testing.T) {
+	type naming struct {
+		UserID    int
+		FirstName string `json:"first"`
+		LastName  string `bson:"last" json:"surname"`
+		Nickname  string `bson:",omitempty" json:"nick"`
+		Skipped   string `json:"-"`
+	}
+	testCases := []struct {
+		name   string
+		parser StructTagParser
+		opts   *bsonoptions.StructCodecOptions
+		keys   []string
+	}{
+		{"default", DefaultStructTagParser, nil, []string{"userid", "firstname", "last", "nickname", "skipped"}},
+		{"camel case", DefaultStructTagParser, bsonoptions.StructCodec().SetFieldNamer(CamelCaseFieldName),
+			[]string{"userID", "firstName", "last", "nickname", "skipped"}},
+		{"snake case", DefaultStructTagParser, bsonoptions.StructCodec().SetFieldNamer(SnakeCaseFieldName),
+			[]string{"user_id", "first_name", "last", "nickname", "skipped"}},
+		{"exact", DefaultStructTagParser, bsonoptions.StructCodec().SetFieldNamer(ExactFieldName),
+			[]string{"UserID", "FirstName", "last", "Nickname", "Skipped"}},
+		{"custom", DefaultStructTagParser, bsonoptions.StructCodec().SetFieldNamer(strings.ToUpper),
+			[]string{"USERID", "FIRSTNAME", "last", "NICKNAME", "SKIPPED"}},
+		{"json struct tags", DefaultStructTagParser, bsonoptions.StructCodec().SetUseJSONStructTags(true),
+			[]string{"userid", "first", "last", "nickname"}},
+		{"json struct tags and camel case", DefaultStructTagParser,
+			bsonoptions.StructCodec().SetUseJSONStructTags(true).SetFieldNamer(CamelCaseFieldName),
+			[]string{"userID", "first", "last", "nickname"}},
+		{"json fallback parser and camel case", JSONFallbackStructTagParser,
+			bsonoptions.StructCodec().SetFieldNamer(CamelCaseFieldName),
+			[]string{"userID", "first", "last", "nickname"}},
+		{"naming parser", NewStructTagParser(SnakeCaseFieldName, true), nil,
+			[]string{"user_id", "first", "last", "nickname"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := NewStructCodec(tc.parser, tc.opts)
+			assert.Nil(t, err)
+			sd, err := sc.describeStruct(buildDefaultRegistry(), reflect.TypeOf(naming{}))
+			assert.Nil(t, err)
+
+			var keys []string
+			for _, fd := range sd.fl {
+				keys = append(keys, fd.name)
+			}
+			assert.Equal(t, tc.keys, keys)
+		})
+	}
+
+	t.Run("NewStructTagParser uses the keys of the codec", func(t *testing.T) {
+		sc, err := NewStructCodec(DefaultStructTagParser,
+			bsonoptions.StructCodec().SetFieldNamer(SnakeCaseFieldName).SetUseJSONStructTags(true))
+		assert.Nil(t, err)
+		sd, err := sc.describeStruct(buildDefaultRegistry(), reflect.TypeOf(naming{}))
+		assert.Nil(t, err)
+
+		var want, got []string
+		for _, fd := range sd.fl {
+			want = append(want, fd.name)
+		}
+		rt := reflect.TypeOf(naming{})
+		for i := 0; i < rt.NumField(); i++ {
+			stags, err := NewStructTagParser(SnakeCaseFieldName, true).ParseStructTags(rt.Field(i))
+			assert.Nil(t, err)
+			if !stags.Skip {
+				got = append(got, stags.Name)
+			}
+		}
+		assert.Equal(t, want, got)
+	})
+	t.Run("naming options with a custom parser", func(t *testing.T) {
+		// custom takes keys from the mongo struct tag and otherwise uses the field name with a prefix.
+		custom := StructTagParserFunc(func(sf reflect.StructField) (StructTags, error) {
+			if key, ok := sf.Tag.Lookup("mongo"); ok {
+				return StructTags{Name: key, OmitEmpty: true}, nil
+			}
+			if key, ok := sf.Tag.Lookup("bson"); ok {
+				return StructTags{Name: key, OmitEmpty: true}, nil
+			}
+			return StructTags{Name: "f_" + sf.Name, OmitEmpty: true}, nil
+		})
+		type fields struct {
+			UserID    int
+			FirstName string `json:"first"`
+			LastName  string `mongo:"last"`
+		}
+		testCases := []struct {
+			name string
+			opts *bsonoptions.StructCodecOptions
+			keys []string
+		}{
+			{"no options", nil, []string{"f_UserID", "f_FirstName", "last"}},
+			{"json struct tags disabled", bsonoptions.StructCodec().SetUseJSONStructTags(false),
+				[]string{"f_UserID", "f_FirstName", "last"}},
+			{"json struct tags", bsonoptions.StructCodec().SetUseJSONStructTags(true),
+				[]string{"f_UserID", "first", "last"}},
+			{"camel case", bsonoptions.StructCodec().SetFieldNamer(CamelCaseFieldName),
+				[]string{"userID", "firstName", "last"}},
+			{"json struct tags and camel case",
+				bsonoptions.StructCodec().SetUseJSONStructTags(true).SetFieldNamer(CamelCaseFieldName),
+				[]string{"userID", "first", "last"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				sc, err := NewStructCodec(custom, tc.opts)
+				assert.Nil(t, err)
+				sd, err := sc.describeStruct(buildDefaultRegistry(), reflect.TypeOf(fields{}))
+				assert.Nil(t, err)
+
+				var keys []string
+				for _, fd := range sd.fl {
+					keys = append(keys, fd.name)
+					assert.True(t, fd.omitEmpty, "expected the parser's omitempty for %s", fd.name)
+				}
+				assert.Equal(t, tc.keys, keys)
+			})
+		}
+	})
+}
@@ -9,6 +9,7 @@ package bsoncodec
 import (
 	"reflect"
 	"strings"
+	"unicode"
 )
 
 // StructTagParser returns the struct tags for a given struct field.
@@ -50,6 +51,9 @@ func (stpf StructTagParserFunc) ParseStructTags(sf reflect.StructField) (StructT
 //     Skip       This struct field should be skipped. This is usually denoted by parsing a "-"
 //                for the name.
 //
+//     Required   When unmarshaling, return an error if the document doesn't have the key of the
+//                field.
+//
 // TODO(skriptble): Add tags for undefined as nil and for null as nil.
 type StructTags struct {
 	Name      string
@@ -58,6 +62,7 @@ type StructTags struct {
 	Truncate  bool
 	Inline    bool
 	Skip      bool
+	Required  bool
 }
 
 // DefaultStructTagParser is the StructTagParser used by the StructCodec by default.
@@ -86,8 +91,31 @@ type StructTags struct {
 // value consisting entirely of '-' will return a StructTags with Skip true and
 // the remaining fields will be their default values.
 var DefaultStructTagParser StructTagParserFunc = func(sf reflect.StructField) (StructTags, error) {
-	key := strings.ToLower(sf.Name)
+	return parseStructTags(sf, false, LowerCaseFieldName)
+}
+
+// NewStructTagParser returns a StructTagParserFunc that parses the bson struct tag of a field like
+// DefaultStructTagParser, falling back to the json struct tag like JSONFallbackStructTagParser if jsonFallback is true.
+// The key of a field without a key in its struct tag is the result of calling namer with the field name, or the
+// lowercased field name if namer is nil. For example, NewStructTagParser(CamelCaseFieldName, false) returns a parser
+// that uses the same keys as a StructCodec created with DefaultStructTagParser and the CamelCaseFieldName FieldNamer.
+func NewStructTagParser(namer func(string) string, jsonFallback bool) StructTagParserFunc {
+	if namer == nil {
+		namer = LowerCaseFieldName
+	}
+	return func(sf reflect.StructField) (StructTags, error) {
+		return parseStructTags(sf, jsonFallback, namer)
+	}
+}
+
+// parseStructTags parses the bson struct tag of sf, or its json struct tag if jsonFallback is true and sf doesn't have a
+// bson struct tag. If the tag doesn't have a key, the key is the result of calling namer with the field name.
+func parseStructTags(sf reflect.StructField, jsonFallback bool, namer func(string) string) (StructTags, error) {
+	key := namer(sf.Name)
 	tag, ok := sf.Tag.Lookup("bson")
+	if !ok && jsonFallback {
+		tag, ok = sf.Tag.Lookup("json")
+	}
 	if !ok && !strings.Contains(string(sf.Tag), ":") && len(sf.Tag) > 0 {
 		tag = string(sf.Tag)
 	}
@@ -114,6 +142,8 @@ func parseTags(key string, tag string) (StructTags, error) {
 			st.Truncate = true
 		case "inline":
 			st.Inline = true
+		case "required":
+			st.Required = true
 		}
 	}
 
@@ -126,14 +156,52 @@ func parseTags(key string, tag string) (StructTags, error) {
 // but will also fallback to parsing the json tag instead on a field where the
 // bson tag isn't available.
 var JSONFallbackStructTagParser StructTagParserFunc = func(sf reflect.StructField) (StructTags, error) {
-	key := strings.ToLower(sf.Name)
-	tag, ok := sf.Tag.Lookup("bson")
-	if !ok {
-		tag, ok = sf.Tag.Lookup("json")
-	}
-	if !ok && !strings.Contains(string(sf.Tag), ":") && len(sf.Tag) > 0 {
-		tag = string(sf.Tag)
+	return parseStructTags(sf, true, LowerCaseFieldName)
+}
+
+// LowerCaseFieldName returns the BSON key for a struct field by lowercasing its name. This is the naming strategy used
+// by DefaultStructTagParser and JSONFallbackStructTagParser. For example, "UserID" becomes "userid".
+func LowerCaseFieldName(name string) string {
+	return strings.ToLower(name)
+}
+
+// ExactFieldName returns the name of a struct field unchanged as its BSON key. For example, "UserID" stays "UserID".
+func ExactFieldName(name string) string {
+	return name
+}
+
+// CamelCaseFieldName returns the BSON key for a struct field by lowercasing the leading upper case letters of its
+// name, leaving the last of them in upper case if it starts the next word. For example, "UserID" becomes "userID" and
+// "HTTPServer" becomes "httpServer".
+func CamelCaseFieldName(name string) string {
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(r)
 	}
+	return string(runes)
+}
 
-	return parseTags(key, tag)
+// SnakeCaseFieldName returns the BSON key for a struct field by splitting its name into lower case words separated by
+// underscores. A new word starts at an upper case letter that follows a lower case letter or a digit, or that is
+// followed by a lower case letter. For example, "UserID" becomes "user_id" and "HTTPServer" becomes "http_server".
+func SnakeCaseFieldName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && runes[i-1] != '_' {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
 }
@@ -158,3 +158,34 @@ func TestStructTagParsers(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldNamers(t *testing.T) {
+	testCases := []struct {
+		name  string
+		camel string
+		snake string
+	}{
+		{"Name", "name", "name"},
+		{"UserID", "userID", "user_id"},
+		{"ID", "id", "id"},
+		{"HTTPServer", "httpServer", "http_server"},
+		{"Address2", "address2", "address2"},
+		{"Address2Line", "address2Line", "address2_line"},
+		{"Already_Snake", "already_Snake", "already_snake"},
+		{"x", "x", "x"},
+		{"ÉtéÀ", "étéÀ", "été_à"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CamelCaseFieldName(tc.name); got != tc.camel {
+				t.Errorf("expected camel case %q, got %q", tc.camel, got)
+			}
+			if got := SnakeCaseFieldName(tc.name); got != tc.snake {
+				t.Errorf("expected snake case %q, got %q", tc.snake, got)
+			}
+			if got := ExactFieldName(tc.name); got != tc.name {
+				t.Errorf("expected exact name %q, got %q", tc.name, got)
+			}
+		})
+	}
+}
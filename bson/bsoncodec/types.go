@@ -8,6 +8,7 @@ package bsoncodec
 
 import (
 	"encoding/json"
+	"math/big"
 	"net/url"
 	"reflect"
 	"time"
@@ -28,6 +29,8 @@ var tByteSlice = reflect.TypeOf([]byte(nil))
 var tByte = reflect.TypeOf(byte(0x00))
 var tURL = reflect.TypeOf(url.URL{})
 var tJSONNumber = reflect.TypeOf(json.Number(""))
+var tBigFloat = reflect.TypeOf(big.Float{})
+var tBigRat = reflect.TypeOf(big.Rat{})
 
 var tValueMarshaler = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
 var tValueUnmarshaler = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
@@ -47,6 +50,7 @@ var tJavaScript = reflect.TypeOf(primitive.JavaScript(""))
 var tSymbol = reflect.TypeOf(primitive.Symbol(""))
 var tTimestamp = reflect.TypeOf(primitive.Timestamp{})
 var tDecimal = reflect.TypeOf(primitive.Decimal128{})
+var tUUID = reflect.TypeOf(primitive.UUID{})
 var tMinKey = reflect.TypeOf(primitive.MinKey{})
 var tMaxKey = reflect.TypeOf(primitive.MaxKey{})
 var tD = reflect.TypeOf(primitive.D{})
@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UUIDCodec is the Codec used for primitive.UUID values. It can also be registered for other UUID types that are
+// defined as [16]byte, such as those from third-party UUID packages:
+//
+//	rb.RegisterCodec(reflect.TypeOf(uuid.UUID{}), bsoncodec.NewUUIDCodec())
+//
+// UUIDs are encoded as BSON binary values using the configured representation. Decoding accepts binary subtype 4,
+// binary subtype 3 if a legacy representation is configured, and strings in the canonical UUID form.
+type UUIDCodec struct {
+	Representation primitive.UUIDRepresentation
+}
+
+var (
+	defaultUUIDCodec = NewUUIDCodec()
+
+	_ ValueCodec  = defaultUUIDCodec
+	_ typeDecoder = defaultUUIDCodec
+)
+
+// NewUUIDCodec returns a UUIDCodec with options opts.
+func NewUUIDCodec(opts ...*bsonoptions.UUIDCodecOptions) *UUIDCodec {
+	uuidOpt := bsonoptions.MergeUUIDCodecOptions(opts...)
+
+	codec := UUIDCodec{}
+	if uuidOpt.Representation != nil {
+		codec.Representation = *uuidOpt.Representation
+	}
+	return &codec
+}
+
+// RegisterUUIDCodec registers a UUIDCodec with options opts as the encoder and decoder for primitive.UUID on rb. This
+// can be used to read and write UUIDs in the format of a legacy driver:
+//
+//	rb := bson.NewRegistryBuilder()
+//	bsoncodec.RegisterUUIDCodec(rb, bsonoptions.UUIDCodec().SetRepresentation(primitive.JavaLegacyUUIDRepresentation))
+func RegisterUUIDCodec(rb *RegistryBuilder, opts ...*bsonoptions.UUIDCodecOptions) *RegistryBuilder {
+	return rb.RegisterCodec(tUUID, NewUUIDCodec(opts...))
+}
+
+// isUUIDType reports whether t is defined as [16]byte, so values of t can be converted to and from primitive.UUID.
+func isUUIDType(t reflect.Type) bool {
+	return t.ConvertibleTo(tUUID) && t.Kind() == reflect.Array
+}
+
+func (uc *UUIDCodec) decodeType(dc DecodeContext, vr bsonrw.ValueReader, t reflect.Type) (reflect.Value, error) {
+	if !isUUIDType(t) {
+		return emptyValue, ValueDecoderError{
+			Name:     "UUIDDecodeValue",
+			Types:    []reflect.Type{tUUID},
+			Received: reflect.Zero(t),
+		}
+	}
+
+	var u primitive.UUID
+	switch vrType := vr.Type(); vrType {
+	case bsontype.Binary:
+		data, subtype, err := vr.ReadBinary()
+		if err != nil {
+			return emptyValue, err
+		}
+		u, err = primitive.UUIDFromBinary(primitive.Binary{Subtype: subtype, Data: data}, uc.Representation)
+		if err != nil {
+			return emptyValue, err
+		}
+	case bsontype.String:
+		str, err := vr.ReadString()
+		if err != nil {
+			return emptyValue, err
+		}
+		u, err = primitive.UUIDFromString(str)
+		if err != nil {
+			return emptyValue, err
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return emptyValue, err
+		}
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return emptyValue, err
+		}
+	default:
+		return emptyValue, fmt.Errorf("cannot decode %v into a UUID", vrType)
+	}
+
+	return reflect.ValueOf(u).Convert(t), nil
+}
+
+// DecodeValue is the ValueDecoderFunc for primitive.UUID and other [16]byte UUID types.
+func (uc *UUIDCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || !isUUIDType(val.Type()) {
+		return ValueDecoderError{Name: "UUIDDecodeValue", Types: []reflect.Type{tUUID}, Received: val}
+	}
+
+	elem, err := uc.decodeType(dc, vr, val.Type())
+	if err != nil {
+		return err
+	}
+
+	val.Set(elem)
+	return nil
+}
+
+// EncodeValue is the ValueEncoderFunc for primitive.UUID and other [16]byte UUID types.
+func (uc *UUIDCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || !isUUIDType(val.Type()) {
+		return ValueEncoderError{Name: "UUIDEncodeValue", Types: []reflect.Type{tUUID}, Received: val}
+	}
+
+	u := val.Convert(tUUID).Interface().(primitive.UUID)
+	b := primitive.NewBinaryFromUUID(u, uc.Representation)
+	return vw.WriteBinaryWithSubtype(b.Data, b.Subtype)
+}
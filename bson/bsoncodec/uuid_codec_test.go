@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+type testUUID [16]byte
+
+func TestUUIDCodec(t *testing.T) {
+	u, err := primitive.UUIDFromString("00112233-4455-6677-8899-aabbccddeeff")
+	assert.Nil(t, err, "UUIDFromString error: %v", err)
+	javaLegacy, err := hex.DecodeString("7766554433221100ffeeddccbbaa9988")
+	assert.Nil(t, err, "DecodeString error: %v", err)
+
+	binaryValue := func(subtype byte, data []byte) bsonrw.ValueReader {
+		return bsonrw.NewBSONValueReader(bsontype.Binary, bsoncore.AppendBinary(nil, subtype, data))
+	}
+	java := bsonoptions.UUIDCodec().SetRepresentation(primitive.JavaLegacyUUIDRepresentation)
+
+	t.Run("DecodeValue", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			opts     *bsonoptions.UUIDCodecOptions
+			vr       bsonrw.ValueReader
+			typ      reflect.Type
+			expected interface{}
+			hasError bool
+		}{
+			{"subtype 4", nil, binaryValue(4, u[:]), tUUID, u, false},
+			{"subtype 4 with legacy representation", java, binaryValue(4, u[:]), tUUID, u, false},
+			{"subtype 3", java, binaryValue(3, javaLegacy), tUUID, u, false},
+			{"subtype 3 with standard representation", nil, binaryValue(3, javaLegacy), tUUID, nil, true},
+			{"string", nil, bsonrw.NewBSONValueReader(bsontype.String, bsoncore.AppendString(nil, u.String())), tUUID, u,
+				false},
+			{"null", nil, bsonrw.NewBSONValueReader(bsontype.Null, nil), tUUID, primitive.NilUUID, false},
+			{"other [16]byte type", java, binaryValue(3, javaLegacy), reflect.TypeOf(testUUID{}), testUUID(u), false},
+			{"wrong length", nil, binaryValue(4, u[:15]), tUUID, nil, true},
+			{"int32", nil, bsonrw.NewBSONValueReader(bsontype.Int32, bsoncore.AppendInt32(nil, 1)), tUUID, nil, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				val := reflect.New(tc.typ).Elem()
+				err := NewUUIDCodec(tc.opts).DecodeValue(DecodeContext{}, tc.vr, val)
+				if tc.hasError {
+					assert.NotNil(t, err, "expected error, got nil")
+					return
+				}
+				assert.Nil(t, err, "DecodeValue error: %v", err)
+				assert.Equal(t, tc.expected, val.Interface(), "expected %v, got %v", tc.expected, val.Interface())
+			})
+		}
+	})
+	t.Run("EncodeValue", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			opts    *bsonoptions.UUIDCodecOptions
+			val     interface{}
+			subtype byte
+			data    []byte
+		}{
+			{"default", nil, u, 4, u[:]},
+			{"java legacy", java, u, 3, javaLegacy},
+			{"other [16]byte type", java, testUUID(u), 3, javaLegacy},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				buf := new(bytes.Buffer)
+				vw, err := bsonrw.NewBSONValueWriter(buf)
+				assert.Nil(t, err, "NewBSONValueWriter error: %v", err)
+				dw, err := vw.WriteDocument()
+				assert.Nil(t, err, "WriteDocument error: %v", err)
+				evw, err := dw.WriteDocumentElement("u")
+				assert.Nil(t, err, "WriteDocumentElement error: %v", err)
+
+				err = NewUUIDCodec(tc.opts).EncodeValue(EncodeContext{}, evw, reflect.ValueOf(tc.val))
+				assert.Nil(t, err, "EncodeValue error: %v", err)
+				err = dw.WriteDocumentEnd()
+				assert.Nil(t, err, "WriteDocumentEnd error: %v", err)
+
+				subtype, data, ok := bsoncore.Document(buf.Bytes()).Lookup("u").BinaryOK()
+				assert.True(t, ok, "expected a binary value to be written")
+				assert.Equal(t, tc.subtype, subtype, "expected subtype %v, got %v", tc.subtype, subtype)
+				assert.Equal(t, tc.data, data, "expected data %x, got %x", tc.data, data)
+			})
+		}
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		val := reflect.ValueOf([]byte{})
+		err := defaultUUIDCodec.EncodeValue(EncodeContext{}, nil, val)
+		expected := ValueEncoderError{Name: "UUIDEncodeValue", Types: []reflect.Type{tUUID}, Received: val}
+		assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+	})
+	t.Run("RegisterUUIDCodec", func(t *testing.T) {
+		rb := NewRegistryBuilder()
+		DefaultValueEncoders{}.RegisterDefaultEncoders(rb)
+		DefaultValueDecoders{}.RegisterDefaultDecoders(rb)
+
+		enc, err := rb.Build().LookupEncoder(tUUID)
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		assert.Equal(t, defaultUUIDCodec, enc, "expected the default UUID codec, got %v", enc)
+
+		reg := RegisterUUIDCodec(rb, java).Build()
+		enc, err = reg.LookupEncoder(tUUID)
+		assert.Nil(t, err, "LookupEncoder error: %v", err)
+		codec, ok := enc.(*UUIDCodec)
+		assert.True(t, ok, "expected a *UUIDCodec, got %T", enc)
+		assert.Equal(t, primitive.JavaLegacyUUIDRepresentation, codec.Representation,
+			"expected representation %v, got %v", primitive.JavaLegacyUUIDRepresentation, codec.Representation)
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonoptions
+
+import "math/big"
+
+// BigNumberCodecOptions represents all possible options for big.Float and big.Rat encoding and decoding.
+type BigNumberCodecOptions struct {
+	// Specifies the precision of decoded big.Float values. Defaults to 0, which uses 128 bits for BSON Decimal128
+	// values and the precision needed to represent doubles and integers exactly for other BSON types.
+	Precision *uint
+
+	// Specifies how values that cannot be represented exactly as a BSON Decimal128 are rounded when encoding. Defaults
+	// to big.ToNearestEven.
+	RoundingMode *big.RoundingMode
+}
+
+// BigNumberCodec creates a new *BigNumberCodecOptions
+func BigNumberCodec() *BigNumberCodecOptions {
+	return &BigNumberCodecOptions{}
+}
+
+// SetPrecision specifies the precision of decoded big.Float values. Defaults to 0, which uses 128 bits for BSON
+// Decimal128 values and the precision needed to represent doubles and integers exactly for other BSON types.
+func (b *BigNumberCodecOptions) SetPrecision(prec uint) *BigNumberCodecOptions {
+	b.Precision = &prec
+	return b
+}
+
+// SetRoundingMode specifies how values that cannot be represented exactly as a BSON Decimal128 are rounded when
+// encoding. Defaults to big.ToNearestEven.
+func (b *BigNumberCodecOptions) SetRoundingMode(mode big.RoundingMode) *BigNumberCodecOptions {
+	b.RoundingMode = &mode
+	return b
+}
+
+// MergeBigNumberCodecOptions combines the given *BigNumberCodecOptions into a single *BigNumberCodecOptions in a last
+// one wins fashion.
+func MergeBigNumberCodecOptions(opts ...*BigNumberCodecOptions) *BigNumberCodecOptions {
+	b := BigNumberCodec()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Precision != nil {
+			b.Precision = opt.Precision
+		}
+		if opt.RoundingMode != nil {
+			b.RoundingMode = opt.RoundingMode
+		}
+	}
+
+	return b
+}
@@ -10,11 +10,14 @@ var defaultOverwriteDuplicatedInlinedFields = true
 
 // StructCodecOptions represents all possible options for struct encoding and decoding.
 type StructCodecOptions struct {
-	DecodeZeroStruct                 *bool // Specifies if structs should be zeroed before decoding into them. Defaults to false.
-	DecodeDeepZeroInline             *bool // Specifies if structs should be recursively zeroed when a inline value is decoded. Defaults to false.
-	EncodeOmitDefaultStruct          *bool // Specifies if default structs should be considered empty by omitempty. Defaults to false.
-	AllowUnexportedFields            *bool // Specifies if unexported fields should be marshaled/unmarshaled. Defaults to false.
-	OverwriteDuplicatedInlinedFields *bool // Specifies if fields in inlined structs can be overwritten by higher level struct fields with the same key. Defaults to true.
+	DecodeZeroStruct                 *bool               // Specifies if structs should be zeroed before decoding into them. Defaults to false.
+	DecodeDeepZeroInline             *bool               // Specifies if structs should be recursively zeroed when a inline value is decoded. Defaults to false.
+	EncodeOmitDefaultStruct          *bool               // Specifies if default structs should be considered empty by omitempty. Defaults to false.
+	AllowUnexportedFields            *bool               // Specifies if unexported fields should be marshaled/unmarshaled. Defaults to false.
+	OverwriteDuplicatedInlinedFields *bool               // Specifies if fields in inlined structs can be overwritten by higher level struct fields with the same key. Defaults to true.
+	DisallowUnknownFields            *bool               // Specifies if decoding should error on keys that don't match a struct field. Defaults to false.
+	FieldNamer                       func(string) string // Specifies how keys are derived from the names of fields without a key in their struct tag. Defaults to lowercasing the name.
+	UseJSONStructTags                *bool               // Specifies if the json struct tag should be used for fields without a bson struct tag. Defaults to false.
 }
 
 // StructCodec creates a new *StructCodecOptions
@@ -56,6 +59,29 @@ func (t *StructCodecOptions) SetAllowUnexportedFields(b bool) *StructCodecOption
 	return t
 }
 
+// SetDisallowUnknownFields specifies if decoding should return an error for document keys that don't match any field of
+// the struct being decoded into. Keys are always allowed if the struct has an inline map. Defaults to false.
+func (t *StructCodecOptions) SetDisallowUnknownFields(b bool) *StructCodecOptions {
+	t.DisallowUnknownFields = &b
+	return t
+}
+
+// SetFieldNamer specifies how the BSON key of a struct field without a key in its struct tag is derived from the field
+// name. The bsoncodec package provides the LowerCaseFieldName, CamelCaseFieldName, SnakeCaseFieldName, and
+// ExactFieldName strategies, but any function can be used. Defaults to the naming of the codec's struct tag parser,
+// which is bsoncodec.LowerCaseFieldName for the default parser.
+func (t *StructCodecOptions) SetFieldNamer(f func(string) string) *StructCodecOptions {
+	t.FieldNamer = f
+	return t
+}
+
+// SetUseJSONStructTags specifies if the json struct tag should be used for struct fields that don't have a bson struct
+// tag. Defaults to false.
+func (t *StructCodecOptions) SetUseJSONStructTags(b bool) *StructCodecOptions {
+	t.UseJSONStructTags = &b
+	return t
+}
+
 // MergeStructCodecOptions combines the given *StructCodecOptions into a single *StructCodecOptions in a last one wins fashion.
 func MergeStructCodecOptions(opts ...*StructCodecOptions) *StructCodecOptions {
 	s := &StructCodecOptions{
@@ -81,6 +107,15 @@ func MergeStructCodecOptions(opts ...*StructCodecOptions) *StructCodecOptions {
 		if opt.AllowUnexportedFields != nil {
 			s.AllowUnexportedFields = opt.AllowUnexportedFields
 		}
+		if opt.DisallowUnknownFields != nil {
+			s.DisallowUnknownFields = opt.DisallowUnknownFields
+		}
+		if opt.FieldNamer != nil {
+			s.FieldNamer = opt.FieldNamer
+		}
+		if opt.UseJSONStructTags != nil {
+			s.UseJSONStructTags = opt.UseJSONStructTags
+		}
 	}
 
 	return s
@@ -0,0 +1,44 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonoptions
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// UUIDCodecOptions represents all possible options for UUID encoding and decoding.
+type UUIDCodecOptions struct {
+	// Specifies how UUIDs are stored as BSON binary values. Encoding always uses this representation, and decoding uses
+	// it to read binary subtype 3 values written by legacy drivers. Defaults to primitive.StandardUUIDRepresentation.
+	Representation *primitive.UUIDRepresentation
+}
+
+// UUIDCodec creates a new *UUIDCodecOptions
+func UUIDCodec() *UUIDCodecOptions {
+	return &UUIDCodecOptions{}
+}
+
+// SetRepresentation specifies how UUIDs are stored as BSON binary values. Encoding always uses this representation,
+// and decoding uses it to read binary subtype 3 values written by legacy drivers. Defaults to
+// primitive.StandardUUIDRepresentation.
+func (u *UUIDCodecOptions) SetRepresentation(r primitive.UUIDRepresentation) *UUIDCodecOptions {
+	u.Representation = &r
+	return u
+}
+
+// MergeUUIDCodecOptions combines the given *UUIDCodecOptions into a single *UUIDCodecOptions in a last one wins fashion.
+func MergeUUIDCodecOptions(opts ...*UUIDCodecOptions) *UUIDCodecOptions {
+	u := UUIDCodec()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Representation != nil {
+			u.Representation = opt.Representation
+		}
+	}
+
+	return u
+}
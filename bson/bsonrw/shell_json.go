@@ -0,0 +1,604 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shellTimeFormats are the formats accepted by ISODate and Date. Times without a time zone are UTC.
+var shellTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// NewShellJSONValueReader creates a new ValueReader that reads documents written in the syntax of the mongo shell,
+// such as {_id: ObjectId("5e1f6c...")}. In addition to extended JSON, it accepts unquoted keys, single quoted
+// strings, trailing commas, regular expression literals and the shell constructors ObjectId, ISODate, Date,
+// NumberLong, NumberInt, NumberDecimal, UUID, BinData, Timestamp, MinKey and MaxKey, optionally preceded by new. The
+// input is translated to canonical extended JSON, so each value is read as the same BSON as its canonical extended
+// JSON representation.
+//
+// Unlike NewExtJSONValueReader, the whole of r is read and translated before the ValueReader is returned.
+func NewShellJSONValueReader(r io.Reader) (ValueReader, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	st := shellJSONTranslator{src: src}
+	if err = st.translate(); err != nil {
+		return nil, err
+	}
+	// The relaxed reader also accepts the canonical extended JSON written by the translator, and allows relaxed
+	// extended JSON in the input.
+	return NewExtJSONValueReader(&st.out, false)
+}
+
+// shellJSONTranslator translates mongo shell syntax to canonical extended JSON.
+type shellJSONTranslator struct {
+	src []byte
+	pos int
+	out bytes.Buffer
+}
+
+// shellArg is a literal argument to a shell constructor.
+type shellArg struct {
+	str      string
+	isString bool
+}
+
+func (st *shellJSONTranslator) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid shell JSON at offset %d: %s", st.pos, fmt.Sprintf(format, args...))
+}
+
+func (st *shellJSONTranslator) translate() error {
+	for {
+		st.skipSpace()
+		if st.pos == len(st.src) {
+			return nil
+		}
+		if err := st.value(); err != nil {
+			return err
+		}
+		st.out.WriteByte('\n')
+	}
+}
+
+func (st *shellJSONTranslator) skipSpace() {
+	for st.pos < len(st.src) {
+		switch st.src[st.pos] {
+		case ' ', '\t', '\n', '\r':
+			st.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (st *shellJSONTranslator) peek() byte {
+	if st.pos < len(st.src) {
+		return st.src[st.pos]
+	}
+	return 0
+}
+
+func (st *shellJSONTranslator) expect(c byte) error {
+	st.skipSpace()
+	if st.peek() != c {
+		return st.errorf("expected %q", c)
+	}
+	st.pos++
+	return nil
+}
+
+func (st *shellJSONTranslator) value() error {
+	st.skipSpace()
+	switch c := st.peek(); {
+	case c == '{':
+		return st.object()
+	case c == '[':
+		return st.array()
+	case c == '"' || c == '\'':
+		s, err := st.str()
+		if err != nil {
+			return err
+		}
+		writeStringWithEscapes(s, &st.out, false)
+		return nil
+	case c == '/':
+		return st.regex()
+	case c == '-' || c == '+' || c == '.' || isDigit(c):
+		num, err := st.number()
+		if err != nil {
+			return err
+		}
+		st.out.WriteString(num)
+		return nil
+	case isIdentStart(c):
+		return st.identifier()
+	case c == 0:
+		return st.errorf("unexpected end of input")
+	default:
+		return st.errorf("unexpected character %q", c)
+	}
+}
+
+func (st *shellJSONTranslator) object() error {
+	st.pos++
+	st.out.WriteByte('{')
+	for first := true; ; first = false {
+		st.skipSpace()
+		if st.peek() == '}' {
+			st.pos++
+			st.out.WriteByte('}')
+			return nil
+		}
+		if !first {
+			st.out.WriteByte(',')
+		}
+
+		var key string
+		switch c := st.peek(); {
+		case c == '"' || c == '\'':
+			var err error
+			if key, err = st.str(); err != nil {
+				return err
+			}
+		case isIdentStart(c) || isDigit(c):
+			key = st.ident()
+		default:
+			return st.errorf("expected key")
+		}
+		writeStringWithEscapes(key, &st.out, false)
+		if err := st.expect(':'); err != nil {
+			return err
+		}
+		st.out.WriteByte(':')
+		if err := st.value(); err != nil {
+			return err
+		}
+
+		st.skipSpace()
+		switch st.peek() {
+		case ',':
+			st.pos++
+		case '}':
+		default:
+			return st.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (st *shellJSONTranslator) array() error {
+	st.pos++
+	st.out.WriteByte('[')
+	for first := true; ; first = false {
+		st.skipSpace()
+		if st.peek() == ']' {
+			st.pos++
+			st.out.WriteByte(']')
+			return nil
+		}
+		if !first {
+			st.out.WriteByte(',')
+		}
+		if err := st.value(); err != nil {
+			return err
+		}
+
+		st.skipSpace()
+		switch st.peek() {
+		case ',':
+			st.pos++
+		case ']':
+		default:
+			return st.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+// str reads a single or double quoted string with JavaScript escape sequences.
+func (st *shellJSONTranslator) str() (string, error) {
+	quote := st.src[st.pos]
+	st.pos++
+	var sb strings.Builder
+	for {
+		if st.pos >= len(st.src) {
+			return "", st.errorf("unterminated string")
+		}
+		c := st.src[st.pos]
+		switch {
+		case c == quote:
+			st.pos++
+			return sb.String(), nil
+		case c == '\n':
+			return "", st.errorf("unterminated string")
+		case c != '\\':
+			sb.WriteByte(c)
+			st.pos++
+			continue
+		}
+
+		st.pos++
+		if st.pos >= len(st.src) {
+			return "", st.errorf("unterminated string")
+		}
+		esc := st.src[st.pos]
+		st.pos++
+		switch esc {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'v':
+			sb.WriteByte('\v')
+		case '0':
+			if st.pos < len(st.src) && isDigit(st.src[st.pos]) {
+				st.pos -= 2
+				return "", st.errorf("octal escape sequences are not supported")
+			}
+			sb.WriteByte(0)
+		case 'x':
+			if st.pos+2 > len(st.src) {
+				return "", st.errorf("invalid hexadecimal escape")
+			}
+			n, err := strconv.ParseUint(string(st.src[st.pos:st.pos+2]), 16, 8)
+			if err != nil {
+				return "", st.errorf("invalid hexadecimal escape")
+			}
+			st.pos += 2
+			sb.WriteRune(rune(n))
+		case '\\', '\'', '"', '/':
+			sb.WriteByte(esc)
+		case '\n':
+			// A backslash at the end of a line continues the string on the next line.
+		case '\r':
+			if st.pos < len(st.src) && st.src[st.pos] == '\n' {
+				st.pos++
+			}
+		case 'u':
+			r, err := st.hexRune()
+			if err != nil {
+				return "", err
+			}
+			if utf16.IsSurrogate(r) && bytes.HasPrefix(st.src[st.pos:], []byte(`\u`)) {
+				st.pos += 2
+				r2, err := st.hexRune()
+				if err != nil {
+					return "", err
+				}
+				r = utf16.DecodeRune(r, r2)
+			}
+			sb.WriteRune(r)
+		default:
+			st.pos -= 2
+			return "", st.errorf("invalid escape sequence \\%c", esc)
+		}
+	}
+}
+
+func (st *shellJSONTranslator) hexRune() (rune, error) {
+	if st.pos+4 > len(st.src) {
+		return 0, st.errorf("invalid unicode escape")
+	}
+	n, err := strconv.ParseUint(string(st.src[st.pos:st.pos+4]), 16, 16)
+	if err != nil {
+		return 0, st.errorf("invalid unicode escape")
+	}
+	st.pos += 4
+	return rune(n), nil
+}
+
+// number reads a number literal and returns it as an extended JSON number.
+func (st *shellJSONTranslator) number() (string, error) {
+	start := st.pos
+	for st.pos < len(st.src) && strings.IndexByte("+-.0123456789eE", st.src[st.pos]) >= 0 {
+		st.pos++
+	}
+	lit := string(st.src[start:st.pos])
+	if lit == "-" || lit == "+" {
+		if ident := st.ident(); ident == "Infinity" {
+			return fmt.Sprintf(`{"$numberDouble":"%sInfinity"}`, strings.TrimPrefix(lit, "+")), nil
+		}
+		st.pos = start
+		return "", st.errorf("invalid number")
+	}
+
+	if i, err := strconv.ParseInt(lit, 10, 64); err == nil {
+		return strconv.FormatInt(i, 10), nil
+	}
+	f, err := strconv.ParseFloat(lit, 64)
+	if err != nil {
+		st.pos = start
+		return "", st.errorf("invalid number %s", lit)
+	}
+	return `{"$numberDouble":"` + formatDouble(f) + `"}`, nil
+}
+
+func (st *shellJSONTranslator) ident() string {
+	start := st.pos
+	for st.pos < len(st.src) && (isIdentStart(st.src[st.pos]) || isDigit(st.src[st.pos])) {
+		st.pos++
+	}
+	return string(st.src[start:st.pos])
+}
+
+// regex reads a regular expression literal. The pattern is kept as written and the options are sorted.
+func (st *shellJSONTranslator) regex() error {
+	st.pos++
+	start := st.pos
+	inClass := false
+	for {
+		if st.pos >= len(st.src) || st.src[st.pos] == '\n' {
+			return st.errorf("unterminated regular expression")
+		}
+		c := st.src[st.pos]
+		if c == '/' && !inClass {
+			break
+		}
+		switch c {
+		case '\\':
+			st.pos++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		}
+		st.pos++
+	}
+	pattern := string(st.src[start:st.pos])
+	st.pos++
+	if pattern == "" {
+		return st.errorf("empty regular expression")
+	}
+	options := st.ident()
+
+	st.out.WriteString(`{"$regularExpression":{"pattern":`)
+	writeStringWithEscapes(pattern, &st.out, false)
+	st.out.WriteString(`,"options":`)
+	writeStringWithEscapes(sortStringAlphebeticAscending(options), &st.out, false)
+	st.out.WriteString(`}}`)
+	return nil
+}
+
+func (st *shellJSONTranslator) identifier() error {
+	start := st.pos
+	name := st.ident()
+	switch name {
+	case "true", "false", "null":
+		st.out.WriteString(name)
+		return nil
+	case "undefined":
+		st.out.WriteString(`{"$undefined":true}`)
+		return nil
+	case "NaN", "Infinity":
+		st.out.WriteString(`{"$numberDouble":"` + name + `"}`)
+		return nil
+	case "MinKey", "MaxKey":
+		// The parentheses are optional.
+		save := st.pos
+		st.skipSpace()
+		if st.peek() == '(' {
+			if args, err := st.args(); err != nil || len(args) != 0 {
+				st.pos = save
+				return st.errorf("%s does not take arguments", name)
+			}
+		} else {
+			st.pos = save
+		}
+		if name == "MinKey" {
+			st.out.WriteString(`{"$minKey":1}`)
+		} else {
+			st.out.WriteString(`{"$maxKey":1}`)
+		}
+		return nil
+	case "new":
+		st.skipSpace()
+		if !isIdentStart(st.peek()) {
+			return st.errorf("expected constructor after new")
+		}
+		start = st.pos
+		name = st.ident()
+	}
+
+	st.skipSpace()
+	if st.peek() != '(' {
+		st.pos = start
+		return st.errorf("unexpected identifier %s", name)
+	}
+	args, err := st.args()
+	if err != nil {
+		return err
+	}
+	if err = st.constructor(name, args); err != nil {
+		st.pos = start
+		return st.errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// args reads the literal arguments of a constructor call.
+func (st *shellJSONTranslator) args() ([]shellArg, error) {
+	st.pos++
+	var args []shellArg
+	for {
+		st.skipSpace()
+		switch c := st.peek(); {
+		case c == ')':
+			st.pos++
+			return args, nil
+		case c == '"' || c == '\'':
+			s, err := st.str()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, shellArg{str: s, isString: true})
+		case c == '-' || c == '+' || c == '.' || isDigit(c):
+			start := st.pos
+			if _, err := st.number(); err != nil {
+				return nil, err
+			}
+			args = append(args, shellArg{str: string(st.src[start:st.pos])})
+		default:
+			return nil, st.errorf("constructor arguments must be strings or numbers")
+		}
+
+		st.skipSpace()
+		switch st.peek() {
+		case ',':
+			st.pos++
+		case ')':
+		default:
+			return nil, st.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+// constructor writes the canonical extended JSON for a shell constructor call.
+func (st *shellJSONTranslator) constructor(name string, args []shellArg) error {
+	arity := func(counts ...int) error {
+		for _, n := range counts {
+			if len(args) == n {
+				return nil
+			}
+		}
+		return fmt.Errorf("wrong number of arguments: %d", len(args))
+	}
+
+	switch name {
+	case "ObjectId":
+		if err := arity(0, 1); err != nil {
+			return err
+		}
+		oid := primitive.NewObjectID()
+		if len(args) == 1 {
+			var err error
+			if oid, err = primitive.ObjectIDFromHex(args[0].str); err != nil || !args[0].isString {
+				return fmt.Errorf("invalid ObjectId %q", args[0].str)
+			}
+		}
+		fmt.Fprintf(&st.out, `{"$oid":"%s"}`, oid.Hex())
+	case "ISODate", "Date":
+		if err := arity(0, 1); err != nil {
+			return err
+		}
+		dt := primitive.NewDateTimeFromTime(time.Now())
+		if len(args) == 1 && args[0].isString {
+			var t time.Time
+			var err error
+			for _, format := range shellTimeFormats {
+				if t, err = time.Parse(format, args[0].str); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("invalid date %q", args[0].str)
+			}
+			dt = primitive.NewDateTimeFromTime(t)
+		} else if len(args) == 1 {
+			ms, err := strconv.ParseInt(args[0].str, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid date %s", args[0].str)
+			}
+			dt = primitive.DateTime(ms)
+		}
+		fmt.Fprintf(&st.out, `{"$date":{"$numberLong":"%d"}}`, int64(dt))
+	case "NumberLong":
+		if err := arity(1); err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(args[0].str, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid 64-bit integer %q", args[0].str)
+		}
+		fmt.Fprintf(&st.out, `{"$numberLong":"%d"}`, i)
+	case "NumberInt":
+		if err := arity(1); err != nil {
+			return err
+		}
+		i, err := strconv.ParseInt(args[0].str, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid 32-bit integer %q", args[0].str)
+		}
+		fmt.Fprintf(&st.out, `{"$numberInt":"%d"}`, i)
+	case "NumberDecimal":
+		if err := arity(1); err != nil {
+			return err
+		}
+		d, err := primitive.ParseDecimal128(args[0].str)
+		if err != nil {
+			return fmt.Errorf("invalid decimal %q", args[0].str)
+		}
+		fmt.Fprintf(&st.out, `{"$numberDecimal":"%s"}`, d.String())
+	case "UUID":
+		if err := arity(1); err != nil {
+			return err
+		}
+		b, err := hex.DecodeString(strings.Replace(args[0].str, "-", "", -1))
+		if err != nil || len(b) != 16 || !args[0].isString {
+			return fmt.Errorf("invalid UUID %q", args[0].str)
+		}
+		st.writeBinary(b, 0x04)
+	case "BinData":
+		if err := arity(2); err != nil {
+			return err
+		}
+		subtype, err := strconv.ParseUint(args[0].str, 10, 8)
+		if err != nil || args[0].isString {
+			return fmt.Errorf("invalid binary subtype %s", args[0].str)
+		}
+		b, err := base64.StdEncoding.DecodeString(args[1].str)
+		if err != nil || !args[1].isString {
+			return fmt.Errorf("invalid base64 data %q", args[1].str)
+		}
+		st.writeBinary(b, byte(subtype))
+	case "Timestamp":
+		if err := arity(2); err != nil {
+			return err
+		}
+		t, err1 := strconv.ParseUint(args[0].str, 10, 32)
+		i, err2 := strconv.ParseUint(args[1].str, 10, 32)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("invalid timestamp (%s, %s)", args[0].str, args[1].str)
+		}
+		fmt.Fprintf(&st.out, `{"$timestamp":{"t":%d,"i":%d}}`, t, i)
+	default:
+		return fmt.Errorf("unknown constructor")
+	}
+	return nil
+}
+
+func (st *shellJSONTranslator) writeBinary(b []byte, subtype byte) {
+	fmt.Fprintf(&st.out, `{"$binary":{"base64":"%s","subType":"%02x"}}`, base64.StdEncoding.EncodeToString(b), subtype)
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$' || c >= utf8.RuneSelf
+}
@@ -0,0 +1,133 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestShellJSONValueReader(t *testing.T) {
+	testCases := []struct {
+		name  string
+		shell string
+		ejson string
+	}{
+		{"extended JSON", `{"a": {"$numberLong": "1"}, "b": [1, 2.5, "x"]}`,
+			`{"a": {"$numberLong": "1"}, "b": [1, {"$numberDouble": "2.5"}, "x"]}`},
+		{"unquoted keys and trailing commas", `{a: 1, $b: [true, null,], c_1: {},}`,
+			`{"a": 1, "$b": [true, null], "c_1": {}}`},
+		{"single quotes", `{'a b': 'it\'s "quoted"\n'}`, `{"a b": "it's \"quoted\"\n"}`},
+		{"unicode escapes", `{a: '\u00e9\ud83d\ude00'}`, `{"a": "é😀"}`},
+		{"hexadecimal escapes", `{s: 'a\x41b\xe9'}`, `{"s": "aAbé"}`},
+		{"escaped punctuation and line continuation", "{s: '\\\\ \\/ \\\" a\\\nb\\\r\nc'}", `{"s": "\\ / \" abc"}`},
+		{"numbers", `{a: -5, b: +5, c: .5, d: 1e3, e: 3000000000, f: -Infinity, g: NaN}`,
+			`{"a": -5, "b": 5, "c": {"$numberDouble": "0.5"}, "d": {"$numberDouble": "1000.0"},
+			"e": 3000000000, "f": {"$numberDouble": "-Infinity"}, "g": {"$numberDouble": "NaN"}}`},
+		{"ObjectId", `{_id: ObjectId("5e1f6c4a2b3c4d5e6f708192")}`, `{"_id": {"$oid": "5e1f6c4a2b3c4d5e6f708192"}}`},
+		{"ISODate", `{a: ISODate("2020-01-02T03:04:05.678Z"), b: new Date('2020-01-02'), c: Date(1000),
+			d: ISODate("2020-01-02T03:04:05+01:00"), e: ISODate("2020-01-02T03:04:05")}`,
+			`{"a": {"$date": {"$numberLong": "1577934245678"}}, "b": {"$date": {"$numberLong": "1577923200000"}},
+			"c": {"$date": {"$numberLong": "1000"}}, "d": {"$date": {"$numberLong": "1577930645000"}},
+			"e": {"$date": {"$numberLong": "1577934245000"}}}`},
+		{"NumberLong", `{a: NumberLong(5), b: NumberLong("-9223372036854775808")}`,
+			`{"a": {"$numberLong": "5"}, "b": {"$numberLong": "-9223372036854775808"}}`},
+		{"NumberInt", `{a: NumberInt(5), b: NumberInt("-7")}`, `{"a": {"$numberInt": "5"}, "b": {"$numberInt": "-7"}}`},
+		{"NumberDecimal", `{a: NumberDecimal("1.50"), b: NumberDecimal(2)}`,
+			`{"a": {"$numberDecimal": "1.50"}, "b": {"$numberDecimal": "2"}}`},
+		{"UUID", `{a: UUID("00112233-4455-6677-8899-aabbccddeeff")}`,
+			`{"a": {"$binary": {"base64": "ABEiM0RVZneImaq7zN3u/w==", "subType": "04"}}}`},
+		{"BinData", `{a: BinData(0, "AQID"), b: new BinData(128, '')}`,
+			`{"a": {"$binary": {"base64": "AQID", "subType": "00"}}, "b": {"$binary": {"base64": "", "subType": "80"}}}`},
+		{"Timestamp", `{a: Timestamp(1577934245, 7)}`, `{"a": {"$timestamp": {"t": 1577934245, "i": 7}}}`},
+		{"regex", `{a: /ab+c/, b: /[/]\/x/mi}`,
+			`{"a": {"$regularExpression": {"pattern": "ab+c", "options": ""}},
+			"b": {"$regularExpression": {"pattern": "[/]\\/x", "options": "im"}}}`},
+		{"MinKey and MaxKey", `{a: MinKey, b: MaxKey()}`, `{"a": {"$minKey": 1}, "b": {"$maxKey": 1}}`},
+		{"undefined", `{a: undefined}`, `{"a": {"$undefined": true}}`},
+		{"relaxed extended JSON",
+			`{d: {"$date": "2020-01-01T00:00:00Z"}, n: {$numberLong: "5"}, x: {"$numberDouble": "1.5"}}`,
+			`{"d": {"$date": {"$numberLong": "1577836800000"}}, "n": {"$numberLong": "5"}, "x": {"$numberDouble": "1.5"}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vr, err := NewShellJSONValueReader(strings.NewReader(tc.shell))
+			assert.Nil(t, err, "NewShellJSONValueReader error: %v", err)
+			got, err := Copier{}.CopyDocumentToBytes(vr)
+			assert.Nil(t, err, "CopyDocumentToBytes error: %v", err)
+
+			ejvr, err := NewExtJSONValueReader(strings.NewReader(tc.ejson), true)
+			assert.Nil(t, err, "NewExtJSONValueReader error: %v", err)
+			want, err := Copier{}.CopyDocumentToBytes(ejvr)
+			assert.Nil(t, err, "CopyDocumentToBytes error: %v", err)
+
+			assert.Equal(t, bsoncore.Document(want), bsoncore.Document(got), "expected %v, got %v",
+				bsoncore.Document(want), bsoncore.Document(got))
+		})
+	}
+
+	t.Run("multiple documents", func(t *testing.T) {
+		vr, err := NewShellJSONValueReader(strings.NewReader("{a: 1}\n{b: 2,}"))
+		assert.Nil(t, err, "NewShellJSONValueReader error: %v", err)
+		for _, key := range []string{"a", "b"} {
+			doc, err := Copier{}.CopyDocumentToBytes(vr)
+			assert.Nil(t, err, "CopyDocumentToBytes error: %v", err)
+			val := bsoncore.Document(doc).Lookup(key)
+			assert.Equal(t, bsontype.Int32, val.Type, "expected %s to be an int32, got %v", key, val.Type)
+		}
+		_, err = Copier{}.CopyDocumentToBytes(vr)
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+	t.Run("ObjectId without arguments", func(t *testing.T) {
+		vr, err := NewShellJSONValueReader(strings.NewReader("{_id: new ObjectId()}"))
+		assert.Nil(t, err, "NewShellJSONValueReader error: %v", err)
+		doc, err := Copier{}.CopyDocumentToBytes(vr)
+		assert.Nil(t, err, "CopyDocumentToBytes error: %v", err)
+		_, ok := bsoncore.Document(doc).Lookup("_id").ObjectIDOK()
+		assert.True(t, ok, "expected _id to be an ObjectID, got %v", bsoncore.Document(doc))
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			shell  string
+			errMsg string
+		}{
+			{"unterminated object", `{a: 1`, "offset 5: expected ',' or '}'"},
+			{"missing colon", `{a 1}`, "offset 3: expected ':'"},
+			{"unterminated string", `{a: 'x}`, "offset 7: unterminated string"},
+			{"invalid key", `{[a]: 1}`, "offset 1: expected key"},
+			{"unknown identifier", `{a: b}`, "offset 4: unexpected identifier b"},
+			{"unknown constructor", `{a: Foo(1)}`, "offset 4: Foo: unknown constructor"},
+			{"invalid ObjectId", `{a: ObjectId("xyz")}`, `ObjectId: invalid ObjectId "xyz"`},
+			{"invalid date", `{a: ISODate("yesterday")}`, `ISODate: invalid date "yesterday"`},
+			{"NumberInt overflow", `{a: NumberInt(3000000000)}`, `NumberInt: invalid 32-bit integer "3000000000"`},
+			{"invalid UUID", `{a: UUID("0011")}`, `UUID: invalid UUID "0011"`},
+			{"invalid BinData", `{a: BinData(0, "!")}`, `BinData: invalid base64 data "!"`},
+			{"wrong number of arguments", `{a: Timestamp(1)}`, "Timestamp: wrong number of arguments: 1"},
+			{"non-literal argument", `{a: NumberLong(x)}`, "constructor arguments must be strings or numbers"},
+			{"unterminated regex", "{a: /ab\n}", "unterminated regular expression"},
+			{"invalid number", `{a: 1.2.3}`, "offset 4: invalid number 1.2.3"},
+			{"unknown escape", `{a: 'x\qy'}`, `offset 6: invalid escape sequence \q`},
+			{"invalid hexadecimal escape", `{a: '\x4g'}`, "invalid hexadecimal escape"},
+			{"short hexadecimal escape", `{a: '\x4`, "invalid hexadecimal escape"},
+			{"octal escape", `{a: '\01'}`, "offset 5: octal escape sequences are not supported"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewShellJSONValueReader(strings.NewReader(tc.shell))
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// DefaultMaxDocumentSize is the maximum document size used by the BSON stream readers and writers when a
+// non-positive maximum is given. It is the maximum size of a BSON document stored by the server.
+const DefaultMaxDocumentSize = 16 * 1024 * 1024
+
+var errNilReader = errors.New("cannot create a ValueReader from a nil io.Reader")
+
+var _ ValueReader = (*streamReader)(nil)
+var _ BytesReader = (*streamReader)(nil)
+
+// streamReader is a ValueReader that reads a sequence of BSON documents from an io.Reader. Each document is read
+// into its own buffer when it's first needed and is then read by an embedded valueReader, so at most one document is
+// held in memory by the reader at a time. The streamReader is only used at the top level, since the DocumentReader
+// and ValueReaders it returns are those of the embedded valueReader.
+type streamReader struct {
+	*valueReader
+
+	r       io.Reader
+	maxSize int
+	loaded  bool
+	err     error
+}
+
+// NewBSONStreamReader returns a ValueReader that reads consecutive BSON documents from r, such as the contents of
+// a mongodump .bson file. Each top level read consumes the next document from r and io.EOF is returned once r is
+// exhausted. Documents larger than maxDocumentSize bytes are rejected without being read; if maxDocumentSize is not
+// positive, DefaultMaxDocumentSize is used.
+func NewBSONStreamReader(r io.Reader, maxDocumentSize int) (ValueReader, error) {
+	if r == nil {
+		return nil, errNilReader
+	}
+	if maxDocumentSize <= 0 {
+		maxDocumentSize = DefaultMaxDocumentSize
+	}
+	return &streamReader{
+		valueReader: newValueReader(nil),
+		r:           r,
+		maxSize:     maxDocumentSize,
+	}, nil
+}
+
+// load reads the next document from the stream if the current one has already been consumed. Errors are sticky
+// because the position in the stream is unknown after a failed read.
+func (sr *streamReader) load() error {
+	if sr.err != nil || sr.loaded {
+		return sr.err
+	}
+	sr.loaded = true
+
+	var lenBuf [4]byte
+	_, err := io.ReadFull(sr.r, lenBuf[:])
+	if err != nil {
+		sr.err = err
+		return err
+	}
+	length := int32(binary.LittleEndian.Uint32(lenBuf[:]))
+	switch {
+	case length < 5:
+		sr.err = fmt.Errorf("invalid document length %d", length)
+	case int64(length) > int64(sr.maxSize):
+		sr.err = fmt.Errorf("document size (%d) is larger than the maximum document size (%d)", length, sr.maxSize)
+	}
+	if sr.err != nil {
+		return sr.err
+	}
+
+	// A new buffer is allocated for each document because decoded values such as []byte and bson.Raw may reference it.
+	doc := make([]byte, length)
+	copy(doc, lenBuf[:])
+	if _, err = io.ReadFull(sr.r, doc[4:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		sr.err = err
+		return err
+	}
+	sr.valueReader.reset(doc)
+	return nil
+}
+
+// consume loads the next document if needed and marks it as consumed, so that the following top level read
+// starts at the next document in the stream.
+func (sr *streamReader) consume() error {
+	err := sr.load()
+	sr.loaded = false
+	return err
+}
+
+func (sr *streamReader) Type() bsontype.Type {
+	if sr.load() != nil {
+		return bsontype.Type(0)
+	}
+	return sr.valueReader.Type()
+}
+
+func (sr *streamReader) Skip() error {
+	return sr.consume()
+}
+
+func (sr *streamReader) ReadDocument() (DocumentReader, error) {
+	if err := sr.consume(); err != nil {
+		return nil, err
+	}
+	return sr.valueReader.ReadDocument()
+}
+
+func (sr *streamReader) ReadValueBytes(dst []byte) (bsontype.Type, []byte, error) {
+	if err := sr.consume(); err != nil {
+		return bsontype.Type(0), nil, err
+	}
+	return sr.valueReader.ReadValueBytes(dst)
+}
@@ -0,0 +1,188 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestBSONStreamReader(t *testing.T) {
+	doc1 := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "a", 1))
+	doc2 := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "b", "two"))
+	stream := append(append([]byte{}, doc1...), doc2...)
+
+	readInt32 := func(t *testing.T, vr ValueReader) int32 {
+		t.Helper()
+		dr, err := vr.ReadDocument()
+		assert.Nil(t, err, "ReadDocument error: %v", err)
+		key, evr, err := dr.ReadElement()
+		assert.Nil(t, err, "ReadElement error: %v", err)
+		assert.Equal(t, "a", key, "expected key %q, got %q", "a", key)
+		i32, err := evr.ReadInt32()
+		assert.Nil(t, err, "ReadInt32 error: %v", err)
+		_, _, err = dr.ReadElement()
+		assert.Equal(t, ErrEOD, err, "expected error %v, got %v", ErrEOD, err)
+		return i32
+	}
+
+	t.Run("nil reader", func(t *testing.T) {
+		_, err := NewBSONStreamReader(nil, 0)
+		assert.Equal(t, errNilReader, err, "expected error %v, got %v", errNilReader, err)
+	})
+	t.Run("reads documents in order", func(t *testing.T) {
+		vr, err := NewBSONStreamReader(bytes.NewReader(stream), 0)
+		assert.Nil(t, err, "NewBSONStreamReader error: %v", err)
+
+		assert.Equal(t, bsontype.Type(0), vr.Type(), "expected top level type 0, got %v", vr.Type())
+		got := readInt32(t, vr)
+		assert.Equal(t, int32(1), got, "expected 1, got %v", got)
+
+		_, b, err := vr.(BytesReader).ReadValueBytes(nil)
+		assert.Nil(t, err, "ReadValueBytes error: %v", err)
+		assert.Equal(t, bsoncore.Document(doc2), bsoncore.Document(b), "expected %v, got %v", doc2, b)
+
+		_, err = vr.ReadDocument()
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+	t.Run("Skip", func(t *testing.T) {
+		vr, err := NewBSONStreamReader(bytes.NewReader(append(append([]byte{}, doc2...), doc1...)), 0)
+		assert.Nil(t, err, "NewBSONStreamReader error: %v", err)
+
+		err = vr.Skip()
+		assert.Nil(t, err, "Skip error: %v", err)
+		got := readInt32(t, vr)
+		assert.Equal(t, int32(1), got, "expected 1, got %v", got)
+		err = vr.Skip()
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+	t.Run("abandoned document", func(t *testing.T) {
+		vr, err := NewBSONStreamReader(bytes.NewReader(append(append([]byte{}, doc2...), doc1...)), 0)
+		assert.Nil(t, err, "NewBSONStreamReader error: %v", err)
+
+		_, err = vr.ReadDocument()
+		assert.Nil(t, err, "ReadDocument error: %v", err)
+		got := readInt32(t, vr)
+		assert.Equal(t, int32(1), got, "expected 1, got %v", got)
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			data    []byte
+			maxSize int
+			errMsg  string
+		}{
+			{"truncated length", doc1[:2], 0, io.ErrUnexpectedEOF.Error()},
+			{"truncated document", doc1[:len(doc1)-1], 0, io.ErrUnexpectedEOF.Error()},
+			{"invalid length", []byte{0x04, 0x00, 0x00, 0x00}, 0, "invalid document length 4"},
+			{"negative length", []byte{0xFF, 0xFF, 0xFF, 0xFF}, 0, "invalid document length -1"},
+			{"too large", doc1, len(doc1) - 1, "is larger than the maximum document size"},
+			{"too large for default", []byte{0x00, 0x00, 0x00, 0x7F}, 0, "is larger than the maximum document size"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				vr, err := NewBSONStreamReader(bytes.NewReader(tc.data), tc.maxSize)
+				assert.Nil(t, err, "NewBSONStreamReader error: %v", err)
+
+				_, err = vr.ReadDocument()
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+
+				// Errors are sticky.
+				_, err2 := vr.ReadDocument()
+				assert.Equal(t, err, err2, "expected error %v, got %v", err, err2)
+			})
+		}
+	})
+}
+
+func TestBSONStreamWriter(t *testing.T) {
+	writeDoc := func(vw ValueWriter, val string) error {
+		dw, err := vw.WriteDocument()
+		if err != nil {
+			return err
+		}
+		evw, err := dw.WriteDocumentElement("s")
+		if err != nil {
+			return err
+		}
+		if err = evw.WriteString(val); err != nil {
+			return err
+		}
+		return dw.WriteDocumentEnd()
+	}
+
+	t.Run("nil writer", func(t *testing.T) {
+		_, err := NewBSONStreamWriter(nil, 0)
+		assert.Equal(t, errNilWriter, err, "expected error %v, got %v", errNilWriter, err)
+	})
+	t.Run("max document size", func(t *testing.T) {
+		small := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "s", "a"))
+		var buf bytes.Buffer
+		vw, err := NewBSONStreamWriter(&buf, len(small))
+		assert.Nil(t, err, "NewBSONStreamWriter error: %v", err)
+
+		err = writeDoc(vw, "a")
+		assert.Nil(t, err, "writeDoc error: %v", err)
+		err = writeDoc(vw, "too long")
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.True(t, strings.Contains(err.Error(), "is larger than the maximum document size"),
+			"expected maximum document size error, got %v", err)
+		err = writeDoc(vw, "a")
+		assert.Nil(t, err, "writeDoc error: %v", err)
+
+		want := append(append([]byte{}, small...), small...)
+		assert.Equal(t, want, buf.Bytes(), "expected %v, got %v", want, buf.Bytes())
+	})
+	t.Run("max document size exceeded before end", func(t *testing.T) {
+		var buf bytes.Buffer
+		vw, err := NewBSONStreamWriter(&buf, 32)
+		assert.Nil(t, err, "NewBSONStreamWriter error: %v", err)
+
+		dw, err := vw.WriteDocument()
+		assert.Nil(t, err, "WriteDocument error: %v", err)
+		for i := 0; err == nil && i < 10; i++ {
+			var evw ValueWriter
+			if evw, err = dw.WriteDocumentElement("s"); err == nil {
+				err = evw.WriteString("abcdefgh")
+			}
+		}
+		assert.NotNil(t, err, "expected error before the document is ended, got nil")
+		assert.True(t, strings.Contains(err.Error(), "is larger than the maximum document size"),
+			"expected maximum document size error, got %v", err)
+
+		err = writeDoc(vw, "a")
+		assert.Nil(t, err, "writeDoc error: %v", err)
+		want := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "s", "a"))
+		assert.Equal(t, want, buf.Bytes(), "expected %v, got %v", want, buf.Bytes())
+	})
+	t.Run("discard document", func(t *testing.T) {
+		var buf bytes.Buffer
+		vw, err := NewBSONStreamWriter(&buf, 0)
+		assert.Nil(t, err, "NewBSONStreamWriter error: %v", err)
+
+		dw, err := vw.WriteDocument()
+		assert.Nil(t, err, "WriteDocument error: %v", err)
+		evw, err := dw.WriteDocumentElement("d")
+		assert.Nil(t, err, "WriteDocumentElement error: %v", err)
+		_, err = evw.WriteDocument()
+		assert.Nil(t, err, "WriteDocument error: %v", err)
+		vw.(DocumentDiscarder).DiscardDocument()
+
+		err = writeDoc(vw, "a")
+		assert.Nil(t, err, "writeDoc error: %v", err)
+		want := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "s", "a"))
+		assert.Equal(t, want, buf.Bytes(), "expected %v, got %v", want, buf.Bytes())
+	})
+}
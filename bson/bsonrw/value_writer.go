@@ -134,6 +134,10 @@ type valueWriter struct {
 	w   io.Writer
 	buf []byte
 
+	// maxDocumentSize is the maximum size of a top level document written to w. It is zero if there is no limit
+	// other than the max int32.
+	maxDocumentSize int
+
 	stack []vwState
 	frame int64
 }
@@ -193,6 +197,23 @@ func NewBSONValueWriter(w io.Writer) (ValueWriter, error) {
 	return newValueWriter(w), nil
 }
 
+// NewBSONStreamWriter creates a ValueWriter that writes a sequence of BSON documents to w, such as the contents of a
+// mongodump .bson file. Each document is buffered until it is complete and the buffer is reused for the next
+// document. Documents larger than maxDocumentSize bytes are discarded instead of being written and an error is
+// returned as soon as the buffered document exceeds the limit; if maxDocumentSize is not positive,
+// DefaultMaxDocumentSize is used.
+func NewBSONStreamWriter(w io.Writer, maxDocumentSize int) (ValueWriter, error) {
+	if w == nil {
+		return nil, errNilWriter
+	}
+	if maxDocumentSize <= 0 {
+		maxDocumentSize = DefaultMaxDocumentSize
+	}
+	vw := newValueWriter(w)
+	vw.maxDocumentSize = maxDocumentSize
+	return vw, nil
+}
+
 func newValueWriter(w io.Writer) *valueWriter {
 	vw := new(valueWriter)
 	stack := make([]vwState, 1, 5)
@@ -208,6 +229,7 @@ func newValueWriterFromSlice(buf []byte) *valueWriter {
 	stack := make([]vwState, 1, 5)
 	stack[0] = vwState{mode: mTopLevel}
 	vw.stack = stack
+	vw.stack[0].start = int32(len(buf))
 	vw.buf = buf
 
 	return vw
@@ -218,10 +240,31 @@ func (vw *valueWriter) reset(buf []byte) {
 		vw.stack = make([]vwState, 1, 5)
 	}
 	vw.stack = vw.stack[:1]
-	vw.stack[0] = vwState{mode: mTopLevel}
+	vw.stack[0] = vwState{mode: mTopLevel, start: int32(len(buf))}
 	vw.buf = buf
 	vw.frame = 0
 	vw.w = nil
+	vw.maxDocumentSize = 0
+}
+
+// DiscardDocument implements the DocumentDiscarder interface.
+func (vw *valueWriter) DiscardDocument() {
+	if start := int(vw.stack[0].start); start < len(vw.buf) {
+		vw.buf = vw.buf[:start]
+	}
+	vw.stack = vw.stack[:1]
+	vw.frame = 0
+}
+
+// checkDocumentSize discards the top level document and returns an error if it has grown larger than
+// maxDocumentSize. It is called as elements are appended so that an oversized document is not buffered in full.
+func (vw *valueWriter) checkDocumentSize() error {
+	size := len(vw.buf) - int(vw.stack[0].start)
+	if vw.maxDocumentSize <= 0 || size <= vw.maxDocumentSize {
+		return nil
+	}
+	vw.DiscardDocument()
+	return fmt.Errorf("document size (%d) is larger than the maximum document size (%d)", size, vw.maxDocumentSize)
 }
 
 func (vw *valueWriter) invalidTransitionError(destination mode, name string, modes []mode) error {
@@ -239,6 +282,10 @@ func (vw *valueWriter) invalidTransitionError(destination mode, name string, mod
 }
 
 func (vw *valueWriter) writeElementHeader(t bsontype.Type, destination mode, callerName string, addmodes ...mode) error {
+	if err := vw.checkDocumentSize(); err != nil {
+		return err
+	}
+
 	switch vw.stack[vw.frame].mode {
 	case mElement:
 		key := vw.stack[vw.frame].key
@@ -521,9 +568,14 @@ func (vw *valueWriter) WriteDocumentEnd() error {
 	}
 
 	if vw.stack[vw.frame].mode == mTopLevel {
+		if err = vw.checkDocumentSize(); err != nil {
+			return err
+		}
 		if err = vw.Flush(); err != nil {
 			return err
 		}
+		// The next top level document starts after this one, which is still in the buffer if there is no writer.
+		vw.stack[0].start = int32(len(vw.buf))
 	}
 
 	vw.pop()
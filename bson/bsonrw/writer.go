@@ -61,6 +61,13 @@ type ValueWriterFlusher interface {
 	Flush() error
 }
 
+// DocumentDiscarder is implemented by ValueWriters that buffer top level documents. DiscardDocument drops the
+// partially written top level document, if any, and returns the writer to the top level so that the next document can
+// be written after an error.
+type DocumentDiscarder interface {
+	DiscardDocument()
+}
+
 // BytesWriter is the interface used to write BSON bytes to a ValueWriter.
 // This interface is meant to be a superset of ValueWriter, so that types that
 // implement ValueWriter may also implement this interface.
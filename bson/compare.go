@@ -0,0 +1,32 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// CompareValues compares two BSON values using the order in which MongoDB sorts them. It returns -1 if a sorts before
+// b, 0 if they are equal, and 1 if a sorts after b.
+//
+// Values of different types are ordered by type: MinKey, undefined, null, numbers, strings and symbols, documents,
+// arrays, binary data, ObjectIDs, booleans, dates, timestamps, regular expressions, DBPointers, JavaScript code,
+// JavaScript code with scope, and MaxKey. Numbers of different types, such as an int32 and a Decimal128, are compared
+// by their numeric values. Strings are compared by their UTF-8 bytes, without a collation.
+func CompareValues(a, b RawValue) int {
+	return bsoncore.CompareValues(convertToCoreValue(a), convertToCoreValue(b))
+}
+
+// CompareDocuments compares two documents using the order in which MongoDB sorts them for the given sort
+// specification, such as {a: 1, "b.c": -1}. It returns -1 if a sorts before b, 0 if they are equal, and 1 if a sorts
+// after b. If sortSpec is empty, the documents are compared as a whole.
+//
+// This can be used to merge sorted results or to implement keyset pagination client-side. See
+// bsoncore.CompareDocuments for how missing fields and arrays are handled.
+func CompareDocuments(a, b, sortSpec Raw) (int, error) {
+	return bsoncore.CompareDocuments(bsoncore.Document(a), bsoncore.Document(b), bsoncore.Document(sortSpec))
+}
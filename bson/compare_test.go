@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"sort"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("CompareValues", func(t *testing.T) {
+		doc, err := Marshal(D{
+			{"int", int32(2)},
+			{"long", int64(2)},
+			{"double", 2.5},
+			{"decimal", primitive.NewDecimal128(0x3040000000000000, 2)},
+			{"string", "2"},
+			{"null", nil},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		raw := Raw(doc)
+
+		testCases := []struct {
+			a, b     string
+			expected int
+		}{
+			{"int", "long", 0},
+			{"int", "decimal", 0},
+			{"int", "double", -1},
+			{"double", "string", -1},
+			{"null", "int", -1},
+		}
+		for _, tc := range testCases {
+			got := CompareValues(raw.Lookup(tc.a), raw.Lookup(tc.b))
+			assert.Equal(t, tc.expected, got, "expected CompareValues(%v, %v) to be %v, got %v", tc.a, tc.b,
+				tc.expected, got)
+		}
+	})
+	t.Run("CompareDocuments", func(t *testing.T) {
+		var docs []Raw
+		for _, d := range []D{
+			{{"_id", 1}, {"score", 7.5}},
+			{{"_id", 2}, {"score", int64(9)}},
+			{{"_id", 3}},
+			{{"_id", 4}, {"score", 9}},
+		} {
+			b, err := Marshal(d)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			docs = append(docs, b)
+		}
+		sortSpec, err := Marshal(D{{"score", -1}, {"_id", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		sort.SliceStable(docs, func(i, j int) bool {
+			c, err := CompareDocuments(docs[i], docs[j], sortSpec)
+			assert.Nil(t, err, "CompareDocuments error: %v", err)
+			return c < 0
+		})
+		var ids []int32
+		for _, doc := range docs {
+			ids = append(ids, doc.Lookup("_id").Int32())
+		}
+		expected := []int32{2, 4, 1, 3}
+		assert.Equal(t, expected, ids, "expected order %v, got %v", expected, ids)
+	})
+}
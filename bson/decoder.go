@@ -9,6 +9,7 @@ package bson
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 
@@ -62,6 +63,19 @@ func NewDecoderWithContext(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader) (*
 	}, nil
 }
 
+// NewStreamDecoder returns a new decoder that uses the DefaultRegistry to read consecutive BSON documents from r, such
+// as the contents of a mongodump .bson file. Each call to Decode reads and decodes the next document, and io.EOF is
+// returned once r is exhausted. Only one document is buffered at a time and documents larger than
+// bsonrw.DefaultMaxDocumentSize are rejected. To use a different limit, create the decoder with NewDecoder and
+// bsonrw.NewBSONStreamReader.
+func NewStreamDecoder(r io.Reader) (*Decoder, error) {
+	vr, err := bsonrw.NewBSONStreamReader(r, bsonrw.DefaultMaxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewDecoder(vr)
+}
+
 // Decode reads the next BSON document from the stream and decodes it into the
 // value pointed to by val.
 //
@@ -116,3 +130,9 @@ func (d *Decoder) SetContext(dc bsoncodec.DecodeContext) error {
 	d.dc = dc
 	return nil
 }
+
+// DisallowUnknownFields causes the Decoder to return an error when decoding a document with a key that doesn't match
+// any field of the struct being decoded into.
+func (d *Decoder) DisallowUnknownFields() {
+	d.dc.DisallowUnknownFields = true
+}
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
 
@@ -17,6 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsonrw/bsonrwtest"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
@@ -263,3 +265,69 @@ func (tu *testUnmarshaler) UnmarshalBSON(d []byte) error {
 	tu.data = d
 	return tu.err
 }
+
+func TestStreamDecoder(t *testing.T) {
+	type item struct {
+		N    int
+		Data []byte
+	}
+
+	t.Run("nil reader", func(t *testing.T) {
+		_, err := NewStreamDecoder(nil)
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc, err := NewStreamEncoder(&buf)
+		assert.Nil(t, err, "NewStreamEncoder error: %v", err)
+		want := []item{{N: 1, Data: []byte{1}}, {N: 2, Data: []byte{2}}, {N: 3, Data: []byte{3}}}
+		for _, it := range want {
+			err = enc.Encode(it)
+			assert.Nil(t, err, "Encode error: %v", err)
+		}
+		err = enc.Encode(D{{Key: "raw", Value: true}})
+		assert.Nil(t, err, "Encode error: %v", err)
+
+		dec, err := NewStreamDecoder(&buf)
+		assert.Nil(t, err, "NewStreamDecoder error: %v", err)
+		var got []item
+		for i := 0; i < len(want); i++ {
+			var it item
+			err = dec.Decode(&it)
+			assert.Nil(t, err, "Decode error: %v", err)
+			got = append(got, it)
+		}
+		// Decoded byte slices must not share a buffer.
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+
+		var raw Raw
+		err = dec.Decode(&raw)
+		assert.Nil(t, err, "Decode error: %v", err)
+		assert.Equal(t, true, raw.Lookup("raw").Boolean(), "expected raw to be true, got %v", raw)
+
+		err = dec.Decode(&raw)
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+	t.Run("encode error", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc, err := NewStreamEncoder(&buf)
+		assert.Nil(t, err, "NewStreamEncoder error: %v", err)
+		err = enc.Encode(struct {
+			A int
+			C chan int
+		}{A: 1})
+		assert.NotNil(t, err, "expected error encoding a chan field, got nil")
+		want := item{N: 1, Data: []byte{1}}
+		err = enc.Encode(want)
+		assert.Nil(t, err, "Encode error: %v", err)
+
+		dec, err := NewStreamDecoder(&buf)
+		assert.Nil(t, err, "NewStreamDecoder error: %v", err)
+		var got item
+		err = dec.Decode(&got)
+		assert.Nil(t, err, "Decode error: %v", err)
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+		err = dec.Decode(&got)
+		assert.Equal(t, io.EOF, err, "expected error %v, got %v", io.EOF, err)
+	})
+}
@@ -81,7 +81,10 @@
 //
 //     2. When marshalling a struct, each field will be lowercased to generate the key for the corresponding BSON element.
 //     For example, a struct field named "Foo" will generate key "foo". This can be overridden via a struct tag (e.g.
-//     `bson:"fooField"` to generate key "fooField" instead).
+//     `bson:"fooField"` to generate key "fooField" instead). A StructCodec created with the FieldNamer option can use
+//     another naming strategy, such as bsoncodec.CamelCaseFieldName or bsoncodec.SnakeCaseFieldName, and one created
+//     with the UseJSONStructTags option also reads keys from json struct tags. bsoncodec.NewStructTagParser returns the
+//     struct tag parser for such a combination of options.
 //
 //     3. An embedded struct field is marshalled as a subdocument. The key will be the lowercased name of the field's type.
 //
@@ -132,6 +135,13 @@
 //     This tag can be used with fields that are pointers to structs. If an inlined pointer field is nil, it will not be
 //     marshalled. For fields that are not maps or structs, this tag is ignored.
 //
+//     5. required: If the required struct tag is specified on a field, unmarshalling a document that doesn't have the key
+//     of the field will return an error wrapping bsoncodec.ErrMissingRequiredField. A BSON null value counts as present.
+//
+// By default, keys that don't match any struct field are ignored when unmarshalling. To return an error wrapping
+// bsoncodec.ErrUnknownField instead, use a StructCodec created with the DisallowUnknownFields option, set the
+// DisallowUnknownFields field of the bsoncodec.DecodeContext, or call the DisallowUnknownFields method of a Decoder.
+//
 // Marshalling and Unmarshalling
 //
 // Manually marshalling and unmarshalling can be done with the Marshal and Unmarshal family of functions.
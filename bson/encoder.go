@@ -8,6 +8,7 @@ package bson
 
 import (
 	"errors"
+	"io"
 	"reflect"
 	"sync"
 
@@ -58,11 +59,35 @@ func NewEncoderWithContext(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter) (*
 	}, nil
 }
 
+// NewStreamEncoder returns a new encoder that uses the DefaultRegistry to write consecutive BSON documents to w, such
+// as the contents of a mongodump .bson file. Each call to Encode writes one document once it is complete, and the
+// buffer used to build it is reused for the next one. Documents larger than bsonrw.DefaultMaxDocumentSize are not
+// written and Encode returns an error. If Encode returns an error, nothing is written for that value and the next
+// value can be encoded. To use a different limit, create the encoder with NewEncoder and bsonrw.NewBSONStreamWriter.
+func NewStreamEncoder(w io.Writer) (*Encoder, error) {
+	vw, err := bsonrw.NewBSONStreamWriter(w, bsonrw.DefaultMaxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncoder(vw)
+}
+
 // Encode writes the BSON encoding of val to the stream.
 //
 // The documentation for Marshal contains details about the conversion of Go
 // values to BSON.
 func (e *Encoder) Encode(val interface{}) error {
+	err := e.encode(val)
+	if err != nil {
+		// Drop the partially written document so that it is not written together with the next one.
+		if dd, ok := e.vw.(bsonrw.DocumentDiscarder); ok {
+			dd.DiscardDocument()
+		}
+	}
+	return err
+}
+
+func (e *Encoder) encode(val interface{}) error {
 	if marshaler, ok := val.(Marshaler); ok {
 		// TODO(skriptble): Should we have a MarshalAppender interface so that we can have []byte reuse?
 		buf, err := marshaler.MarshalBSON()
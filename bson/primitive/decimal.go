@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"regexp"
 	"strconv"
@@ -184,6 +185,72 @@ func (d Decimal128) BigInt() (*big.Int, int, error) {
 	return bi, exp, nil
 }
 
+// Rat returns the exact value of d as a *big.Rat. It returns an error if d is NaN or an infinity.
+func (d Decimal128) Rat() (*big.Rat, error) {
+	u := d.unpack()
+	if u.form != finite {
+		return nil, fmt.Errorf("cannot convert %v to a *big.Rat", d)
+	}
+
+	r := new(big.Rat)
+	if u.exp >= 0 {
+		r.SetInt(u.coef.Mul(u.coef, pow10(u.exp)))
+	} else {
+		r.SetFrac(u.coef, pow10(-u.exp))
+	}
+	if u.neg {
+		r.Neg(r)
+	}
+	return r, nil
+}
+
+// BigFloat returns d as a *big.Float with the given precision, rounding to the nearest value if d cannot be
+// represented exactly. If prec is 0, a precision of 128 bits is used, which is enough for
+// ParseDecimal128FromBigFloat to convert the result back to a Decimal128 equal to d. It returns an error if d is NaN.
+func (d Decimal128) BigFloat(prec uint) (*big.Float, error) {
+	if prec == 0 {
+		prec = 128
+	}
+	f := new(big.Float).SetPrec(prec)
+
+	u := d.unpack()
+	switch u.form {
+	case nan:
+		return nil, fmt.Errorf("cannot convert %v to a *big.Float", d)
+	case infinite:
+		return f.SetInf(u.neg), nil
+	}
+
+	r, _ := d.Abs().Rat()
+	f.SetRat(r)
+	if u.neg {
+		f.Neg(f)
+	}
+	return f, nil
+}
+
+// Float64 returns the float64 value nearest to d and whether that value is exactly equal to d. NaN and the
+// infinities are converted to the matching float64 values.
+func (d Decimal128) Float64() (float64, bool) {
+	u := d.unpack()
+	switch u.form {
+	case nan:
+		return math.NaN(), true
+	case infinite:
+		if u.neg {
+			return math.Inf(-1), true
+		}
+		return math.Inf(1), true
+	}
+
+	r, _ := d.Rat()
+	f, exact := r.Float64()
+	if u.neg && f == 0 {
+		f = math.Copysign(0, -1)
+	}
+	return f, exact
+}
+
 // IsNaN returns whether d is NaN.
 func (d Decimal128) IsNaN() bool {
 	return d.h>>58&(1<<5-1) == 0x1F
@@ -407,6 +474,33 @@ func ParseDecimal128FromBigInt(bi *big.Int, exp int) (Decimal128, bool) {
 	return Decimal128{h: h, l: l}, true
 }
 
+// ParseDecimal128FromFloat64 returns the Decimal128 with the shortest decimal representation that converts back to f,
+// so ParseDecimal128FromFloat64(0.1) is 0.1 rather than the exact binary value of f.
+func ParseDecimal128FromFloat64(f float64) Decimal128 {
+	// A float64 needs at most 17 significant digits, so the result is never rounded.
+	d, _ := ParseDecimal128(strconv.FormatFloat(f, 'e', -1, 64))
+	return d
+}
+
+// ParseDecimal128FromRat converts r to a Decimal128, rounding it to 34 significant digits using mode if needed. It
+// returns the result and whether it is exactly equal to r.
+func ParseDecimal128FromRat(r *big.Rat, mode big.RoundingMode) (Decimal128, bool) {
+	return quo(r.Sign() < 0, new(big.Int).Abs(r.Num()), r.Denom(), 0, mode)
+}
+
+// ParseDecimal128FromBigFloat converts f to a Decimal128, rounding it to 34 significant digits using mode if needed.
+// It returns the result and whether it is exactly equal to f.
+func ParseDecimal128FromBigFloat(f *big.Float, mode big.RoundingMode) (Decimal128, bool) {
+	switch {
+	case f.IsInf():
+		return infinity(f.Signbit()), true
+	case f.Sign() == 0:
+		return pack(f.Signbit(), new(big.Int), 0), true
+	}
+	r, _ := f.Rat(nil)
+	return ParseDecimal128FromRat(r, mode)
+}
+
 // bigIntCmpAbs computes big.Int.Cmp(absoluteValue(x), absoluteValue(y)).
 func bigIntCmpAbs(x, y *big.Int) int {
 	xAbs := bigIntAbsValue(x)
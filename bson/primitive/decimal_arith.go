@@ -0,0 +1,427 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"math/big"
+)
+
+// decimal128Digits is the number of decimal digits in the significand of a Decimal128.
+const decimal128Digits = 34
+
+var bigOne = big.NewInt(1)
+
+// decimalForm is the kind of value held by a Decimal128.
+type decimalForm uint8
+
+const (
+	finite decimalForm = iota
+	infinite
+	nan
+)
+
+// unpacked is a Decimal128 split into its sign, coefficient and exponent. The value of a finite decimal is
+// (-1)^neg * coef * 10^exp.
+type unpacked struct {
+	form decimalForm
+	neg  bool
+	coef *big.Int
+	exp  int
+}
+
+func (d Decimal128) unpack() unpacked {
+	u := unpacked{neg: d.h>>63&1 == 1}
+	switch d.h >> 58 & (1<<5 - 1) {
+	case 0x1F:
+		u.form = nan
+		return u
+	case 0x1E:
+		u.form = infinite
+		return u
+	}
+
+	u.coef = new(big.Int)
+	if d.h>>61&3 == 3 {
+		// Significands with an implicit 0b100 prefix are out of range, so the value is zero.
+		u.exp = int(d.h>>47&(1<<14-1)) + MinDecimal128Exp
+		return u
+	}
+	u.exp = int(d.h>>49&(1<<14-1)) + MinDecimal128Exp
+	u.coef.SetUint64(d.h & (1<<49 - 1))
+	u.coef.Lsh(u.coef, 64).Or(u.coef, new(big.Int).SetUint64(d.l))
+	if u.coef.Cmp(maxS) > 0 {
+		// Non-canonical significands are treated as zero.
+		u.coef.SetInt64(0)
+	}
+	return u
+}
+
+func (u unpacked) isZero() bool {
+	return u.form == finite && u.coef.Sign() == 0
+}
+
+// pack encodes a coefficient with at most 34 digits and an exponent within the Decimal128 range.
+func pack(neg bool, coef *big.Int, exp int) Decimal128 {
+	var h, l uint64
+	b := coef.Bytes()
+	for i := 0; i < len(b); i++ {
+		if i < len(b)-8 {
+			h = h<<8 | uint64(b[i])
+			continue
+		}
+		l = l<<8 | uint64(b[i])
+	}
+
+	h |= uint64(exp-MinDecimal128Exp) & uint64(1<<14-1) << 49
+	if neg {
+		h |= 1 << 63
+	}
+	return Decimal128{h: h, l: l}
+}
+
+func infinity(neg bool) Decimal128 {
+	if neg {
+		return dNegInf
+	}
+	return dPosInf
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func numDigits(x *big.Int) int {
+	if x.Sign() == 0 {
+		return 1
+	}
+	return len(x.Text(10))
+}
+
+// roundDigits removes the last n digits of coef, rounding the result using mode. sticky indicates that nonzero digits
+// were already discarded after those of coef. It returns the rounded coefficient, whether it was rounded away from
+// zero, and whether any nonzero digits were discarded.
+func roundDigits(neg bool, coef *big.Int, n int, sticky bool, mode big.RoundingMode) (*big.Int, bool, bool) {
+	q := new(big.Int)
+	var half int // the comparison of the discarded digits to half a unit in the last place
+	inexact := sticky
+	if n > numDigits(coef) {
+		// The discarded digits are less than half of 10^n.
+		half = -1
+		inexact = inexact || coef.Sign() != 0
+	} else {
+		p := pow10(n)
+		r := new(big.Int)
+		q.QuoRem(coef, p, r)
+		half = r.Lsh(r, 1).Cmp(p)
+		if half == 0 && sticky {
+			half = 1
+		}
+		inexact = inexact || r.Sign() != 0
+	}
+	if !inexact {
+		return q, false, false
+	}
+
+	var up bool
+	switch mode {
+	case big.ToNearestEven:
+		up = half > 0 || half == 0 && q.Bit(0) == 1
+	case big.ToNearestAway:
+		up = half >= 0
+	case big.AwayFromZero:
+		up = true
+	case big.ToNegativeInf:
+		up = neg
+	case big.ToPositiveInf:
+		up = !neg
+	}
+	if up {
+		q.Add(q, bigOne)
+	}
+	return q, up, true
+}
+
+// round converts (-1)^neg * coef * 10^exp to a Decimal128, rounding it to 34 significant digits and to the exponent
+// range of a Decimal128 using mode. sticky indicates that nonzero digits were already discarded after those of coef.
+// It returns the result and whether it is exact.
+func round(neg bool, coef *big.Int, exp int, sticky bool, mode big.RoundingMode) (Decimal128, bool) {
+	inexact := sticky
+	drop := numDigits(coef) - decimal128Digits
+	if n := MinDecimal128Exp - exp; n > drop {
+		drop = n
+	}
+	if drop > 0 {
+		var up bool
+		coef, up, inexact = roundDigits(neg, coef, drop, sticky, mode)
+		exp += drop
+		if up && numDigits(coef) > decimal128Digits {
+			// Rounding carried into a new digit, so the last digit is zero.
+			coef.Quo(coef, ten)
+			exp++
+		}
+	}
+
+	if exp > MaxDecimal128Exp {
+		switch shift := exp - MaxDecimal128Exp; {
+		case coef.Sign() == 0:
+			exp = MaxDecimal128Exp
+		case numDigits(coef)+shift <= decimal128Digits:
+			// Clamp the exponent by padding the coefficient with zeros.
+			coef = new(big.Int).Mul(coef, pow10(shift))
+			exp = MaxDecimal128Exp
+		default:
+			return overflow(neg, mode), false
+		}
+	}
+	return pack(neg, coef, exp), !inexact
+}
+
+// overflow returns the result of rounding a value too large for a Decimal128, which is either infinity or the largest
+// finite value depending on the rounding direction.
+func overflow(neg bool, mode big.RoundingMode) Decimal128 {
+	switch {
+	case mode == big.ToZero,
+		mode == big.ToPositiveInf && neg,
+		mode == big.ToNegativeInf && !neg:
+		return pack(neg, maxS, MaxDecimal128Exp)
+	}
+	return infinity(neg)
+}
+
+// quo returns (-1)^neg * n/den * 10^exp. If the quotient is exact, the result has the exponent closest to exp.
+func quo(neg bool, n, den *big.Int, exp int, mode big.RoundingMode) (Decimal128, bool) {
+	if n.Sign() == 0 {
+		return round(neg, n, exp, false, mode)
+	}
+
+	// Scale n so the quotient has at least one more digit than a Decimal128 holds.
+	k := decimal128Digits + 1 + numDigits(den) - numDigits(n)
+	if k < 0 {
+		k = 0
+	}
+	q, r := new(big.Int).QuoRem(new(big.Int).Mul(n, pow10(k)), den, new(big.Int))
+	if r.Sign() == 0 {
+		qq, rr := new(big.Int), new(big.Int)
+		for ; k > 0; k-- {
+			if qq.QuoRem(q, ten, rr); rr.Sign() != 0 {
+				break
+			}
+			q, qq = qq, q
+		}
+	}
+	return round(neg, q, exp-k, r.Sign() != 0, mode)
+}
+
+// Add returns the sum d+x rounded to a Decimal128 using mode.
+//
+// The operations on Decimal128 follow the IEEE 754-2008 rules for decimal arithmetic: exact results keep the
+// preferred exponent, so 1.50 + 1 is 2.50, and results are rounded to 34 significant digits. Operations that have no
+// defined result, such as Infinity - Infinity, return NaN, and results too large to represent are rounded to infinity
+// or to the largest finite value according to mode.
+func (d Decimal128) Add(x Decimal128, mode big.RoundingMode) Decimal128 {
+	a, b := d.unpack(), x.unpack()
+	switch {
+	case a.form == nan || b.form == nan:
+		return dNaN
+	case a.form == infinite:
+		if b.form == infinite && a.neg != b.neg {
+			return dNaN
+		}
+		return infinity(a.neg)
+	case b.form == infinite:
+		return infinity(b.neg)
+	}
+
+	if a.coef.Sign() != 0 && b.coef.Sign() != 0 {
+		a, b = reduceAddend(a, b), reduceAddend(b, a)
+	}
+
+	exp := a.exp
+	if b.exp < exp {
+		exp = b.exp
+	}
+	// Adding zero does not need more digits than the other operand has.
+	if a.isZero() && !b.isZero() {
+		if e := b.exp - (decimal128Digits - numDigits(b.coef)); e > exp {
+			exp = e
+		}
+	}
+	if b.isZero() && !a.isZero() {
+		if e := a.exp - (decimal128Digits - numDigits(a.coef)); e > exp {
+			exp = e
+		}
+	}
+
+	sum := new(big.Int)
+	for _, u := range []unpacked{a, b} {
+		if u.coef.Sign() == 0 {
+			continue
+		}
+		c := new(big.Int).Mul(u.coef, pow10(u.exp-exp))
+		if u.neg {
+			c.Neg(c)
+		}
+		sum.Add(sum, c)
+	}
+
+	neg := sum.Sign() < 0
+	if sum.Sign() == 0 {
+		// The sum of zeros with different signs is +0, except when rounding towards -Infinity.
+		neg = a.neg && b.neg || a.neg != b.neg && mode == big.ToNegativeInf
+	}
+	res, _ := round(neg, sum.Abs(sum), exp, false, mode)
+	return res
+}
+
+// reduceAddend returns b, or a smaller value with the same effect on the rounded sum a+b if b is too small to affect
+// any digit of the result. This avoids scaling coefficients by up to 10^12287 to align the exponents.
+func reduceAddend(b, a unpacked) unpacked {
+	adjA := a.exp + numDigits(a.coef) - 1
+	adjB := b.exp + numDigits(b.coef) - 1
+	if adjB >= adjA-decimal128Digits-3 || b.exp >= a.exp {
+		return b
+	}
+	exp := adjA - decimal128Digits - 4
+	if a.exp < exp {
+		exp = a.exp
+	}
+	return unpacked{form: finite, neg: b.neg, coef: big.NewInt(1), exp: exp - 1}
+}
+
+// Sub returns the difference d-x rounded to a Decimal128 using mode.
+func (d Decimal128) Sub(x Decimal128, mode big.RoundingMode) Decimal128 {
+	return d.Add(x.Neg(), mode)
+}
+
+// Mul returns the product d*x rounded to a Decimal128 using mode.
+func (d Decimal128) Mul(x Decimal128, mode big.RoundingMode) Decimal128 {
+	a, b := d.unpack(), x.unpack()
+	neg := a.neg != b.neg
+	switch {
+	case a.form == nan || b.form == nan:
+		return dNaN
+	case a.form == infinite || b.form == infinite:
+		if a.isZero() || b.isZero() {
+			return dNaN
+		}
+		return infinity(neg)
+	}
+
+	res, _ := round(neg, new(big.Int).Mul(a.coef, b.coef), a.exp+b.exp, false, mode)
+	return res
+}
+
+// Quo returns the quotient d/x rounded to a Decimal128 using mode. Dividing a nonzero value by zero returns an
+// infinity, and dividing zero by zero returns NaN.
+func (d Decimal128) Quo(x Decimal128, mode big.RoundingMode) Decimal128 {
+	a, b := d.unpack(), x.unpack()
+	neg := a.neg != b.neg
+	switch {
+	case a.form == nan || b.form == nan:
+		return dNaN
+	case a.form == infinite:
+		if b.form == infinite {
+			return dNaN
+		}
+		return infinity(neg)
+	case b.form == infinite:
+		return pack(neg, new(big.Int), MinDecimal128Exp)
+	case b.isZero():
+		if a.isZero() {
+			return dNaN
+		}
+		return infinity(neg)
+	}
+
+	res, _ := quo(neg, a.coef, b.coef, a.exp-b.exp, mode)
+	return res
+}
+
+// Neg returns d with its sign inverted.
+func (d Decimal128) Neg() Decimal128 {
+	return Decimal128{h: d.h ^ 1<<63, l: d.l}
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal128) Abs() Decimal128 {
+	return Decimal128{h: d.h &^ (1 << 63), l: d.l}
+}
+
+// Round returns d rounded to the given number of decimal places using mode, so the exponent of the result is at
+// least -places. For example, rounding 2.345 to 2 places with big.ToNearestEven returns 2.34 and rounding it with
+// big.ToNearestAway returns 2.35. Values that already have fewer decimal places, NaN and infinities are returned
+// unchanged.
+func (d Decimal128) Round(places int, mode big.RoundingMode) Decimal128 {
+	u := d.unpack()
+	if u.form != finite || u.exp >= -places {
+		return d
+	}
+
+	coef, _, _ := roundDigits(u.neg, u.coef, -places-u.exp, false, mode)
+	res, _ := round(u.neg, coef, -places, false, mode)
+	return res
+}
+
+// Cmp compares d and x and returns -1 if d < x, 0 if d == x, and +1 if d > x. Values are compared numerically, so
+// 1.0 and 1.00 are equal, as are -0 and +0. NaN is equal to NaN and less than all other values, which is the order
+// the server uses when sorting.
+func (d Decimal128) Cmp(x Decimal128) int {
+	a, b := d.unpack(), x.unpack()
+	switch {
+	case a.form == nan && b.form == nan:
+		return 0
+	case a.form == nan:
+		return -1
+	case b.form == nan:
+		return 1
+	}
+
+	sa, sb := a.sign(), b.sign()
+	switch {
+	case sa < sb:
+		return -1
+	case sa > sb:
+		return 1
+	case sa == 0:
+		return 0
+	}
+	return sa * cmpAbs(a, b)
+}
+
+func (u unpacked) sign() int {
+	switch {
+	case u.form == finite && u.coef.Sign() == 0:
+		return 0
+	case u.neg:
+		return -1
+	}
+	return 1
+}
+
+// cmpAbs compares the magnitudes of the nonzero values a and b.
+func cmpAbs(a, b unpacked) int {
+	switch {
+	case a.form == infinite && b.form == infinite:
+		return 0
+	case a.form == infinite:
+		return 1
+	case b.form == infinite:
+		return -1
+	}
+
+	adjA := a.exp + numDigits(a.coef) - 1
+	adjB := b.exp + numDigits(b.coef) - 1
+	switch {
+	case adjA < adjB:
+		return -1
+	case adjA > adjB:
+		return 1
+	case a.exp > b.exp:
+		return new(big.Int).Mul(a.coef, pow10(a.exp-b.exp)).Cmp(b.coef)
+	}
+	return a.coef.Cmp(new(big.Int).Mul(b.coef, pow10(b.exp-a.exp)))
+}
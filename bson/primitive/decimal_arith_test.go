@@ -0,0 +1,349 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path"
+	"strconv"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func mustParseDecimal128(t *testing.T, s string) Decimal128 {
+	t.Helper()
+
+	d, err := ParseDecimal128(s)
+	assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+	return d
+}
+
+func TestDecimal128Arithmetic(t *testing.T) {
+	testCases := []struct {
+		op       string
+		x, y     string
+		mode     big.RoundingMode
+		expected string
+	}{
+		{"add", "1.50", "1", big.ToNearestEven, "2.50"},
+		{"add", "1E+2", "1", big.ToNearestEven, "101"},
+		{"add", "0E+5", "1.5", big.ToNearestEven, "1.5"},
+		{"add", "0E-10", "1.5", big.ToNearestEven, "1.5000000000"},
+		{"add", "9999999999999999999999999999999999", "1", big.ToNearestEven, "1.000000000000000000000000000000000E+34"},
+		{"add", "9999999999999999999999999999999999", "0.5", big.ToNearestEven, "1.000000000000000000000000000000000E+34"},
+		{"add", "9999999999999999999999999999999998", "0.5", big.ToNearestEven, "9999999999999999999999999999999998"},
+		{"add", "9999999999999999999999999999999998", "0.5", big.ToNearestAway, "9999999999999999999999999999999999"},
+		{"add", "1", "1E-40", big.ToNearestEven, "1.000000000000000000000000000000000"},
+		{"add", "1", "1E-40", big.ToPositiveInf, "1.000000000000000000000000000000001"},
+		{"add", "1", "1E-6176", big.AwayFromZero, "1.000000000000000000000000000000001"},
+		{"add", "1", "-1E-40", big.ToNearestEven, "1.000000000000000000000000000000000"},
+		{"add", "1", "-1E-40", big.ToZero, "0.9999999999999999999999999999999999"},
+		{"add", "-1", "1E-40", big.ToNegativeInf, "-1.000000000000000000000000000000000"},
+		{"add", "1", "-1", big.ToNearestEven, "0"},
+		{"add", "1", "-1", big.ToNegativeInf, "-0"},
+		{"add", "-0", "0", big.ToNearestEven, "0"},
+		{"add", "-0", "-0", big.ToNearestEven, "-0"},
+		{"add", "Infinity", "1", big.ToNearestEven, "Infinity"},
+		{"add", "Infinity", "-Infinity", big.ToNearestEven, "NaN"},
+		{"add", "NaN", "1", big.ToNearestEven, "NaN"},
+		{"add", "9.999999999999999999999999999999999E+6144", "9E+6144", big.ToNearestEven, "Infinity"},
+		{"add", "9.999999999999999999999999999999999E+6144", "9E+6144", big.ToZero, "9.999999999999999999999999999999999E+6144"},
+		{"add", "-9.999999999999999999999999999999999E+6144", "-9E+6144", big.ToPositiveInf,
+			"-9.999999999999999999999999999999999E+6144"},
+		{"sub", "2.50", "1", big.ToNearestEven, "1.50"},
+		{"sub", "1", "1", big.ToNearestEven, "0"},
+		{"sub", "Infinity", "Infinity", big.ToNearestEven, "NaN"},
+		{"sub", "-Infinity", "Infinity", big.ToNearestEven, "-Infinity"},
+		{"mul", "1.5", "2", big.ToNearestEven, "3.0"},
+		{"mul", "-2", "0", big.ToNearestEven, "-0"},
+		{"mul", "1.1", "1.1", big.ToNearestEven, "1.21"},
+		{"mul", "Infinity", "0", big.ToNearestEven, "NaN"},
+		{"mul", "-Infinity", "2", big.ToNearestEven, "-Infinity"},
+		{"mul", "1E-6176", "0.1", big.ToNearestEven, "0E-6176"},
+		{"mul", "1E-6176", "0.1", big.AwayFromZero, "1E-6176"},
+		{"mul", "1E+6000", "1E+200", big.ToNearestEven, "Infinity"},
+		{"mul", "1E+6000", "1E+111", big.ToNearestEven, "1E+6111"},
+		{"mul", "1E+6000", "1E+120", big.ToNearestEven, "1.000000000E+6120"},
+		{"mul", "1111111111111111111111111111111111", "3", big.ToNearestEven, "3333333333333333333333333333333333"},
+		{"mul", "9999999999999999999999999999999999", "9", big.ToNearestEven, "8.999999999999999999999999999999999E+34"},
+		{"quo", "1", "3", big.ToNearestEven, "0.3333333333333333333333333333333333"},
+		{"quo", "2", "3", big.ToNearestEven, "0.6666666666666666666666666666666667"},
+		{"quo", "2", "3", big.ToZero, "0.6666666666666666666666666666666666"},
+		{"quo", "-2", "3", big.ToNegativeInf, "-0.6666666666666666666666666666666667"},
+		{"quo", "1", "4", big.ToNearestEven, "0.25"},
+		{"quo", "10", "2", big.ToNearestEven, "5"},
+		{"quo", "1.00", "2", big.ToNearestEven, "0.50"},
+		{"quo", "6", "2.0", big.ToNearestEven, "3"},
+		{"quo", "1000", "1E+2", big.ToNearestEven, "10.00"},
+		{"quo", "0.00", "3", big.ToNearestEven, "0.00"},
+		{"quo", "1", "0", big.ToNearestEven, "Infinity"},
+		{"quo", "-1", "0", big.ToNearestEven, "-Infinity"},
+		{"quo", "0", "0", big.ToNearestEven, "NaN"},
+		{"quo", "1", "Infinity", big.ToNearestEven, "0E-6176"},
+		{"quo", "Infinity", "-2", big.ToNearestEven, "-Infinity"},
+		{"quo", "Infinity", "Infinity", big.ToNearestEven, "NaN"},
+		{"quo", "1E+6111", "1E-10", big.ToNearestEven, "1.0000000000E+6121"},
+		{"quo", "1E+6111", "1E-40", big.ToNearestEven, "Infinity"},
+		{"quo", "1E-6176", "2", big.ToNearestEven, "0E-6176"},
+		{"quo", "3E-6176", "2", big.ToNearestEven, "2E-6176"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.op+" "+tc.x+" "+tc.y, func(t *testing.T) {
+			x, y := mustParseDecimal128(t, tc.x), mustParseDecimal128(t, tc.y)
+			var got Decimal128
+			switch tc.op {
+			case "add":
+				got = x.Add(y, tc.mode)
+			case "sub":
+				got = x.Sub(y, tc.mode)
+			case "mul":
+				got = x.Mul(y, tc.mode)
+			case "quo":
+				got = x.Quo(y, tc.mode)
+			}
+			assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+		})
+	}
+}
+
+func TestDecimal128Round(t *testing.T) {
+	testCases := []struct {
+		x        string
+		places   int
+		mode     big.RoundingMode
+		expected string
+	}{
+		{"2.345", 2, big.ToNearestEven, "2.34"},
+		{"2.345", 2, big.ToNearestAway, "2.35"},
+		{"2.3451", 2, big.ToNearestEven, "2.35"},
+		{"-2.345", 2, big.ToNegativeInf, "-2.35"},
+		{"-2.345", 2, big.ToPositiveInf, "-2.34"},
+		{"2.5", 0, big.ToNearestEven, "2"},
+		{"3.5", 0, big.ToNearestEven, "4"},
+		{"2.1", 0, big.AwayFromZero, "3"},
+		{"2.9", 0, big.ToZero, "2"},
+		{"1.2", 3, big.ToNearestEven, "1.2"},
+		{"-0.001", 2, big.ToNearestEven, "-0.00"},
+		{"9.99", 1, big.ToNearestEven, "10.0"},
+		{"123", -1, big.ToNearestEven, "1.2E+2"},
+		{"0.0004", 2, big.ToNearestEven, "0.00"},
+		{"1E-6176", 2, big.AwayFromZero, "0.01"},
+		{"NaN", 2, big.ToNearestEven, "NaN"},
+		{"-Infinity", 2, big.ToNearestEven, "-Infinity"},
+	}
+	for _, tc := range testCases {
+		got := mustParseDecimal128(t, tc.x).Round(tc.places, tc.mode)
+		assert.Equal(t, tc.expected, got.String(), "expected %v rounded to %v places to be %v, got %v", tc.x, tc.places,
+			tc.expected, got)
+	}
+}
+
+func TestDecimal128Cmp(t *testing.T) {
+	testCases := []struct {
+		x, y     string
+		expected int
+	}{
+		{"1.0", "1.00", 0},
+		{"-0", "0", 0},
+		{"0E+10", "0E-10", 0},
+		{"1E+2", "99", 1},
+		{"99", "1E+2", -1},
+		{"-1", "-2", 1},
+		{"-1", "1", -1},
+		{"0.1", "0.1000000000000000000000000000000000", 0},
+		{"0.1000000000000000000000000000000001", "0.1", 1},
+		{"Infinity", "9.999999999999999999999999999999999E+6144", 1},
+		{"-Infinity", "-Infinity", 0},
+		{"NaN", "-Infinity", -1},
+		{"1", "NaN", 1},
+		{"NaN", "NaN", 0},
+	}
+	for _, tc := range testCases {
+		got := mustParseDecimal128(t, tc.x).Cmp(mustParseDecimal128(t, tc.y))
+		assert.Equal(t, tc.expected, got, "expected Cmp(%v, %v) to be %v, got %v", tc.x, tc.y, tc.expected, got)
+	}
+}
+
+func TestDecimal128Conversions(t *testing.T) {
+	t.Run("Neg and Abs", func(t *testing.T) {
+		d := mustParseDecimal128(t, "-1.50")
+		assert.Equal(t, "1.50", d.Neg().String(), "expected 1.50, got %v", d.Neg())
+		assert.Equal(t, "1.50", d.Abs().String(), "expected 1.50, got %v", d.Abs())
+		assert.Equal(t, "-1.50", d.Abs().Neg().String(), "expected -1.50, got %v", d.Abs().Neg())
+	})
+	t.Run("Float64", func(t *testing.T) {
+		testCases := []struct {
+			x        string
+			expected float64
+			exact    bool
+		}{
+			{"0.1", 0.1, false},
+			{"0.5", 0.5, true},
+			{"-12345678901234567890", -12345678901234567890, false},
+			{"1E+400", math.Inf(1), false},
+			{"Infinity", math.Inf(1), true},
+		}
+		for _, tc := range testCases {
+			got, exact := mustParseDecimal128(t, tc.x).Float64()
+			assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+			assert.Equal(t, tc.exact, exact, "expected exact to be %v for %v, got %v", tc.exact, tc.x, exact)
+		}
+
+		got, _ := mustParseDecimal128(t, "-0").Float64()
+		assert.True(t, got == 0 && math.Signbit(got), "expected -0, got %v", got)
+		got, _ = mustParseDecimal128(t, "NaN").Float64()
+		assert.True(t, math.IsNaN(got), "expected NaN, got %v", got)
+	})
+	t.Run("ParseDecimal128FromFloat64", func(t *testing.T) {
+		testCases := []struct {
+			f        float64
+			expected string
+		}{
+			{0.1, "0.1"},
+			{-2.5e-10, "-2.5E-10"},
+			{1e300, "1E+300"},
+			{math.Copysign(0, -1), "-0"},
+			{math.Inf(-1), "-Infinity"},
+			{math.NaN(), "NaN"},
+		}
+		for _, tc := range testCases {
+			got := ParseDecimal128FromFloat64(tc.f)
+			assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+		}
+	})
+	t.Run("ParseDecimal128FromRat", func(t *testing.T) {
+		testCases := []struct {
+			r        *big.Rat
+			mode     big.RoundingMode
+			expected string
+			exact    bool
+		}{
+			{big.NewRat(1, 3), big.ToNearestEven, "0.3333333333333333333333333333333333", false},
+			{big.NewRat(1, 3), big.ToPositiveInf, "0.3333333333333333333333333333333334", false},
+			{big.NewRat(5, 4), big.ToNearestEven, "1.25", true},
+			{big.NewRat(-7, 1), big.ToNearestEven, "-7", true},
+			{new(big.Rat), big.ToNearestEven, "0", true},
+		}
+		for _, tc := range testCases {
+			got, exact := ParseDecimal128FromRat(tc.r, tc.mode)
+			assert.Equal(t, tc.expected, got.String(), "expected %v, got %v", tc.expected, got)
+			assert.Equal(t, tc.exact, exact, "expected exact to be %v for %v, got %v", tc.exact, tc.r, exact)
+		}
+	})
+	t.Run("BigFloat", func(t *testing.T) {
+		f, err := mustParseDecimal128(t, "0.1").BigFloat(53)
+		assert.Nil(t, err, "BigFloat error: %v", err)
+		got, _ := f.Float64()
+		assert.Equal(t, 0.1, got, "expected 0.1, got %v", got)
+
+		f, err = mustParseDecimal128(t, "-Infinity").BigFloat(0)
+		assert.Nil(t, err, "BigFloat error: %v", err)
+		assert.True(t, f.IsInf() && f.Signbit(), "expected -Inf, got %v", f)
+
+		_, err = mustParseDecimal128(t, "NaN").BigFloat(0)
+		assert.NotNil(t, err, "expected error, got nil")
+
+		d, exact := ParseDecimal128FromBigFloat(big.NewFloat(0.1), big.ToNearestEven)
+		assert.Equal(t, "0.1000000000000000055511151231257827", d.String(), "expected the value of float64(0.1), got %v", d)
+		assert.False(t, exact, "expected inexact conversion")
+
+		d, exact = ParseDecimal128FromBigFloat(new(big.Float).Neg(new(big.Float)), big.ToNearestEven)
+		assert.Equal(t, "-0", d.String(), "expected -0, got %v", d)
+		assert.True(t, exact, "expected exact conversion")
+	})
+}
+
+// TestDecimal128Corpus checks arithmetic identities and conversions for every valid decimal128 value in the BSON
+// corpus.
+func TestDecimal128Corpus(t *testing.T) {
+	const dir = "../../data/bson-corpus"
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err, "ReadDir error: %v", err)
+
+	var zeroMaxExp = pack(false, new(big.Int), MaxDecimal128Exp)
+	var oneValue = pack(false, big.NewInt(1), 0)
+
+	for _, file := range files {
+		if ok, _ := path.Match("decimal128-*.json", file.Name()); !ok {
+			continue
+		}
+		content, err := ioutil.ReadFile(path.Join(dir, file.Name()))
+		assert.Nil(t, err, "ReadFile error: %v", err)
+
+		var test struct {
+			Valid []struct {
+				Description   string `json:"description"`
+				CanonicalBSON string `json:"canonical_bson"`
+			} `json:"valid"`
+		}
+		err = json.Unmarshal(content, &test)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		for _, v := range test.Valid {
+			t.Run(file.Name()+" "+v.Description, func(t *testing.T) {
+				b, err := hex.DecodeString(v.CanonicalBSON)
+				assert.Nil(t, err, "DecodeString error: %v", err)
+				// The value follows the length, the type byte and the key "d".
+				d := NewDecimal128(binary.LittleEndian.Uint64(b[15:23]), binary.LittleEndian.Uint64(b[7:15]))
+
+				assert.True(t, d == d.Neg().Neg(), "expected -(-%v) to be unchanged", d)
+				assert.Equal(t, 0, d.Cmp(d), "expected %v to equal itself", d)
+
+				if d.IsNaN() {
+					assert.True(t, d.Add(oneValue, big.ToNearestEven).IsNaN(), "expected NaN + 1 to be NaN")
+					assert.True(t, d.Mul(oneValue, big.ToNearestEven).IsNaN(), "expected NaN * 1 to be NaN")
+					return
+				}
+
+				// Multiplying or dividing by 1 and adding a zero are exact and keep the exponent. The results are
+				// always canonical, so values with invalid significands become zeros.
+				canonical := d
+				if u := d.unpack(); u.form == finite {
+					canonical = pack(u.neg, u.coef, u.exp)
+				}
+				assert.True(t, canonical == d.Mul(oneValue, big.ToNearestEven), "expected %v * 1 to be unchanged", d)
+				assert.True(t, canonical == d.Quo(oneValue, big.ToNearestEven), "expected %v / 1 to be unchanged", d)
+				if sum := d.Add(zeroMaxExp, big.ToNearestEven); !d.unpack().isZero() || d.h>>63 == 0 {
+					assert.True(t, canonical == sum, "expected %v + 0 to be unchanged, got %v", d, sum)
+				}
+
+				f, _ := d.Float64()
+				expected, err := strconv.ParseFloat(d.String(), 64)
+				if ne, ok := err.(*strconv.NumError); ok && ne.Err != strconv.ErrRange {
+					t.Fatalf("ParseFloat error: %v", err)
+				}
+				assert.Equal(t, math.Float64bits(expected), math.Float64bits(f), "expected %v to convert to %v, got %v",
+					d, expected, f)
+
+				if d.IsInf() != 0 {
+					assert.True(t, d.Sub(d, big.ToNearestEven).IsNaN(), "expected %v - %v to be NaN", d, d)
+					return
+				}
+				diff := d.Sub(d, big.ToNearestEven)
+				assert.True(t, diff.unpack().isZero(), "expected %v - %v to be zero, got %v", d, d, diff)
+
+				r, err := d.Rat()
+				assert.Nil(t, err, "Rat error: %v", err)
+				fromRat, exact := ParseDecimal128FromRat(r, big.ToNearestEven)
+				assert.True(t, exact, "expected %v to convert exactly from a *big.Rat", d)
+				assert.Equal(t, 0, d.Cmp(fromRat), "expected %v after conversion from a *big.Rat, got %v", d, fromRat)
+
+				bf, err := d.BigFloat(0)
+				assert.Nil(t, err, "BigFloat error: %v", err)
+				fromFloat, _ := ParseDecimal128FromBigFloat(bf, big.ToNearestEven)
+				assert.Equal(t, 0, d.Cmp(fromFloat), "expected %v after conversion from a *big.Float, got %v", d,
+					fromFloat)
+			})
+		}
+	}
+}
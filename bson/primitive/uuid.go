@@ -0,0 +1,180 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
+)
+
+// ErrInvalidUUID indicates that a string cannot be converted to a UUID.
+var ErrInvalidUUID = errors.New("the provided string is not a valid UUID")
+
+// UUID is a universally unique identifier. By default, it is stored in BSON as binary subtype 4.
+type UUID [16]byte
+
+// NilUUID is the zero value for UUID.
+var NilUUID UUID
+
+var _ encoding.TextMarshaler = UUID{}
+var _ encoding.TextUnmarshaler = &UUID{}
+
+// NewUUID generates a new random (version 4) UUID.
+func NewUUID() (UUID, error) {
+	u, err := uuid.New()
+	return UUID(u), err
+}
+
+// UUIDFromString creates a new UUID from a string in the canonical form "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" or
+// from 32 hex digits without hyphens. It returns an error if the string is not a valid UUID.
+func UUIDFromString(s string) (UUID, error) {
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return NilUUID, ErrInvalidUUID
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return NilUUID, ErrInvalidUUID
+	}
+
+	var u UUID
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return NilUUID, ErrInvalidUUID
+	}
+	return u, nil
+}
+
+// String returns the UUID in the canonical form "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// IsZero returns true if u is the empty UUID.
+func (u UUID) IsZero() bool {
+	return u == NilUUID
+}
+
+// MarshalText returns the UUID as UTF-8-encoded text in its canonical form. Implementing this allows us to use UUID
+// as a map key when marshalling JSON. See https://pkg.go.dev/encoding#TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText populates the UUID from its text form. Implementing this allows us to use UUID as a map key when
+// unmarshalling JSON. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (u *UUID) UnmarshalText(b []byte) error {
+	parsed, err := UUIDFromString(string(b))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// UUIDRepresentation specifies how a UUID is stored as a BSON binary value. Data written by the legacy Java, C# and
+// Python drivers uses binary subtype 3 and a driver-specific byte order.
+type UUIDRepresentation uint8
+
+// These constants are the supported UUID representations.
+const (
+	// StandardUUIDRepresentation stores UUIDs as binary subtype 4 in the byte order defined by RFC 4122.
+	StandardUUIDRepresentation UUIDRepresentation = iota
+	// JavaLegacyUUIDRepresentation stores UUIDs as binary subtype 3 with each half of the UUID in reverse byte order.
+	JavaLegacyUUIDRepresentation
+	// CSharpLegacyUUIDRepresentation stores UUIDs as binary subtype 3 with the first three groups of the UUID in
+	// reverse byte order.
+	CSharpLegacyUUIDRepresentation
+	// PythonLegacyUUIDRepresentation stores UUIDs as binary subtype 3 in the byte order defined by RFC 4122.
+	PythonLegacyUUIDRepresentation
+)
+
+// String returns the name of the representation as used in connection strings, such as "javaLegacy".
+func (r UUIDRepresentation) String() string {
+	switch r {
+	case StandardUUIDRepresentation:
+		return "standard"
+	case JavaLegacyUUIDRepresentation:
+		return "javaLegacy"
+	case CSharpLegacyUUIDRepresentation:
+		return "csharpLegacy"
+	case PythonLegacyUUIDRepresentation:
+		return "pythonLegacy"
+	}
+	return fmt.Sprintf("UUIDRepresentation(%d)", uint8(r))
+}
+
+// reorder converts between the RFC 4122 byte order and the byte order of the representation. The conversion is its
+// own inverse.
+func (r UUIDRepresentation) reorder(b []byte) {
+	reverse := func(b []byte) {
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+	}
+
+	switch r {
+	case JavaLegacyUUIDRepresentation:
+		reverse(b[0:8])
+		reverse(b[8:16])
+	case CSharpLegacyUUIDRepresentation:
+		reverse(b[0:4])
+		reverse(b[4:6])
+		reverse(b[6:8])
+	}
+}
+
+// NewBinaryFromUUID returns the BSON binary value that stores u using representation r.
+func NewBinaryFromUUID(u UUID, r UUIDRepresentation) Binary {
+	data := make([]byte, len(u))
+	copy(data, u[:])
+	if r == StandardUUIDRepresentation {
+		return Binary{Subtype: bsontype.BinaryUUID, Data: data}
+	}
+	r.reorder(data)
+	return Binary{Subtype: bsontype.BinaryUUIDOld, Data: data}
+}
+
+// UUIDFromBinary returns the UUID stored in b. Binary subtype 4 is always read in the standard byte order, and binary
+// subtype 3 is read using the byte order of the legacy representation r. It returns an error if b is not 16 bytes
+// long, if b has a different subtype, or if b has subtype 3 and r is StandardUUIDRepresentation, because the byte
+// order of the value is unknown.
+func UUIDFromBinary(b Binary, r UUIDRepresentation) (UUID, error) {
+	if len(b.Data) != len(NilUUID) {
+		return NilUUID, fmt.Errorf("cannot convert binary value of length %d to a UUID", len(b.Data))
+	}
+
+	var u UUID
+	copy(u[:], b.Data)
+	switch {
+	case b.Subtype == bsontype.BinaryUUID:
+		return u, nil
+	case b.Subtype != bsontype.BinaryUUIDOld:
+		return NilUUID, fmt.Errorf("cannot convert binary subtype %d to a UUID", b.Subtype)
+	case r == StandardUUIDRepresentation:
+		return NilUUID, errors.New("cannot convert binary subtype 3 to a UUID with the standard representation, " +
+			"use a legacy representation instead")
+	}
+	r.reorder(u[:])
+	return u, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestUUID(t *testing.T) {
+	const canonical = "00112233-4455-6677-8899-aabbccddeeff"
+
+	t.Run("UUIDFromString", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			s     string
+			valid bool
+		}{
+			{"canonical", canonical, true},
+			{"upper case", "00112233-4455-6677-8899-AABBCCDDEEFF", true},
+			{"without hyphens", "00112233445566778899aabbccddeeff", true},
+			{"misplaced hyphen", "0011223-34455-6677-8899-aabbccddeeff", false},
+			{"invalid hex", "00112233-4455-6677-8899-aabbccddeefg", false},
+			{"too short", "00112233-4455-6677-8899-aabbccddee", false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				u, err := UUIDFromString(tc.s)
+				if !tc.valid {
+					assert.Equal(t, ErrInvalidUUID, err, "expected error %v, got %v", ErrInvalidUUID, err)
+					return
+				}
+				assert.Nil(t, err, "UUIDFromString error: %v", err)
+				assert.Equal(t, canonical, u.String(), "expected %v, got %v", canonical, u)
+			})
+		}
+	})
+	t.Run("NewUUID", func(t *testing.T) {
+		u, err := NewUUID()
+		assert.Nil(t, err, "NewUUID error: %v", err)
+		assert.False(t, u.IsZero(), "expected a non-zero UUID")
+		assert.Equal(t, byte(0x40), u[6]&0xf0, "expected version 4, got %v", u[6]>>4)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		u, err := UUIDFromString(canonical)
+		assert.Nil(t, err, "UUIDFromString error: %v", err)
+
+		b, err := json.Marshal(map[UUID]UUID{u: u})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		expected := `{"` + canonical + `":"` + canonical + `"}`
+		assert.Equal(t, expected, string(b), "expected %v, got %v", expected, string(b))
+
+		var got map[UUID]UUID
+		err = json.Unmarshal(b, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, u, got[u], "expected %v, got %v", u, got[u])
+	})
+}
+
+func TestUUIDRepresentation(t *testing.T) {
+	u, err := UUIDFromString("00112233-4455-6677-8899-aabbccddeeff")
+	assert.Nil(t, err, "UUIDFromString error: %v", err)
+
+	testCases := []struct {
+		r       UUIDRepresentation
+		subtype byte
+		data    string
+	}{
+		{StandardUUIDRepresentation, 4, "00112233445566778899aabbccddeeff"},
+		{JavaLegacyUUIDRepresentation, 3, "7766554433221100ffeeddccbbaa9988"},
+		{CSharpLegacyUUIDRepresentation, 3, "33221100554477668899aabbccddeeff"},
+		{PythonLegacyUUIDRepresentation, 3, "00112233445566778899aabbccddeeff"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.r.String(), func(t *testing.T) {
+			b := NewBinaryFromUUID(u, tc.r)
+			assert.Equal(t, tc.subtype, b.Subtype, "expected subtype %v, got %v", tc.subtype, b.Subtype)
+			assert.Equal(t, tc.data, hex.EncodeToString(b.Data), "expected data %v, got %x", tc.data, b.Data)
+
+			got, err := UUIDFromBinary(b, tc.r)
+			assert.Nil(t, err, "UUIDFromBinary error: %v", err)
+			assert.Equal(t, u, got, "expected %v, got %v", u, got)
+
+			// Subtype 4 is read in the standard byte order regardless of the representation.
+			got, err = UUIDFromBinary(NewBinaryFromUUID(u, StandardUUIDRepresentation), tc.r)
+			assert.Nil(t, err, "UUIDFromBinary error: %v", err)
+			assert.Equal(t, u, got, "expected %v, got %v", u, got)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := UUIDFromBinary(NewBinaryFromUUID(u, JavaLegacyUUIDRepresentation), StandardUUIDRepresentation)
+		assert.NotNil(t, err, "expected error reading subtype 3 with the standard representation")
+		_, err = UUIDFromBinary(Binary{Subtype: 0, Data: u[:]}, StandardUUIDRepresentation)
+		assert.NotNil(t, err, "expected error reading subtype 0")
+		_, err = UUIDFromBinary(Binary{Subtype: 4, Data: u[:8]}, StandardUUIDRepresentation)
+		assert.NotNil(t, err, "expected error reading 8 bytes")
+	})
+}
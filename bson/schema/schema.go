@@ -0,0 +1,460 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package schema generates $jsonSchema documents that describe the BSON documents the bson package produces for Go
+// structs. The generated validators can be passed to CreateCollectionOptions.SetValidator or used as the validator
+// of a collMod command, so that a collection validator stays in sync with the struct it guards:
+//
+//	validator, err := schema.Validator(Person{})
+//	if err != nil {
+//		return err
+//	}
+//	err = db.CreateCollection(ctx, "people", options.CreateCollection().SetValidator(validator))
+//
+// Struct fields are described using the same struct tags as the StructCodec:
+//
+//   - Each exported field is a property named by the key from its struct tag.
+//   - Fields without omitempty are always written by the encoder, so they are required.
+//   - Inlined structs add their fields to the enclosing struct. Fields of an inlined struct pointer are not required
+//     because they are not written when the pointer is nil. An inlined map describes the additional properties.
+//   - Nested structs are described by nested object schemas.
+//   - An enum option lists the allowed values of a field separated by '|', as in `bson:"status,enum=active|inactive"`.
+//
+// The bsonType of a field is the BSON type the encoder writes for it. Go types in the type map of the registry are
+// described by the BSON types they are registered for with RegisterTypeMapEntry, and other integer types by the int
+// and long types they can be encoded as. Integers with the minsize option can always be int or long. Pointers, slices
+// and maps that aren't omitempty can also be null.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bsonTypeAliases are the $jsonSchema bsonType aliases in the order of the BSON types.
+var bsonTypeAliases = []struct {
+	t     bsontype.Type
+	alias string
+}{
+	{bsontype.Double, "double"},
+	{bsontype.String, "string"},
+	{bsontype.EmbeddedDocument, "object"},
+	{bsontype.Array, "array"},
+	{bsontype.Binary, "binData"},
+	{bsontype.Undefined, "undefined"},
+	{bsontype.ObjectID, "objectId"},
+	{bsontype.Boolean, "bool"},
+	{bsontype.DateTime, "date"},
+	{bsontype.Null, "null"},
+	{bsontype.Regex, "regex"},
+	{bsontype.DBPointer, "dbPointer"},
+	{bsontype.JavaScript, "javascript"},
+	{bsontype.Symbol, "symbol"},
+	{bsontype.CodeWithScope, "javascriptWithScope"},
+	{bsontype.Int32, "int"},
+	{bsontype.Timestamp, "timestamp"},
+	{bsontype.Int64, "long"},
+	{bsontype.Decimal128, "decimal"},
+	{bsontype.MinKey, "minKey"},
+	{bsontype.MaxKey, "maxKey"},
+}
+
+var (
+	tByte           = reflect.TypeOf(byte(0))
+	tE              = reflect.TypeOf(primitive.E{})
+	tTime           = reflect.TypeOf(time.Time{})
+	tUUID           = reflect.TypeOf(primitive.UUID{})
+	tRaw            = reflect.TypeOf(bson.Raw(nil))
+	tMarshaler      = reflect.TypeOf((*bson.Marshaler)(nil)).Elem()
+	tValueMarshaler = reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem()
+)
+
+// Generator generates $jsonSchema documents for struct types. The zero value uses bson.DefaultRegistry and
+// bsoncodec.DefaultStructTagParser.
+type Generator struct {
+	// Registry is the registry whose type map is used to find the BSON types of fields.
+	Registry *bsoncodec.Registry
+
+	// StructTagParser parses the struct tags of fields. It should be the parser used by the StructCodec of Registry.
+	// For a StructCodec created with the FieldNamer or UseJSONStructTags option, bsoncodec.NewStructTagParser returns
+	// the parser that produces the same keys.
+	StructTagParser bsoncodec.StructTagParser
+}
+
+// JSONSchema returns a $jsonSchema document for the struct type of val using the default Generator.
+func JSONSchema(val interface{}) (bson.D, error) {
+	return Generator{}.JSONSchema(val)
+}
+
+// Validator returns a collection validator of the form {$jsonSchema: <schema>} for the struct type of val using the
+// default Generator.
+func Validator(val interface{}) (bson.D, error) {
+	return Generator{}.Validator(val)
+}
+
+// Validator returns a collection validator of the form {$jsonSchema: <schema>} for the struct type of val.
+func (g Generator) Validator(val interface{}) (bson.D, error) {
+	s, err := g.JSONSchema(val)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: "$jsonSchema", Value: s}}, nil
+}
+
+// JSONSchema returns a $jsonSchema document for the struct type of val. The val parameter can be a struct, a pointer
+// to a struct or the reflect.Type of a struct.
+func (g Generator) JSONSchema(val interface{}) (bson.D, error) {
+	t, ok := val.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(val)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot generate a schema for %v, a struct or struct pointer is required", t)
+	}
+
+	sg := &generator{
+		parser:   g.StructTagParser,
+		typeMap:  make(map[reflect.Type][]string),
+		visiting: make(map[reflect.Type]bool),
+	}
+	if sg.parser == nil {
+		sg.parser = bsoncodec.DefaultStructTagParser
+	}
+	reg := g.Registry
+	if reg == nil {
+		reg = bson.DefaultRegistry
+	}
+	for _, bt := range bsonTypeAliases {
+		if rt, err := reg.LookupTypeMapEntry(bt.t); err == nil {
+			sg.typeMap[rt] = append(sg.typeMap[rt], bt.alias)
+		}
+	}
+
+	doc, err := sg.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	return append(bson.D{{Key: "bsonType", Value: "object"}}, doc...), nil
+}
+
+// generator holds the state of a single schema generation.
+type generator struct {
+	parser   bsoncodec.StructTagParser
+	typeMap  map[reflect.Type][]string
+	visiting map[reflect.Type]bool
+}
+
+// field describes a property of a struct schema.
+type field struct {
+	name     string
+	typ      reflect.Type
+	tags     bsoncodec.StructTags
+	enum     []string
+	depth    int
+	optional bool
+}
+
+// structSchema returns the required, properties and additionalProperties keywords for the struct type t. Recursive
+// struct types are only described down to the first repetition.
+func (g *generator) structSchema(t reflect.Type) (bson.D, error) {
+	if g.visiting[t] {
+		return nil, nil
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	fields, inlineMap, err := g.structFields(t, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var required bson.A
+	properties := bson.D{}
+	for _, f := range fields {
+		s, err := g.valueSchema(f.typ, f.tags, f.enum)
+		if err != nil {
+			return nil, fmt.Errorf("(struct %s) field %s: %v", t, f.name, err)
+		}
+		properties = append(properties, bson.E{Key: f.name, Value: s})
+		if !f.tags.OmitEmpty && !f.optional {
+			required = append(required, f.name)
+		}
+	}
+
+	var doc bson.D
+	if len(required) > 0 {
+		doc = append(doc, bson.E{Key: "required", Value: required})
+	}
+	doc = append(doc, bson.E{Key: "properties", Value: properties})
+	if inlineMap != nil {
+		s, err := g.valueSchema(inlineMap.Elem(), bsoncodec.StructTags{}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("(struct %s) inline map: %v", t, err)
+		}
+		doc = append(doc, bson.E{Key: "additionalProperties", Value: s})
+	}
+	return doc, nil
+}
+
+// structFields returns the fields of the struct type t in encoding order, including the fields of inlined structs,
+// and the type of the inline map of t if it has one. Duplicate keys are resolved like the StructCodec does: the
+// least nested field wins and fields at the same depth conflict.
+func (g *generator) structFields(t reflect.Type, depth int, optional bool) ([]field, reflect.Type, error) {
+	var fields []field
+	var inlineMap reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		tags, err := g.parser.ParseStructTags(sf)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tags.Skip {
+			continue
+		}
+
+		if !tags.Inline {
+			fields = append(fields, field{
+				name:     tags.Name,
+				typ:      sf.Type,
+				tags:     tags,
+				enum:     enumValues(sf),
+				depth:    depth,
+				optional: optional,
+			})
+			continue
+		}
+
+		ft, inlineOptional := sf.Type, optional
+		switch ft.Kind() {
+		case reflect.Map:
+			if inlineMap != nil {
+				return nil, nil, fmt.Errorf("(struct %s) multiple inline maps", t)
+			}
+			if ft.Key().Kind() != reflect.String {
+				return nil, nil, fmt.Errorf("(struct %s) inline map must have a string keys", t)
+			}
+			inlineMap = ft
+			continue
+		case reflect.Ptr:
+			ft, inlineOptional = ft.Elem(), true
+			if ft.Kind() != reflect.Struct {
+				return nil, nil, fmt.Errorf("(struct %s) inline fields must be a struct, a struct pointer, or a map", t)
+			}
+		case reflect.Struct:
+		default:
+			return nil, nil, fmt.Errorf("(struct %s) inline fields must be a struct, a struct pointer, or a map", t)
+		}
+		inlined, _, err := g.structFields(ft, depth+1, inlineOptional)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, inlined...)
+	}
+
+	minDepth := make(map[string]int, len(fields))
+	atMin := make(map[string]int, len(fields))
+	for _, f := range fields {
+		d, ok := minDepth[f.name]
+		switch {
+		case !ok || f.depth < d:
+			minDepth[f.name], atMin[f.name] = f.depth, 1
+		case f.depth == d:
+			atMin[f.name]++
+		}
+	}
+	dominant := fields[:0]
+	for _, f := range fields {
+		if f.depth != minDepth[f.name] {
+			continue
+		}
+		if atMin[f.name] > 1 {
+			return nil, nil, fmt.Errorf("struct %s has duplicated key %s", t, f.name)
+		}
+		dominant = append(dominant, f)
+	}
+	return dominant, inlineMap, nil
+}
+
+// enumValues returns the values of the enum option in the bson struct tag of sf.
+func enumValues(sf reflect.StructField) []string {
+	tag, ok := sf.Tag.Lookup("bson")
+	if !ok && !strings.Contains(string(sf.Tag), ":") {
+		tag = string(sf.Tag)
+	}
+	for i, opt := range strings.Split(tag, ",") {
+		if i > 0 && strings.HasPrefix(opt, "enum=") {
+			return strings.Split(strings.TrimPrefix(opt, "enum="), "|")
+		}
+	}
+	return nil
+}
+
+// valueSchema returns the schema for a value of type t encoded with the given struct tags.
+func (g *generator) valueSchema(t reflect.Type, tags bsoncodec.StructTags, enum []string) (bson.D, error) {
+	var nullable bool
+	for t.Kind() == reflect.Ptr && g.typeMap[t] == nil {
+		nullable = true
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map:
+		nullable = true
+	}
+	if tags.OmitEmpty {
+		nullable = false
+	}
+
+	types, doc, err := g.describe(t, tags.MinSize, enum)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return bson.D{}, nil
+	}
+	if nullable {
+		types = append(types, "null")
+		for i, e := range doc {
+			if e.Key == "enum" {
+				doc[i].Value = append(e.Value.(bson.A), nil)
+			}
+		}
+	}
+
+	var bsonType interface{} = types[0]
+	if len(types) > 1 {
+		a := make(bson.A, 0, len(types))
+		for _, alias := range types {
+			a = append(a, alias)
+		}
+		bsonType = a
+	}
+	return append(bson.D{{Key: "bsonType", Value: bsonType}}, doc...), nil
+}
+
+// describe returns the bsonType aliases of a non-nil value of type t and the other keywords that describe it. No
+// aliases are returned if the value can be of any type.
+func (g *generator) describe(t reflect.Type, minSize bool, enum []string) ([]string, bson.D, error) {
+	var types []string
+	var doc bson.D
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		types = []string{"int"}
+	case reflect.Int:
+		types = []string{"int", "long"}
+	case reflect.Int64, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		types = []string{"long"}
+		if minSize {
+			return g.withEnum(t, []string{"int", "long"}, doc, enum)
+		}
+	}
+
+	switch {
+	case g.typeMap[t] != nil:
+		types = append([]string(nil), g.typeMap[t]...)
+	case t == tTime:
+		types = []string{"date"}
+	case t == tUUID:
+		types = []string{"binData"}
+	case t == tRaw || t.Implements(tMarshaler) || reflect.PtrTo(t).Implements(tMarshaler):
+		types = []string{"object"}
+	case t.Implements(tValueMarshaler) || reflect.PtrTo(t).Implements(tValueMarshaler):
+		return nil, nil, nil
+	}
+	if types != nil {
+		return g.withEnum(t, types, doc, enum)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		types = []string{"bool"}
+	case reflect.Float32, reflect.Float64:
+		types = []string{"double"}
+	case reflect.String:
+		types = []string{"string"}
+	case reflect.Interface:
+		if enum != nil {
+			return nil, nil, fmt.Errorf("enum is not supported for %s", t)
+		}
+		return nil, nil, nil
+	case reflect.Struct:
+		s, err := g.structSchema(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		types, doc = []string{"object"}, s
+	case reflect.Map:
+		s, err := g.valueSchema(t.Elem(), bsoncodec.StructTags{MinSize: minSize}, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		types, doc = []string{"object"}, bson.D{{Key: "additionalProperties", Value: s}}
+	case reflect.Slice, reflect.Array:
+		switch t.Elem() {
+		case tByte:
+			types = []string{"binData"}
+		case tE:
+			types = []string{"object"}
+		default:
+			items, err := g.valueSchema(t.Elem(), bsoncodec.StructTags{MinSize: minSize}, enum)
+			if err != nil {
+				return nil, nil, err
+			}
+			types, doc = []string{"array"}, bson.D{{Key: "items", Value: items}}
+			if t.Kind() == reflect.Array {
+				doc = append(doc, bson.E{Key: "minItems", Value: t.Len()}, bson.E{Key: "maxItems", Value: t.Len()})
+			}
+			return types, doc, nil
+		}
+	default:
+		return nil, nil, fmt.Errorf("no BSON type for %s", t)
+	}
+	return g.withEnum(t, types, doc, enum)
+}
+
+// withEnum adds the enum keyword to doc if enum is not nil, converting each value to the kind of t.
+func (g *generator) withEnum(t reflect.Type, types []string, doc bson.D, enum []string) ([]string, bson.D, error) {
+	if enum == nil {
+		return types, doc, nil
+	}
+
+	values := make(bson.A, 0, len(enum))
+	for _, s := range enum {
+		var v interface{}
+		var err error
+		switch t.Kind() {
+		case reflect.String:
+			v = s
+		case reflect.Bool:
+			v, err = strconv.ParseBool(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err = strconv.ParseInt(s, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			v, err = strconv.ParseFloat(s, 64)
+		default:
+			return nil, nil, fmt.Errorf("enum is not supported for %s", t)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid enum value %q for %s", s, t)
+		}
+		values = append(values, v)
+	}
+	return types, append(doc, bson.E{Key: "enum", Value: values}), nil
+}
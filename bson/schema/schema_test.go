@@ -0,0 +1,230 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+type address struct {
+	Street string `bson:"street"`
+	City   string `bson:"city,omitempty"`
+}
+
+type audit struct {
+	By string    `bson:"by"`
+	At time.Time `bson:"at"`
+}
+
+type meta struct {
+	Version int    `bson:"version"`
+	Kind    string `bson:"kind"`
+}
+
+type person struct {
+	ID       primitive.ObjectID     `bson:"_id,omitempty"`
+	Name     string                 `bson:"name"`
+	Status   string                 `bson:"status,enum=active|inactive"`
+	Level    int32                  `bson:"level,omitempty,enum=1|2|3"`
+	Age      int                    `bson:"age"`
+	Count    int64                  `bson:"count"`
+	Small    int64                  `bson:"small,minsize"`
+	Score    float64                `bson:"score"`
+	Active   bool                   `bson:"active"`
+	Created  time.Time              `bson:"created"`
+	Balance  primitive.Decimal128   `bson:"balance"`
+	Avatar   []byte                 `bson:"avatar,omitempty"`
+	Session  primitive.UUID         `bson:"session"`
+	Tags     []string               `bson:"tags,enum=a|b"`
+	Home     address                `bson:"home"`
+	Work     *address               `bson:"work"`
+	Labels   map[string]int32       `bson:"labels,omitempty"`
+	Extra    interface{}            `bson:"extra"`
+	Doc      bson.D                 `bson:"doc,omitempty"`
+	Meta     meta                   `bson:",inline"`
+	Audit    *audit                 `bson:",inline"`
+	Rest     map[string]interface{} `bson:",inline"`
+	Kind     string                 `bson:"kind"`
+	Ignored  string                 `bson:"-"`
+	internal int
+}
+
+type node struct {
+	Value    string  `bson:"value"`
+	Children []*node `bson:"children,omitempty"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	testCases := []struct {
+		name string
+		gen  Generator
+		val  interface{}
+		want string
+	}{
+		{
+			"person",
+			Generator{},
+			person{},
+			`{"bsonType":"object",` +
+				`"required":["name","status","age","count","small","score","active","created","balance","session",` +
+				`"tags","home","work","extra","version","kind"],` +
+				`"properties":{` +
+				`"_id":{"bsonType":"objectId"},` +
+				`"name":{"bsonType":"string"},` +
+				`"status":{"bsonType":"string","enum":["active","inactive"]},` +
+				`"level":{"bsonType":"int","enum":[1,2,3]},` +
+				`"age":{"bsonType":["int","long"]},` +
+				`"count":{"bsonType":"long"},` +
+				`"small":{"bsonType":["int","long"]},` +
+				`"score":{"bsonType":"double"},` +
+				`"active":{"bsonType":"bool"},` +
+				`"created":{"bsonType":"date"},` +
+				`"balance":{"bsonType":"decimal"},` +
+				`"avatar":{"bsonType":"binData"},` +
+				`"session":{"bsonType":"binData"},` +
+				`"tags":{"bsonType":["array","null"],"items":{"bsonType":"string","enum":["a","b"]}},` +
+				`"home":{"bsonType":"object","required":["street"],"properties":{` +
+				`"street":{"bsonType":"string"},"city":{"bsonType":"string"}}},` +
+				`"work":{"bsonType":["object","null"],"required":["street"],"properties":{` +
+				`"street":{"bsonType":"string"},"city":{"bsonType":"string"}}},` +
+				`"labels":{"bsonType":"object","additionalProperties":{"bsonType":"int"}},` +
+				`"extra":{},` +
+				`"doc":{"bsonType":"object"},` +
+				`"version":{"bsonType":["int","long"]},` +
+				`"by":{"bsonType":"string"},` +
+				`"at":{"bsonType":"date"},` +
+				`"kind":{"bsonType":"string"}},` +
+				`"additionalProperties":{}}`,
+		},
+		{
+			"recursive type",
+			Generator{},
+			&node{},
+			`{"bsonType":"object","required":["value"],"properties":{"value":{"bsonType":"string"},` +
+				`"children":{"bsonType":"array","items":{"bsonType":["object","null"]}}}}`,
+		},
+		{
+			"registry type map",
+			Generator{Registry: bson.NewRegistryBuilder().
+				RegisterTypeMapEntry(bsontype.Int32, reflect.TypeOf(primitive.DateTime(0))).Build()},
+			struct{ When primitive.DateTime }{},
+			`{"bsonType":"object","required":["when"],"properties":{"when":{"bsonType":["date","int"]}}}`,
+		},
+		{
+			"struct tag parser",
+			Generator{StructTagParser: bsoncodec.JSONFallbackStructTagParser},
+			reflect.TypeOf(struct {
+				A string `json:"a_json,omitempty"`
+			}{}),
+			`{"bsonType":"object","properties":{"a_json":{"bsonType":"string"}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := tc.gen.JSONSchema(tc.val)
+			assert.Nil(t, err, "JSONSchema error: %v", err)
+			got, err := bson.MarshalExtJSON(doc, false, false)
+			assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+			assert.Equal(t, tc.want, string(got), "expected %s, got %s", tc.want, got)
+		})
+	}
+
+	t.Run("camel case registry", func(t *testing.T) {
+		type profile struct {
+			DisplayName string `bson:",omitempty"`
+		}
+		type account struct {
+			UserID    int64
+			FirstName string `bson:"first"`
+			Profile   profile
+		}
+		sc, err := bsoncodec.NewStructCodec(bsoncodec.DefaultStructTagParser,
+			bsonoptions.StructCodec().SetFieldNamer(bsoncodec.CamelCaseFieldName))
+		assert.Nil(t, err, "NewStructCodec error: %v", err)
+		reg := bson.NewRegistryBuilder().
+			RegisterDefaultEncoder(reflect.Struct, sc).
+			RegisterDefaultDecoder(reflect.Struct, sc).
+			Build()
+
+		want := `{"bsonType":"object","required":["userID","first","profile"],"properties":{` +
+			`"userID":{"bsonType":"long"},"first":{"bsonType":"string"},` +
+			`"profile":{"bsonType":"object","properties":{"displayName":{"bsonType":"string"}}}}}`
+		gen := Generator{
+			Registry:        reg,
+			StructTagParser: bsoncodec.NewStructTagParser(bsoncodec.CamelCaseFieldName, false),
+		}
+		doc, err := gen.JSONSchema(account{})
+		assert.Nil(t, err, "JSONSchema error: %v", err)
+		got, err := bson.MarshalExtJSON(doc, false, false)
+		assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+		assert.Equal(t, want, string(got), "expected %s, got %s", want, got)
+
+		// The schema properties are the keys the registry actually writes.
+		encoded, err := bson.MarshalWithRegistry(reg, account{Profile: profile{DisplayName: "x"}})
+		assert.Nil(t, err, "MarshalWithRegistry error: %v", err)
+		elems, err := bson.Raw(encoded).Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		var keys []string
+		for _, e := range elems {
+			keys = append(keys, e.Key())
+		}
+		keys = append(keys, bson.Raw(encoded).Lookup("profile").Document().Index(0).Key())
+		wantKeys := []string{"userID", "first", "profile", "displayName"}
+		assert.Equal(t, wantKeys, keys, "expected keys %v, got %v", wantKeys, keys)
+	})
+	t.Run("Validator", func(t *testing.T) {
+		v, err := Validator(address{})
+		assert.Nil(t, err, "Validator error: %v", err)
+		got, err := bson.MarshalExtJSON(v, false, false)
+		assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+		want := `{"$jsonSchema":{"bsonType":"object","required":["street"],"properties":{` +
+			`"street":{"bsonType":"string"},"city":{"bsonType":"string"}}}}`
+		assert.Equal(t, want, string(got), "expected %s, got %s", want, got)
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			val    interface{}
+			errMsg string
+		}{
+			{"not a struct", 1, "a struct or struct pointer is required"},
+			{"nil", nil, "a struct or struct pointer is required"},
+			{"duplicate key", struct {
+				A int `bson:"x"`
+				B int `bson:"x"`
+			}{}, "has duplicated key x"},
+			{"invalid enum", struct {
+				A int `bson:"a,enum=one"`
+			}{}, `invalid enum value "one" for int`},
+			{"unsupported enum", struct {
+				A time.Time `bson:"a,enum=now"`
+			}{}, "enum is not supported for time.Time"},
+			{"unsupported type", struct{ C chan int }{}, "no BSON type for chan int"},
+			{"inline scalar", struct {
+				A int `bson:",inline"`
+			}{}, "inline fields must be a struct, a struct pointer, or a map"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := JSONSchema(tc.val)
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
@@ -84,6 +84,20 @@ func UnmarshalExtJSONWithContext(dc bsoncodec.DecodeContext, data []byte, canoni
 	return unmarshalFromReader(dc, ejvr, val)
 }
 
+// UnmarshalShellJSON parses data written in the syntax of the mongo shell, such as
+// {_id: ObjectId("5e1f6c..."), n: NumberLong(5)}, and stores the result in the value pointed to by val. Shell
+// constructors produce the same BSON as their canonical extended JSON representations. See
+// bsonrw.NewShellJSONValueReader for the supported syntax. If val is nil or not a pointer, UnmarshalShellJSON
+// returns InvalidUnmarshalError.
+func UnmarshalShellJSON(data []byte, val interface{}) error {
+	vr, err := bsonrw.NewShellJSONValueReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	return unmarshalFromReader(bsoncodec.DecodeContext{Registry: DefaultRegistry}, vr, val)
+}
+
 func unmarshalFromReader(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val interface{}) error {
 	dec := decPool.Get().(*Decoder)
 	defer decPool.Put(dec)
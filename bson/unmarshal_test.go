@@ -9,10 +9,14 @@ package bson
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -72,6 +76,91 @@ func TestUnmarshalWithContext(t *testing.T) {
 	}
 }
 
+func TestUnmarshalStrict(t *testing.T) {
+	type inner struct {
+		B int32 `bson:"b,required"`
+	}
+	type outer struct {
+		A     inner   `bson:"a"`
+		Items []inner `bson:"items"`
+	}
+	type withInlineMap struct {
+		A    int32            `bson:"a"`
+		Rest map[string]int32 `bson:",inline"`
+	}
+
+	doc := func(elems ...[]byte) []byte {
+		return bsoncore.BuildDocumentFromElements(nil, elems...)
+	}
+	unknown := doc(bsoncore.AppendDocumentElement(nil, "a", doc(
+		bsoncore.AppendInt32Element(nil, "b", 1),
+		bsoncore.AppendInt32Element(nil, "c", 2),
+	)))
+	structCodec, err := bsoncodec.NewStructCodec(bsoncodec.DefaultStructTagParser,
+		bsonoptions.StructCodec().SetDisallowUnknownFields(true))
+	assert.Nil(t, err, "NewStructCodec error: %v", err)
+	strictRegistry := NewRegistryBuilder().RegisterDefaultDecoder(reflect.Struct, structCodec).Build()
+
+	t.Run("unknown fields are allowed by default", func(t *testing.T) {
+		var got outer
+		err := Unmarshal(unknown, &got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+	})
+	t.Run("registry", func(t *testing.T) {
+		var got outer
+		err := UnmarshalWithRegistry(strictRegistry, unknown, &got)
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.Equal(t, "error decoding key a.c: unknown field", err.Error(), "unexpected error %v", err)
+		de, ok := err.(*bsoncodec.DecodeError)
+		assert.True(t, ok, "expected *DecodeError, got %T", err)
+		assert.Equal(t, bsoncodec.ErrUnknownField, de.Unwrap(), "expected error %v, got %v",
+			bsoncodec.ErrUnknownField, de.Unwrap())
+	})
+	t.Run("decode context", func(t *testing.T) {
+		var got outer
+		data := doc(bsoncore.BuildArrayElement(nil, "items",
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc(bsoncore.AppendInt32Element(nil, "b", 1))},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: doc(
+				bsoncore.AppendInt32Element(nil, "b", 1),
+				bsoncore.AppendInt32Element(nil, "x", 2),
+			)},
+		))
+		err := UnmarshalWithContext(bsoncodec.DecodeContext{Registry: DefaultRegistry, DisallowUnknownFields: true},
+			data, &got)
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.Equal(t, "error decoding key items.1.x: unknown field", err.Error(), "unexpected error %v", err)
+	})
+	t.Run("decoder", func(t *testing.T) {
+		dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(unknown))
+		assert.Nil(t, err, "NewDecoder error: %v", err)
+		dec.DisallowUnknownFields()
+		var got outer
+		err = dec.Decode(&got)
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.Equal(t, "error decoding key a.c: unknown field", err.Error(), "unexpected error %v", err)
+	})
+	t.Run("inline map", func(t *testing.T) {
+		var got withInlineMap
+		err := UnmarshalWithRegistry(strictRegistry, doc(
+			bsoncore.AppendInt32Element(nil, "a", 1),
+			bsoncore.AppendInt32Element(nil, "b", 2),
+		), &got)
+		assert.Nil(t, err, "UnmarshalWithRegistry error: %v", err)
+		want := withInlineMap{A: 1, Rest: map[string]int32{"b": 2}}
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+	})
+	t.Run("required", func(t *testing.T) {
+		var got outer
+		err := Unmarshal(doc(bsoncore.AppendDocumentElement(nil, "a", doc())), &got)
+		assert.NotNil(t, err, "expected error, got nil")
+		assert.Equal(t, "error decoding key a.b: missing required field", err.Error(), "unexpected error %v", err)
+
+		err = Unmarshal(doc(bsoncore.AppendDocumentElement(nil, "a", doc(bsoncore.AppendNullElement(nil, "b")))),
+			&got)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+	})
+}
+
 func TestUnmarshalExtJSONWithRegistry(t *testing.T) {
 	t.Run("UnmarshalExtJSONWithContext", func(t *testing.T) {
 		type teststruct struct{ Foo int }
@@ -94,6 +183,29 @@ func TestUnmarshalExtJSONWithRegistry(t *testing.T) {
 	})
 }
 
+func TestUnmarshalShellJSON(t *testing.T) {
+	type teststruct struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		When time.Time
+		N    int64
+	}
+	data := []byte(`{_id: ObjectId("5e1f6c4a2b3c4d5e6f708192"), when: ISODate("2020-01-02T03:04:05Z"), n: NumberLong(5),}`)
+	var got teststruct
+	err := UnmarshalShellJSON(data, &got)
+	assert.Nil(t, err, "UnmarshalShellJSON error: %v", err)
+
+	oid, _ := primitive.ObjectIDFromHex("5e1f6c4a2b3c4d5e6f708192")
+	want := teststruct{ID: oid, When: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), N: 5}
+	assert.True(t, got.ID == want.ID && got.When.Equal(want.When) && got.N == want.N, "expected %v, got %v", want, got)
+
+	t.Run("relaxed extended JSON", func(t *testing.T) {
+		var got teststruct
+		err := UnmarshalShellJSON([]byte(`{when: {"$date": "2020-01-02T03:04:05Z"}, n: {"$numberLong": "5"}}`), &got)
+		assert.Nil(t, err, "UnmarshalShellJSON error: %v", err)
+		assert.True(t, got.When.Equal(want.When) && got.N == want.N, "expected %v, got %v", want, got)
+	})
+}
+
 func TestUnmarshalExtJSONWithContext(t *testing.T) {
 	type fooInt struct {
 		Foo int
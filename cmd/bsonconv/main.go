@@ -0,0 +1,309 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// bsonconv converts a stream of documents between BSON and extended JSON, similar to bsondump. BSON input is a
+// sequence of BSON documents, such as a mongodump .bson file. JSON input is a sequence of extended JSON documents
+// separated by whitespace, such as JSON Lines. JSON output is written as JSON Lines, one document per line, in
+// relaxed extended JSON unless -canonical is set.
+//
+// Usage:
+//
+//	bsonconv [-from bson|json] [-to bson|json] [-canonical] [-pretty] [-fields a,b.c] [-o output] [input]
+//	bsonconv -validate [input]
+//
+// BSON input is validated before it is converted. With -validate, every document is checked and each invalid one is
+// reported with its offset in the input and the reason; the exit status is 1 if any document is invalid. With
+// -fields, only the given dotted paths are kept in each document.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// config contains the conversion options set by the command line flags.
+type config struct {
+	from, to     string
+	canonical    bool
+	pretty       bool
+	validateOnly bool
+	fields       []string
+	maxSize      int
+}
+
+func main() {
+	var cfg config
+	var fields, output string
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "bsonconv converts documents between BSON and extended JSON.")
+		fmt.Fprintln(fs.Output(), "usage: bsonconv [flags] [input]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&cfg.from, "from", "bson", "input format: bson or json")
+	fs.StringVar(&cfg.to, "to", "json", "output format: bson or json")
+	fs.BoolVar(&cfg.canonical, "canonical", false, "write canonical instead of relaxed extended JSON")
+	fs.BoolVar(&cfg.pretty, "pretty", false, "indent JSON output")
+	fs.BoolVar(&cfg.validateOnly, "validate", false, "validate the input and report invalid documents instead of converting")
+	fs.StringVar(&fields, "fields", "", "comma-separated list of dotted paths to keep in each document")
+	fs.IntVar(&cfg.maxSize, "maxsize", bsonrw.DefaultMaxDocumentSize, "maximum document size in bytes")
+	fs.StringVar(&output, "o", "", "output file; default standard output")
+	err := fs.Parse(os.Args[1:])
+	if err == flag.ErrHelp {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Could not parse flags: %v", err)
+	}
+	if fields != "" {
+		cfg.fields = strings.Split(fields, ",")
+	}
+
+	var in io.Reader = os.Stdin
+	if args := fs.Args(); len(args) > 0 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatalf("Could not open input: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	var out io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatalf("Could not create output: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	bw := bufio.NewWriter(out)
+	err = run(cfg, bufio.NewReader(in), bw)
+	if flushErr := bw.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run reads the documents in r and writes them to w as configured by cfg. In validation mode, a report of the
+// invalid documents is written to w instead.
+func run(cfg config, r io.Reader, w io.Writer) error {
+	if cfg.maxSize <= 0 {
+		cfg.maxSize = bsonrw.DefaultMaxDocumentSize
+	}
+	src, err := newSource(cfg, r)
+	if err != nil {
+		return err
+	}
+	var dst sink
+	if !cfg.validateOnly {
+		if dst, err = newSink(cfg, w); err != nil {
+			return err
+		}
+	}
+	var proj projection
+	if len(cfg.fields) > 0 {
+		proj = newProjection(cfg.fields)
+	}
+
+	var count, invalid int
+	for {
+		doc, err := src.next()
+		if err == io.EOF {
+			break
+		}
+		if ide, ok := err.(invalidDocumentError); ok && cfg.validateOnly {
+			fmt.Fprintln(w, ide)
+			count++
+			invalid++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		count++
+		if cfg.validateOnly {
+			continue
+		}
+
+		if proj != nil {
+			doc = proj.apply(doc)
+		}
+		if err = dst.write(doc); err != nil {
+			return fmt.Errorf("document %d: %v", count, err)
+		}
+	}
+
+	if cfg.validateOnly {
+		fmt.Fprintf(w, "%d documents, %d invalid\n", count, invalid)
+		if invalid > 0 {
+			return fmt.Errorf("found %d invalid documents", invalid)
+		}
+	}
+	return nil
+}
+
+// invalidDocumentError is returned by a source when a document is read but is not valid BSON. The source can continue
+// reading after an invalidDocumentError.
+type invalidDocumentError struct {
+	n      int
+	offset int64
+	pos    int64
+	err    error
+}
+
+func (ide invalidDocumentError) Error() string {
+	return fmt.Sprintf("document %d at offset %d is invalid at offset %d: %v", ide.n, ide.offset, ide.pos, ide.err)
+}
+
+// source reads documents from an input stream. Its next method returns io.EOF once the stream is exhausted.
+type source interface {
+	next() (bsoncore.Document, error)
+}
+
+func newSource(cfg config, r io.Reader) (source, error) {
+	switch cfg.from {
+	case "bson":
+		vr, err := bsonrw.NewBSONStreamReader(r, cfg.maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return &bsonSource{vr: vr}, nil
+	case "json":
+		// The relaxed reader accepts both canonical and relaxed extended JSON, so -canonical only affects the output.
+		vr, err := bsonrw.NewExtJSONValueReader(r, false)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSource{vr: vr, maxSize: cfg.maxSize}, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q", cfg.from)
+	}
+}
+
+type bsonSource struct {
+	vr     bsonrw.ValueReader
+	n      int
+	offset int64
+}
+
+func (bs *bsonSource) next() (bsoncore.Document, error) {
+	doc, err := bsonrw.Copier{}.CopyDocumentToBytes(bs.vr)
+	if err == io.EOF {
+		return nil, err
+	}
+	bs.n++
+	offset := bs.offset
+	if err != nil {
+		return nil, fmt.Errorf("document %d at offset %d: %v", bs.n, offset, err)
+	}
+	bs.offset += int64(len(doc))
+
+	if pos, err := validate(doc); err != nil {
+		return nil, invalidDocumentError{n: bs.n, offset: offset, pos: offset + int64(pos), err: err}
+	}
+	return doc, nil
+}
+
+type jsonSource struct {
+	vr      bsonrw.ValueReader
+	n       int
+	maxSize int
+}
+
+func (js *jsonSource) next() (bsoncore.Document, error) {
+	doc, err := bsonrw.Copier{}.CopyDocumentToBytes(js.vr)
+	if err == io.EOF {
+		return nil, err
+	}
+	js.n++
+	if err != nil {
+		return nil, fmt.Errorf("document %d: %v", js.n, err)
+	}
+	if len(doc) > js.maxSize {
+		return nil, fmt.Errorf("document %d: document size (%d) is larger than the maximum document size (%d)",
+			js.n, len(doc), js.maxSize)
+	}
+	return doc, nil
+}
+
+// sink writes documents to an output stream.
+type sink interface {
+	write(doc bsoncore.Document) error
+}
+
+func newSink(cfg config, w io.Writer) (sink, error) {
+	switch cfg.to {
+	case "bson":
+		vw, err := bsonrw.NewBSONStreamWriter(w, cfg.maxSize)
+		if err != nil {
+			return nil, err
+		}
+		return &bsonSink{vw: vw}, nil
+	case "json":
+		js := &jsonSink{w: w, pretty: cfg.pretty}
+		vw, err := bsonrw.NewExtJSONValueWriter(&js.buf, cfg.canonical, false)
+		if err != nil {
+			return nil, err
+		}
+		js.vw = vw
+		return js, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", cfg.to)
+	}
+}
+
+type bsonSink struct {
+	vw bsonrw.ValueWriter
+}
+
+func (bs *bsonSink) write(doc bsoncore.Document) error {
+	return bsonrw.Copier{}.CopyDocumentFromBytes(bs.vw, doc)
+}
+
+// jsonSink writes each document as extended JSON followed by a newline. The ExtJSON ValueWriter writes each document
+// to buf once it is complete so that it can be indented before it is written to w.
+type jsonSink struct {
+	w        io.Writer
+	vw       bsonrw.ValueWriter
+	pretty   bool
+	buf      bytes.Buffer
+	indented bytes.Buffer
+}
+
+func (js *jsonSink) write(doc bsoncore.Document) error {
+	js.buf.Reset()
+	if err := (bsonrw.Copier{}).CopyDocumentFromBytes(js.vw, doc); err != nil {
+		return err
+	}
+	out := js.buf.Bytes()
+	if js.pretty {
+		js.indented.Reset()
+		if err := json.Indent(&js.indented, out, "", "  "); err != nil {
+			return err
+		}
+		out = js.indented.Bytes()
+	}
+	if _, err := js.w.Write(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(js.w, "\n")
+	return err
+}
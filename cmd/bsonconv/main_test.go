@@ -0,0 +1,166 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRun(t *testing.T) {
+	doc1 := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt64Element(nil, "n", 1),
+		bsoncore.AppendDocumentElement(nil, "a", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "b", "x"),
+			bsoncore.AppendStringElement(nil, "c", "y"),
+		)),
+	)
+	doc2 := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendInt64Element(nil, "n", 2),
+		bsoncore.BuildArrayElement(nil, "a",
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "b", "z"),
+				bsoncore.AppendStringElement(nil, "c", "w"),
+			)},
+			bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 3)},
+		),
+	)
+	stream := string(doc1) + string(doc2)
+
+	testCases := []struct {
+		name string
+		cfg  config
+		in   string
+		want string
+	}{
+		{
+			"bson to relaxed json",
+			config{from: "bson", to: "json"},
+			stream,
+			`{"n":1,"a":{"b":"x","c":"y"}}` + "\n" + `{"n":2,"a":[{"b":"z","c":"w"},3]}` + "\n",
+		},
+		{
+			"bson to canonical json",
+			config{from: "bson", to: "json", canonical: true},
+			string(doc1),
+			`{"n":{"$numberLong":"1"},"a":{"b":"x","c":"y"}}` + "\n",
+		},
+		{
+			"pretty",
+			config{from: "bson", to: "json", pretty: true},
+			string(doc1),
+			"{\n  \"n\": 1,\n  \"a\": {\n    \"b\": \"x\",\n    \"c\": \"y\"\n  }\n}\n",
+		},
+		{
+			"projection",
+			config{from: "bson", to: "json", fields: []string{"a.b"}},
+			stream,
+			`{"a":{"b":"x"}}` + "\n" + `{"a":[{"b":"z"}]}` + "\n",
+		},
+		{
+			"projection prefix wins",
+			config{from: "bson", to: "json", fields: []string{"a.b", "n", "a"}},
+			string(doc1),
+			`{"n":1,"a":{"b":"x","c":"y"}}` + "\n",
+		},
+		{
+			"json to bson",
+			config{from: "json", to: "bson", canonical: true},
+			`{"n":{"$numberLong":"1"},"a":{"b":"x","c":"y"}}` + "\n" +
+				`{"n":{"$numberLong":"2"},"a":[{"b":"z","c":"w"},{"$numberInt":"3"}]}`,
+			stream,
+		},
+		{
+			"relaxed json to canonical json",
+			config{from: "json", to: "json", canonical: true},
+			`{"d":{"$date":"2020-01-01T00:00:00Z"},"n":1}`,
+			`{"d":{"$date":{"$numberLong":"1577836800000"}},"n":{"$numberInt":"1"}}` + "\n",
+		},
+		{
+			"relaxed json to bson",
+			config{from: "json", to: "bson", canonical: true},
+			`{"n":1,"a":{"b":"x","c":"y"}}`,
+			string(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "n", 1),
+				bsoncore.AppendDocumentElement(nil, "a", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendStringElement(nil, "b", "x"),
+					bsoncore.AppendStringElement(nil, "c", "y"),
+				)),
+			)),
+		},
+		{
+			"json lines round trip",
+			config{from: "json", to: "json"},
+			`{"n":1,"a":{"b":"x","c":"y"}}` + "\n\n" + `  {"n":2,"a":[{"b":"z","c":"w"},3]}`,
+			`{"n":1,"a":{"b":"x","c":"y"}}` + "\n" + `{"n":2,"a":[{"b":"z","c":"w"},3]}` + "\n",
+		},
+		{
+			"validate",
+			config{from: "bson", validateOnly: true},
+			stream,
+			"2 documents, 0 invalid\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := run(tc.cfg, strings.NewReader(tc.in), &buf)
+			assert.Nil(t, err, "run error: %v", err)
+			assert.Equal(t, tc.want, buf.String(), "expected %q, got %q", tc.want, buf.String())
+		})
+	}
+
+	t.Run("validate reports invalid documents", func(t *testing.T) {
+		bad := []byte(string(doc1))
+		// Corrupt the length of the string value of a.c so that it runs past the end of the embedded document.
+		pos := bytes.Index(bad, []byte("c\x00")) + 2
+		bad[pos] = 0x7F
+		in := string(doc2) + string(bad) + string(doc2)
+
+		var buf bytes.Buffer
+		err := run(config{from: "bson", validateOnly: true}, strings.NewReader(in), &buf)
+		assert.NotNil(t, err, "expected error, got nil")
+		want := "document 2 at offset " + strconv.Itoa(len(doc2)) + " is invalid at offset " +
+			strconv.Itoa(len(doc2)+pos-3) + ": "
+		assert.True(t, strings.HasPrefix(buf.String(), want), "expected report starting with %q, got %q", want,
+			buf.String())
+		assert.True(t, strings.HasSuffix(buf.String(), "3 documents, 1 invalid\n"), "expected summary, got %q",
+			buf.String())
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			cfg    config
+			in     string
+			errMsg string
+		}{
+			{"unknown input format", config{from: "yaml", to: "json"}, "", `unknown input format "yaml"`},
+			{"unknown output format", config{from: "bson", to: "yaml"}, "", `unknown output format "yaml"`},
+			{"invalid length", config{from: "bson", to: "json"}, string(doc1) + "\x01\x00\x00\x00",
+				"document 2 at offset " + strconv.Itoa(len(doc1)) + ": invalid document length 1"},
+			{"too large", config{from: "bson", to: "json", maxSize: 10}, string(doc1),
+				"is larger than the maximum document size (10)"},
+			{"invalid document", config{from: "bson", to: "json"}, string(doc1[:len(doc1)-1]) + "\x01",
+				"document 1 at offset 0 is invalid at offset " + strconv.Itoa(len(doc1)-1)},
+			{"invalid json", config{from: "json", to: "bson"}, `{"a":1} {"b":`, "document 2: "},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := run(tc.cfg, strings.NewReader(tc.in), &bytes.Buffer{})
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
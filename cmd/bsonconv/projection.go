@@ -0,0 +1,91 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// projection is a tree of the fields to keep in a document. A nil subtree keeps the whole field.
+type projection map[string]projection
+
+// newProjection creates a projection from a list of dotted paths. If both a path and one of its prefixes are given,
+// the prefix wins and the whole field is kept.
+func newProjection(paths []string) projection {
+	p := make(projection)
+	for _, path := range paths {
+		node := p
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			sub, ok := node[part]
+			if ok && sub == nil {
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if !ok {
+				sub = make(projection)
+				node[part] = sub
+			}
+			node = sub
+		}
+	}
+	return p
+}
+
+// apply returns a new document with the fields of doc that are included in the projection. As with a query
+// projection, a path into an array is applied to each embedded document in the array and other array values are
+// dropped. The document must be valid.
+func (p projection) apply(doc bsoncore.Document) bsoncore.Document {
+	idx, dst := bsoncore.AppendDocumentStart(nil)
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		sub, ok := p[elem.Key()]
+		if !ok {
+			continue
+		}
+		if sub == nil {
+			dst = append(dst, elem...)
+			continue
+		}
+
+		val := elem.Value()
+		switch val.Type {
+		case bsontype.EmbeddedDocument:
+			dst = bsoncore.AppendDocumentElement(dst, elem.Key(), sub.apply(val.Document()))
+		case bsontype.Array:
+			dst = bsoncore.AppendArrayElement(dst, elem.Key(), sub.applyArray(val.Array()))
+		}
+	}
+	dst, _ = bsoncore.AppendDocumentEnd(dst, idx)
+	return dst
+}
+
+func (p projection) applyArray(arr bsoncore.Array) bsoncore.Array {
+	idx, dst := bsoncore.AppendArrayStart(nil)
+	vals, _ := arr.Values()
+	i := 0
+	for _, val := range vals {
+		switch val.Type {
+		case bsontype.EmbeddedDocument:
+			dst = bsoncore.AppendDocumentElement(dst, strconv.Itoa(i), p.apply(val.Document()))
+		case bsontype.Array:
+			dst = bsoncore.AppendArrayElement(dst, strconv.Itoa(i), p.applyArray(val.Array()))
+		default:
+			continue
+		}
+		i++
+	}
+	dst, _ = bsoncore.AppendArrayEnd(dst, idx)
+	return dst
+}
@@ -0,0 +1,56 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// validate checks that doc is a well formed BSON document. Unlike bsoncore.Document.Validate, it also validates
+// embedded documents and arrays, and it returns the offset in doc of the invalid bytes along with the reason.
+func validate(doc bsoncore.Document) (int, error) {
+	length, _, ok := bsoncore.ReadLength(doc)
+	if !ok {
+		return 0, bsoncore.NewInsufficientBytesError(doc, doc)
+	}
+	if length < 5 || int(length) > len(doc) {
+		return 0, bsoncore.NewDocumentLengthError(int(length), len(doc))
+	}
+	end := int(length) - 1
+	if doc[end] != 0x00 {
+		return end, bsoncore.ErrMissingNull
+	}
+
+	offset := 4
+	for offset < end {
+		rem := doc[offset:end]
+		if t := bsontype.Type(rem[0]); t.String() == "invalid" {
+			return offset, fmt.Errorf("invalid element type 0x%02x", rem[0])
+		}
+		elem, _, ok := bsoncore.ReadElement(rem)
+		if !ok {
+			if err := bsoncore.Element(rem).Validate(); err != nil {
+				return offset, err
+			}
+			return offset, bsoncore.NewInsufficientBytesError(doc, rem)
+		}
+
+		val := elem.Value()
+		switch val.Type {
+		case bsontype.EmbeddedDocument, bsontype.Array:
+			valOffset := offset + len(elem) - len(val.Data)
+			if off, err := validate(val.Data); err != nil {
+				return valOffset + off, err
+			}
+		}
+		offset += len(elem)
+	}
+	return 0, nil
+}
@@ -0,0 +1,788 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+const (
+	bsonPath      = "go.mongodb.org/mongo-driver/bson"
+	bsoncodecPath = "go.mongodb.org/mongo-driver/bson/bsoncodec"
+	bsontypePath  = "go.mongodb.org/mongo-driver/bson/bsontype"
+	primitivePath = "go.mongodb.org/mongo-driver/bson/primitive"
+	bsoncorePath  = "go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	fmtPath       = "fmt"
+	mathPath      = "math"
+	reflectPath   = "reflect"
+	strconvPath   = "strconv"
+	stringsPath   = "strings"
+)
+
+// kind describes how the generated code handles a Go type.
+type kind int
+
+const (
+	// kindFallback values are encoded and decoded with bson.DefaultRegistry.
+	kindFallback kind = iota
+	kindString
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindBytes
+	kindTime
+	kindObjectID
+	kindDecimal128
+	kindDateTime
+	kindUUID
+	// kindStruct values have generated methods.
+	kindStruct
+	kindPointer
+	kindSlice
+	kindMap
+	kindInterface
+)
+
+// field is a struct field, or a field of an inlined struct, that is written as a BSON element.
+type field struct {
+	name      string // BSON key
+	sel       string // selector relative to the receiver
+	typ       types.Type
+	omitEmpty bool
+	minSize   bool
+	truncate  bool
+	idx       int // index in the declaring struct
+	// inline is the index path of the field through inlined structs, or nil if the field is not inlined.
+	inline []int
+	// parents are the inlined struct pointer fields that contain the field, outermost first.
+	parents []field
+}
+
+// structDescription mirrors the description the bsoncodec.StructCodec builds for a struct type.
+type structDescription struct {
+	fields    []field
+	inlineMap *field
+}
+
+type generator struct {
+	pkg     *types.Package
+	targets map[*types.Named]bool
+	imports map[string]string
+	buf     bytes.Buffer
+	tmp     int
+	// errKey is the BSON key of the field being decoded, used to annotate errors.
+	errKey string
+}
+
+// generate returns the formatted source of a file containing the BSON methods for the named types in pkg. The command
+// is recorded in the header of the file.
+func generate(pkg *types.Package, names []string, command string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		targets: make(map[*types.Named]bool),
+		imports: make(map[string]string),
+	}
+
+	named := make([]*types.Named, 0, len(names))
+	for _, name := range names {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found in package %s", name, pkg.Path())
+		}
+		t, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a named type", name)
+		}
+		if _, ok := t.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("%s is not a struct type", name)
+		}
+		g.targets[t] = true
+		named = append(named, t)
+	}
+
+	for _, t := range named {
+		if err := g.generateType(t); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by %q; DO NOT EDIT.\n\n", command)
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name())
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// Standard library packages are grouped before other packages, like goimports does.
+	sort.SliceStable(paths, func(i, j int) bool {
+		return !strings.Contains(paths[i], ".") && strings.Contains(paths[j], ".")
+	})
+	out.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && strings.Contains(path, ".") && !strings.Contains(paths[i-1], ".") {
+			out.WriteString("\n")
+		}
+		if name := g.imports[path]; name != pathBase(path) {
+			fmt.Fprintf(&out, "%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(&out, "%q\n", path)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+func pathBase(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// use records that the generated code imports path and returns the name to refer to the package by.
+func (g *generator) use(path string) string {
+	if name, ok := g.imports[path]; ok {
+		return name
+	}
+	name := pathBase(path)
+	for taken := true; taken; {
+		taken = false
+		for _, other := range g.imports {
+			if other == name {
+				name += "_"
+				taken = true
+			}
+		}
+	}
+	g.imports[path] = name
+	return name
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		return g.use(pkg.Path())
+	})
+}
+
+func (g *generator) temp(prefix string) string {
+	g.tmp++
+	return prefix + strconv.Itoa(g.tmp)
+}
+
+func (g *generator) generateType(t *types.Named) error {
+	sd, err := g.describeStruct(t, make(map[types.Type]bool))
+	if err != nil {
+		return err
+	}
+	name := t.Obj().Name()
+	bsoncore := g.use(bsoncorePath)
+
+	g.printf("\n// MarshalBSON implements the bson.Marshaler interface.\n")
+	g.printf("func (v %s) MarshalBSON() ([]byte, error) {\nreturn v.AppendBSON(nil)\n}\n", name)
+
+	g.printf("\n// AppendBSON appends the BSON document for v to dst.\n")
+	g.printf("func (v %s) AppendBSON(dst []byte) ([]byte, error) {\n", name)
+	g.printf("idx, dst := %s.AppendDocumentStart(dst)\nvar err error\n", bsoncore)
+	for _, f := range sd.fields {
+		g.encodeField(f)
+	}
+	if sd.inlineMap != nil {
+		g.encodeInlineMap(*sd.inlineMap, sd.fields)
+	}
+	g.printf("if dst, err = %s.AppendDocumentEnd(dst, idx); err != nil {\nreturn nil, err\n}\n", bsoncore)
+	g.printf("return dst, nil\n}\n")
+
+	g.printf("\n// UnmarshalBSON implements the bson.Unmarshaler interface.\n")
+	g.printf("func (v *%s) UnmarshalBSON(data []byte) error {\n", name)
+	g.printf("elems, err := %s.Document(data).Elements()\nif err != nil {\nreturn err\n}\n", bsoncore)
+	g.printf("for _, elem := range elems {\nname := elem.Key()\nval := elem.Value()\nkey := name\n")
+	if len(sd.fields) == 0 {
+		// Silence the unused variable check when there is nothing to match against.
+		g.printf("_ = val\n")
+	}
+	g.printf("lookup:\nswitch key {\n")
+	for _, f := range sd.fields {
+		g.printf("case %q:\n", f.name)
+		g.errKey = strconv.Quote(f.name)
+		for _, p := range f.parents {
+			g.printf("if v.%s == nil {\nv.%s = new(%s)\n}\n", p.sel, p.sel,
+				g.typeString(p.typ.Underlying().(*types.Pointer).Elem()))
+		}
+		g.decode("v."+f.sel, "val", f.typ, f.truncate)
+	}
+	g.printf("default:\n")
+	g.printf("// Keys without a struct tag are matched case-insensitively, like the default struct codec.\n")
+	g.printf("if lower := %s.ToLower(key); lower != key {\nkey = lower\ngoto lookup\n}\n", g.use(stringsPath))
+	if sd.inlineMap != nil {
+		g.decodeInlineMap(*sd.inlineMap)
+	}
+	g.printf("}\n}\nreturn nil\n}\n")
+	return nil
+}
+
+// describeStruct collects the fields of t the same way bsoncodec.StructCodec does with the default struct tag parser,
+// including flattening inlined structs and resolving duplicate keys.
+func (g *generator) describeStruct(t types.Type, seen map[types.Type]bool) (*structDescription, error) {
+	if seen[t] {
+		return nil, fmt.Errorf("struct %s inlines itself", t)
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	st := t.Underlying().(*types.Struct)
+	sd := &structDescription{}
+	var fields []field
+	for i := 0; i < st.NumFields(); i++ {
+		sf := st.Field(i)
+		if !sf.Exported() {
+			continue
+		}
+
+		stags, err := bsoncodec.DefaultStructTagParser(reflect.StructField{
+			Name: sf.Name(),
+			Tag:  reflect.StructTag(st.Tag(i)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if stags.Skip {
+			continue
+		}
+		f := field{
+			name:      stags.Name,
+			sel:       sf.Name(),
+			typ:       sf.Type(),
+			omitEmpty: stags.OmitEmpty,
+			minSize:   stags.MinSize,
+			truncate:  stags.Truncate,
+			idx:       i,
+		}
+		if !stags.Inline {
+			fields = append(fields, f)
+			continue
+		}
+
+		inlineType := sf.Type()
+		switch u := inlineType.Underlying().(type) {
+		case *types.Map:
+			if sd.inlineMap != nil {
+				return nil, fmt.Errorf("(struct %s) multiple inline maps", t)
+			}
+			if !types.Identical(u.Key(), types.Typ[types.String]) {
+				return nil, fmt.Errorf("(struct %s) inline map must have a string keys", t)
+			}
+			sd.inlineMap = &f
+			continue
+		case *types.Pointer:
+			inlineType = u.Elem()
+		}
+		if _, ok := inlineType.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("(struct %s) inline fields must be a struct, a struct pointer, or a map", t)
+		}
+		inlined, err := g.describeStruct(inlineType, seen)
+		if err != nil {
+			return nil, err
+		}
+		for _, fd := range inlined.fields {
+			if fd.inline == nil {
+				fd.inline = []int{i, fd.idx}
+			} else {
+				fd.inline = append([]int{i}, fd.inline...)
+			}
+			for j := range fd.parents {
+				fd.parents[j].sel = f.sel + "." + fd.parents[j].sel
+			}
+			if _, ok := f.typ.Underlying().(*types.Pointer); ok {
+				fd.parents = append([]field{f}, fd.parents...)
+			}
+			fd.sel = f.sel + "." + fd.sel
+			fields = append(fields, fd)
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].inline) != len(fields[j].inline) {
+			return len(fields[i].inline) < len(fields[j].inline)
+		}
+		return indexLess(fields[i], fields[j])
+	})
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fields[i].name {
+				break
+			}
+		}
+		if advance > 1 && len(fields[i].inline) == len(fields[i+1].inline) {
+			return nil, fmt.Errorf("struct %s has duplicated key %s", t, fields[i].name)
+		}
+		sd.fields = append(sd.fields, fields[i])
+	}
+	sort.Slice(sd.fields, func(i, j int) bool { return indexLess(sd.fields[i], sd.fields[j]) })
+
+	return sd, nil
+}
+
+// indexLess orders fields by their position in the top level struct, like bsoncodec's byIndex.
+func indexLess(a, b field) bool {
+	aIdx, bIdx := a.idx, b.idx
+	if len(a.inline) > 0 {
+		aIdx = a.inline[0]
+	}
+	if len(b.inline) > 0 {
+		bIdx = b.inline[0]
+	}
+	if aIdx != bIdx {
+		return aIdx < bIdx
+	}
+	for k, ak := range a.inline {
+		if k >= len(b.inline) {
+			return false
+		}
+		if ak != b.inline[k] {
+			return ak < b.inline[k]
+		}
+	}
+	return len(a.inline) < len(b.inline)
+}
+
+// classify determines how values of t are handled. Types from other packages are only handled directly if they are
+// well known, because the default registry may have codecs registered for them.
+func (g *generator) classify(t types.Type) kind {
+	if named, ok := t.(*types.Named); ok {
+		if g.targets[named] {
+			return kindStruct
+		}
+		obj := named.Obj()
+		if obj.Pkg() == nil {
+			// Predeclared types such as error.
+			return kindInterface
+		}
+		switch obj.Pkg().Path() + "." + obj.Name() {
+		case "time.Time":
+			return kindTime
+		case primitivePath + ".ObjectID":
+			return kindObjectID
+		case primitivePath + ".Decimal128":
+			return kindDecimal128
+		case primitivePath + ".DateTime":
+			return kindDateTime
+		case primitivePath + ".UUID":
+			return kindUUID
+		}
+		if _, ok := t.Underlying().(*types.Interface); ok {
+			return kindInterface
+		}
+		if obj.Pkg() != g.pkg || hasBSONMethods(named) {
+			return kindFallback
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.String:
+			return kindString
+		case types.Bool:
+			return kindBool
+		case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+			return kindInt
+		case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			return kindUint
+		case types.Float32, types.Float64:
+			return kindFloat
+		}
+	case *types.Pointer:
+		return kindPointer
+	case *types.Slice:
+		if types.Identical(u.Elem(), types.Typ[types.Byte]) {
+			return kindBytes
+		}
+		if elem, ok := u.Elem().(*types.Named); ok && elem.Obj().Pkg() != nil &&
+			elem.Obj().Pkg().Path() == primitivePath && elem.Obj().Name() == "E" {
+			// Slices of primitive.E are encoded as documents.
+			return kindFallback
+		}
+		return kindSlice
+	case *types.Map:
+		if key, ok := u.Key().Underlying().(*types.Basic); ok && key.Kind() == types.String {
+			return kindMap
+		}
+	case *types.Interface:
+		return kindInterface
+	}
+	return kindFallback
+}
+
+// hasBSONMethods reports whether values of t or *t implement any of the interfaces the default registry uses as hooks.
+func hasBSONMethods(t types.Type) bool {
+	ms := types.NewMethodSet(types.NewPointer(t))
+	for _, name := range []string{"MarshalBSON", "MarshalBSONValue", "ProxyBSON", "UnmarshalBSON", "UnmarshalBSONValue"} {
+		if ms.Lookup(nil, name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// notEmpty returns an expression reporting whether x of type t is not empty according to bsoncodec.StructCodec, or
+// false if values of t are never empty.
+func (g *generator) notEmpty(x string, t types.Type) (string, bool) {
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return x + " != nil", true
+	}
+	if sel := types.NewMethodSet(t).Lookup(nil, "IsZero"); sel != nil {
+		sig := sel.Type().(*types.Signature)
+		if sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+			types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool]) {
+			if _, ok := t.Underlying().(*types.Pointer); ok {
+				return x + " != nil && !" + x + ".IsZero()", true
+			}
+			return "!" + x + ".IsZero()", true
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return "len(" + x + ") != 0", true
+		case u.Info()&types.IsBoolean != 0:
+			return x, true
+		case u.Info()&(types.IsInteger|types.IsFloat) != 0:
+			return x + " != 0", true
+		}
+	case *types.Slice, *types.Map:
+		return "len(" + x + ") != 0", true
+	case *types.Array:
+		if u.Len() == 0 {
+			return "false", true
+		}
+	case *types.Pointer:
+		return x + " != nil", true
+	}
+	return "", false
+}
+
+// deref returns the expression for the value x points to.
+func deref(x string) string {
+	return "(*" + x + ")"
+}
+
+// addr returns the expression for a pointer to the addressable expression x.
+func addr(x string) string {
+	if strings.HasPrefix(x, "(*") && strings.HasSuffix(x, ")") {
+		return x[2 : len(x)-1]
+	}
+	return "&" + x
+}
+
+func (g *generator) encodeField(f field) {
+	var conds []string
+	for _, p := range f.parents {
+		conds = append(conds, "v."+p.sel+" != nil")
+	}
+	x := "v." + f.sel
+	if f.omitEmpty {
+		if cond, ok := g.notEmpty(x, f.typ); ok {
+			conds = append(conds, cond)
+		}
+	}
+	if len(conds) > 0 {
+		g.printf("if %s {\n", strings.Join(conds, " && "))
+	}
+	g.encode(strconv.Quote(f.name), x, f.typ, f.minSize)
+	if len(conds) > 0 {
+		g.printf("}\n")
+	}
+}
+
+func (g *generator) encodeInlineMap(f field, fields []field) {
+	k, e := g.temp("k"), g.temp("e")
+	g.printf("for %s, %s := range v.%s {\n", k, e, f.sel)
+	if len(fields) > 0 {
+		names := make([]string, len(fields))
+		for i, fd := range fields {
+			names[i] = strconv.Quote(fd.name)
+		}
+		g.printf("switch %s {\ncase %s:\n", k, strings.Join(names, ", "))
+		g.printf("return nil, %s.Errorf(\"Key %%s of inlined map conflicts with a struct field name\", %s)\n}\n",
+			g.use(fmtPath), k)
+	}
+	// The inline map is written with the context of the enclosing struct, so the field's minsize option is ignored.
+	g.encode(k, e, f.typ.Underlying().(*types.Map).Elem(), false)
+	g.printf("}\n")
+}
+
+// encode writes statements that append an element with the key expression key and the value x of type t to dst.
+func (g *generator) encode(key, x string, t types.Type, minSize bool) {
+	bsoncore := g.use(bsoncorePath)
+	switch g.classify(t) {
+	case kindString:
+		g.printf("dst = %s.AppendStringElement(dst, %s, string(%s))\n", bsoncore, key, x)
+	case kindBool:
+		g.printf("dst = %s.AppendBooleanElement(dst, %s, bool(%s))\n", bsoncore, key, x)
+	case kindInt:
+		switch t.Underlying().(*types.Basic).Kind() {
+		case types.Int8, types.Int16, types.Int32:
+			g.printf("dst = %s.AppendInt32Element(dst, %s, int32(%s))\n", bsoncore, key, x)
+		case types.Int64:
+			if !minSize {
+				g.printf("dst = %s.AppendInt64Element(dst, %s, int64(%s))\n", bsoncore, key, x)
+				break
+			}
+			fallthrough
+		default:
+			// Values of type int are always written as an int32 if they fit.
+			math := g.use(mathPath)
+			g.printf("if %s >= %s.MinInt32 && %s <= %s.MaxInt32 {\n", x, math, x, math)
+			g.printf("dst = %s.AppendInt32Element(dst, %s, int32(%s))\n} else {\n", bsoncore, key, x)
+			g.printf("dst = %s.AppendInt64Element(dst, %s, int64(%s))\n}\n", bsoncore, key, x)
+		}
+	case kindUint:
+		switch bk := t.Underlying().(*types.Basic).Kind(); bk {
+		case types.Uint8, types.Uint16:
+			g.printf("dst = %s.AppendInt32Element(dst, %s, int32(%s))\n", bsoncore, key, x)
+		default:
+			math := g.use(mathPath)
+			if minSize {
+				g.printf("if %s <= %s.MaxInt32 {\n", x, math)
+				g.printf("dst = %s.AppendInt32Element(dst, %s, int32(%s))\n} else ", bsoncore, key, x)
+			}
+			if bk != types.Uint32 {
+				g.printf("if uint64(%s) > %s.MaxInt64 {\n", x, math)
+				g.printf("return nil, %s.Errorf(\"%%d overflows int64\", %s)\n} else ", g.use(fmtPath), x)
+			}
+			g.printf("{\ndst = %s.AppendInt64Element(dst, %s, int64(%s))\n}\n", bsoncore, key, x)
+		}
+	case kindFloat:
+		g.printf("dst = %s.AppendDoubleElement(dst, %s, float64(%s))\n", bsoncore, key, x)
+	case kindBytes:
+		g.printf("if %s == nil {\ndst = %s.AppendNullElement(dst, %s)\n} else {\n", x, bsoncore, key)
+		g.printf("dst = %s.AppendBinaryElement(dst, %s, %s.BinaryGeneric, %s)\n}\n", bsoncore, key,
+			g.use(bsontypePath), x)
+	case kindTime:
+		g.printf("dst = %s.AppendTimeElement(dst, %s, %s)\n", bsoncore, key, x)
+	case kindObjectID:
+		g.printf("dst = %s.AppendObjectIDElement(dst, %s, %s)\n", bsoncore, key, x)
+	case kindDecimal128:
+		g.printf("dst = %s.AppendDecimal128Element(dst, %s, %s)\n", bsoncore, key, x)
+	case kindDateTime:
+		g.printf("dst = %s.AppendDateTimeElement(dst, %s, int64(%s))\n", bsoncore, key, x)
+	case kindUUID:
+		g.printf("dst = %s.AppendBinaryElement(dst, %s, %s.BinaryUUID, %s[:])\n", bsoncore, key,
+			g.use(bsontypePath), x)
+	case kindStruct:
+		g.printf("dst = %s.AppendHeader(dst, %s.EmbeddedDocument, %s)\n", bsoncore, g.use(bsontypePath), key)
+		g.printf("if dst, err = %s.AppendBSON(dst); err != nil {\nreturn nil, err\n}\n", x)
+	case kindPointer:
+		g.printf("if %s == nil {\ndst = %s.AppendNullElement(dst, %s)\n} else {\n", x, bsoncore, key)
+		g.encode(key, deref(x), t.Underlying().(*types.Pointer).Elem(), minSize)
+		g.printf("}\n")
+	case kindSlice:
+		idx, i := g.temp("idx"), g.temp("i")
+		g.printf("if %s == nil {\ndst = %s.AppendNullElement(dst, %s)\n} else {\n", x, bsoncore, key)
+		g.printf("var %s int32\n%s, dst = %s.AppendArrayElementStart(dst, %s)\n", idx, idx, bsoncore, key)
+		g.printf("for %s := range %s {\n", i, x)
+		g.encode(g.use(strconvPath)+".Itoa("+i+")", x+"["+i+"]", t.Underlying().(*types.Slice).Elem(), minSize)
+		g.printf("}\nif dst, err = %s.AppendArrayEnd(dst, %s); err != nil {\nreturn nil, err\n}\n}\n", bsoncore, idx)
+	case kindMap:
+		idx, k, e := g.temp("idx"), g.temp("k"), g.temp("e")
+		g.printf("if %s == nil {\ndst = %s.AppendNullElement(dst, %s)\n} else {\n", x, bsoncore, key)
+		g.printf("var %s int32\n%s, dst = %s.AppendDocumentElementStart(dst, %s)\n", idx, idx, bsoncore, key)
+		g.printf("for %s, %s := range %s {\n", k, e, x)
+		g.encode("string("+k+")", e, t.Underlying().(*types.Map).Elem(), minSize)
+		g.printf("}\nif dst, err = %s.AppendDocumentEnd(dst, %s); err != nil {\nreturn nil, err\n}\n}\n", bsoncore, idx)
+	case kindInterface:
+		g.printf("if %s == nil {\ndst = %s.AppendNullElement(dst, %s)\n} else {\n", x, bsoncore, key)
+		g.encodeFallback(key, x, minSize)
+		g.printf("}\n")
+	default:
+		g.printf("{\n")
+		g.encodeFallback(key, x, minSize)
+		g.printf("}\n")
+	}
+}
+
+// encodeFallback writes statements that append x using bson.DefaultRegistry. The statements declare variables, so they
+// must be written in their own block.
+func (g *generator) encodeFallback(key, x string, minSize bool) {
+	bson, bsoncore := g.use(bsonPath), g.use(bsoncorePath)
+	ec := fmt.Sprintf("%s.EncodeContext{Registry: %s.DefaultRegistry", g.use(bsoncodecPath), bson)
+	if minSize {
+		ec += ", MinSize: true"
+	}
+	g.printf("typ, data, err := %s.MarshalValueWithContext(%s}, %s)\n", bson, ec, x)
+	g.printf("if err != nil {\nreturn nil, err\n}\n")
+	g.printf("dst = %s.AppendValueElement(dst, %s, %s.Value{Type: typ, Data: data})\n", bsoncore, key, bsoncore)
+}
+
+func (g *generator) decodeInlineMap(f field) {
+	elem := f.typ.Underlying().(*types.Map).Elem()
+	e := g.temp("e")
+	g.printf("if v.%s == nil {\nv.%s = make(%s)\n}\n", f.sel, f.sel, g.typeString(f.typ))
+	g.printf("var %s %s\n", e, g.typeString(elem))
+	g.errKey = "name"
+	if g.classify(elem) == kindInterface {
+		// Embedded documents are decoded as the type of the map, like the default struct codec does.
+		g.decodeFallback(e, "val", f.truncate, g.use(reflectPath)+".TypeOf(v."+f.sel+")")
+	} else {
+		g.decode(e, "val", elem, f.truncate)
+	}
+	g.printf("v.%s[name] = %s\n", f.sel, e)
+}
+
+// decode writes statements that decode the bsoncore.Value val into the addressable expression x of type t. Values
+// that are not of the BSON type usually written for t are decoded with bson.DefaultRegistry, which handles conversions
+// between BSON types and reports errors the same way.
+func (g *generator) decode(x, val string, t types.Type, truncate bool) {
+	typeName := g.typeString(t)
+	bsontype := g.use(bsontypePath)
+	k := g.classify(t)
+	switch k {
+	case kindString:
+		g.printf("if s, ok := %s.StringValueOK(); ok {\n%s = %s(s)\n} else ", val, x, typeName)
+	case kindBool:
+		g.printf("if b, ok := %s.BooleanOK(); ok {\n%s = %s(b)\n} else ", val, x, typeName)
+	case kindInt, kindUint:
+		bk := t.Underlying().(*types.Basic).Kind()
+		var fit64, fit32 string
+		switch {
+		case k == kindUint:
+			fit64 = fmt.Sprintf(" && i64 >= 0 && uint64(%s(i64)) == uint64(i64)", typeName)
+			fit32 = " && i32 >= 0"
+			if bk == types.Uint8 || bk == types.Uint16 {
+				fit32 += fmt.Sprintf(" && int32(%s(i32)) == i32", typeName)
+			}
+		case bk == types.Int64:
+		case bk == types.Int || bk == types.Int32:
+			fit64 = fmt.Sprintf(" && int64(%s(i64)) == i64", typeName)
+		default:
+			fit64 = fmt.Sprintf(" && int64(%s(i64)) == i64", typeName)
+			fit32 = fmt.Sprintf(" && int32(%s(i32)) == i32", typeName)
+		}
+		g.printf("if i32, ok := %s.Int32OK(); ok%s {\n%s = %s(i32)\n", val, fit32, x, typeName)
+		g.printf("} else if i64, ok := %s.Int64OK(); ok%s {\n%s = %s(i64)\n} else ", val, fit64, x, typeName)
+	case kindFloat:
+		fit := ""
+		if t.Underlying().(*types.Basic).Kind() == types.Float32 && !truncate {
+			fit = " && float64(float32(f)) == f"
+		}
+		g.printf("if f, ok := %s.DoubleOK(); ok%s {\n%s = %s(f)\n} else ", val, fit, x, typeName)
+	case kindBytes:
+		g.printf("if subtype, b, ok := %s.BinaryOK(); ok && subtype == %s.BinaryGeneric {\n", val, bsontype)
+		g.printf("%s = make(%s, len(b))\ncopy(%s, b)\n} else ", x, typeName, x)
+	case kindTime:
+		g.printf("if dt, ok := %s.DateTimeOK(); ok {\n%s = %s.DateTime(dt).Time().UTC()\n} else ", val, x,
+			g.use(primitivePath))
+	case kindObjectID:
+		g.printf("if oid, ok := %s.ObjectIDOK(); ok {\n%s = oid\n} else ", val, x)
+	case kindDecimal128:
+		g.printf("if d128, ok := %s.Decimal128OK(); ok {\n%s = d128\n} else ", val, x)
+	case kindDateTime:
+		g.printf("if dt, ok := %s.DateTimeOK(); ok {\n%s = %s(dt)\n} else ", val, x, typeName)
+	case kindUUID:
+		g.printf("if subtype, b, ok := %s.BinaryOK(); ok && subtype == %s.BinaryUUID && len(b) == len(%s) {\n",
+			val, bsontype, x)
+		g.printf("copy(%s[:], b)\n} else ", x)
+	case kindStruct:
+		g.printf("if %s.Type == %s.EmbeddedDocument {\n", val, bsontype)
+		g.printf("if err := %s.UnmarshalBSON(%s.Data); err != nil {\n", x, val)
+		g.fail("err")
+		g.printf("}\n} else ")
+	case kindPointer:
+		elem := t.Underlying().(*types.Pointer).Elem()
+		g.printf("if %s.Type == %s.Null || %s.Type == %s.Undefined {\n%s = nil\n} else {\n", val, bsontype, val,
+			bsontype, x)
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", x, x, g.typeString(elem))
+		g.decode(deref(x), val, elem, truncate)
+		g.printf("}\n")
+		return
+	case kindSlice, kindMap:
+		var elem types.Type
+		if k == kindSlice {
+			elem = t.Underlying().(*types.Slice).Elem()
+		} else {
+			elem = t.Underlying().(*types.Map).Elem()
+		}
+		if g.classify(elem) == kindInterface {
+			// Embedded documents in interface elements are decoded based on the type of the container.
+			break
+		}
+		if k == kindSlice {
+			g.decodeSlice(x, val, typeName, elem, truncate)
+		} else {
+			g.decodeMap(x, val, typeName, t.Underlying().(*types.Map).Key(), elem, truncate)
+		}
+		g.printf("} else if %s.Type == %s.Null || %s.Type == %s.Undefined {\n%s = nil\n} else ", val, bsontype,
+			val, bsontype, x)
+	}
+	g.decodeFallback(x, val, truncate, "")
+}
+
+func (g *generator) decodeSlice(x, val, typeName string, elem types.Type, truncate bool) {
+	vals, ev, e := g.temp("vals"), g.temp("val"), g.temp("e")
+	g.printf("if arr, ok := %s.ArrayOK(); ok {\n%s, err := arr.Values()\nif err != nil {\n", val, vals)
+	g.fail("err")
+	g.printf("}\nif %s == nil {\n%s = make(%s, 0, len(%s))\n}\n%s = %s[:0]\n", x, x, typeName, vals, x, x)
+	g.printf("for _, %s := range %s {\nvar %s %s\n", ev, vals, e, g.typeString(elem))
+	g.decode(e, ev, elem, truncate)
+	g.printf("%s = append(%s, %s)\n}\n", x, x, e)
+}
+
+func (g *generator) decodeMap(x, val, typeName string, key, elem types.Type, truncate bool) {
+	elems, el, ev, e := g.temp("elems"), g.temp("elem"), g.temp("val"), g.temp("e")
+	g.printf("if doc, ok := %s.DocumentOK(); ok {\n%s, err := doc.Elements()\nif err != nil {\n", val, elems)
+	g.fail("err")
+	g.printf("}\nif %s == nil {\n%s = make(%s, len(%s))\n}\n", x, x, typeName, elems)
+	g.printf("for _, %s := range %s {\n%s := %s.Value()\nvar %s %s\n", el, elems, ev, el, e, g.typeString(elem))
+	g.decode(e, ev, elem, truncate)
+	g.printf("%s[%s(%s.Key())] = %s\n}\n", x, g.typeString(key), el, e)
+}
+
+// decodeFallback writes a statement that decodes val into x using bson.DefaultRegistry.
+func (g *generator) decodeFallback(x, val string, truncate bool, ancestor string) {
+	bson := g.use(bsonPath)
+	dc := fmt.Sprintf("%s.DecodeContext{Registry: %s.DefaultRegistry", g.use(bsoncodecPath), bson)
+	if truncate {
+		dc += ", Truncate: true"
+	}
+	if ancestor != "" {
+		dc += ", Ancestor: " + ancestor
+	}
+	g.printf("if err := (%s.RawValue{Type: %s.Type, Value: %s.Data}).UnmarshalWithContext(&%s}, %s); err != nil {\n",
+		bson, val, val, dc, addr(x))
+	g.fail("err")
+	g.printf("}\n")
+}
+
+func (g *generator) fail(err string) {
+	g.printf("return %s.Errorf(\"error decoding key %%s: %%v\", %s, %s)\n", g.use(fmtPath), g.errKey, err)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("generated test types are up to date", func(t *testing.T) {
+		dir := filepath.Join("internal", "bsongentest")
+		output := filepath.Join(dir, "types_bson.go")
+		pkg, err := loadPackage(dir, output)
+		assert.Nil(t, err, "loadPackage error: %v", err)
+
+		got, err := generate(pkg, []string{"Person", "Address", "Counters", "Wrapper"},
+			"bsongen -type Person,Address,Counters,Wrapper -output types_bson.go")
+		assert.Nil(t, err, "generate error: %v", err)
+		want, err := ioutil.ReadFile(output)
+		assert.Nil(t, err, "ReadFile error: %v", err)
+		assert.True(t, bytes.Equal(want, got), "%s is out of date, run go generate", output)
+	})
+	t.Run("load package", func(t *testing.T) {
+		load := func(t *testing.T, files map[string]string) error {
+			t.Helper()
+			dir, err := ioutil.TempDir("", "bsongen")
+			assert.Nil(t, err, "TempDir error: %v", err)
+			defer os.RemoveAll(dir)
+			for name, src := range files {
+				err = ioutil.WriteFile(filepath.Join(dir, name), []byte("package p\n"+src), 0644)
+				assert.Nil(t, err, "WriteFile error: %v", err)
+			}
+			_, err = loadPackage(dir, filepath.Join(dir, "t_bson.go"))
+			return err
+		}
+
+		t.Run("references to output ignored", func(t *testing.T) {
+			err := load(t, map[string]string{
+				"t.go":      "type T struct{ A int }\nvar _ = T{}.MarshalBSON",
+				"t_bson.go": "func (v T) MarshalBSON() ([]byte, error) { return v.B, nil }",
+			})
+			assert.Nil(t, err, "loadPackage error: %v", err)
+		})
+		t.Run("other errors reported", func(t *testing.T) {
+			err := load(t, map[string]string{
+				"t.go":      "type T struct{ A int }\nvar _ = T{}.MarshalBSON\nvar _ int = \"x\"",
+				"t_bson.go": "func (v T) MarshalBSON() ([]byte, error) { return nil, nil }",
+			})
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.True(t, strings.Contains(err.Error(), "t.go:4"), "expected error in t.go, got %v", err)
+		})
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			src    string
+			errMsg string
+		}{
+			{"missing type", "type T struct{}", "type Missing not found"},
+			{"not a struct", "type Missing int", "Missing is not a struct type"},
+			{
+				"multiple inline maps",
+				"type Missing struct { A map[string]int `bson:\",inline\"`; B map[string]int `bson:\",inline\"` }",
+				"multiple inline maps",
+			},
+			{
+				"inline map key",
+				"type K string; type Missing struct { A map[K]int `bson:\",inline\"` }",
+				"inline map must have a string keys",
+			},
+			{
+				"inline scalar",
+				"type Missing struct { A int `bson:\",inline\"` }",
+				"inline fields must be a struct, a struct pointer, or a map",
+			},
+			{
+				"duplicate keys",
+				"type Missing struct { A int `bson:\"x\"`; B int `bson:\"x\"` }",
+				"has duplicated key x",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				fset := token.NewFileSet()
+				file, err := parser.ParseFile(fset, "src.go", "package p\n"+tc.src, 0)
+				assert.Nil(t, err, "ParseFile error: %v", err)
+				conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+				pkg, err := conf.Check("p", fset, []*ast.File{file}, nil)
+				assert.Nil(t, err, "Check error: %v", err)
+
+				_, err = generate(pkg, []string{"Missing"}, "bsongen")
+				assert.NotNil(t, err, "expected error, got nil")
+				assert.True(t, strings.Contains(err.Error(), tc.errMsg), "expected error containing %q, got %v",
+					tc.errMsg, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsongentest
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// The plain types have the same fields and tags as the generated types but no methods, so bson.Marshal and
+// bson.Unmarshal use the default struct codec for them.
+type plainPerson Person
+type plainCounters Counters
+type plainWrapper Wrapper
+
+type generated interface {
+	bson.Marshaler
+	bson.Unmarshaler
+}
+
+func TestGeneratedMatchesStructCodec(t *testing.T) {
+	now := time.Date(2022, 3, 4, 5, 6, 7, 8000000, time.UTC)
+	oid := primitive.NewObjectIDFromTimestamp(now)
+	dec, _ := primitive.ParseDecimal128("1234.5678")
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	full := Person{
+		ID:       oid,
+		Name:     "Ada",
+		Nickname: "ada",
+		Age:      36,
+		Active:   true,
+		Score:    98.5,
+		Ratio:    0.25,
+		Status:   "active",
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"team": "core"},
+		Created:  now,
+		Updated:  &now,
+		Balance:  dec,
+		Session:  primitive.UUID{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd},
+		Seen:     primitive.NewDateTimeFromTime(now),
+		Avatar:   []byte{1, 2, 3},
+		Home:     Address{Street: "1 Main St", City: "Springfield"},
+		Work:     &Address{Street: "2 Side St"},
+		Previous: []Address{{Street: "3 Old Rd"}, {City: "Shelbyville"}},
+		Extra:    bson.D{{Key: "nested", Value: int32(1)}},
+		Binary:   primitive.Binary{Subtype: 0x80, Data: []byte{4, 5}},
+		Ignored:  "ignored",
+		internal: 1,
+	}
+	zeroUpdated := time.Time{}
+
+	testCases := []struct {
+		name  string
+		val   generated
+		plain interface{}
+	}{
+		{"zero Person", &Person{}, plainPerson{}},
+		{"full Person", &full, plainPerson(full)},
+		{"Person with zero time pointer", &Person{Updated: &zeroUpdated, Tags: []string{}},
+			plainPerson{Updated: &zeroUpdated, Tags: []string{}}},
+		{"zero Counters", &Counters{}, plainCounters{}},
+		{
+			"small Counters",
+			&Counters{Int: 1, Int8: -2, Int16: 3, Int32: -4, Int64: 5, MinInt64: 6, Uint8: 7, Uint16: 8, Uint32: 9,
+				MinUint: 10, Uint64: 11, Uint: 12, Many: []int64{1, 2}, ByName: map[string]int64{"a": 1},
+				Ptr: int64Ptr(0), Fixed: [2]int64{1, 2}},
+			plainCounters{Int: 1, Int8: -2, Int16: 3, Int32: -4, Int64: 5, MinInt64: 6, Uint8: 7, Uint16: 8,
+				Uint32: 9, MinUint: 10, Uint64: 11, Uint: 12, Many: []int64{1, 2}, ByName: map[string]int64{"a": 1},
+				Ptr: int64Ptr(0), Fixed: [2]int64{1, 2}},
+		},
+		{
+			"large Counters",
+			&Counters{Int: math.MaxInt32 + 1, Int64: math.MinInt64, MinInt64: math.MaxInt32 + 1,
+				Uint32: math.MaxUint32, MinUint: math.MaxUint32, Uint64: math.MaxInt64, Uint: math.MaxInt32 + 1,
+				Many: []int64{math.MaxInt64}},
+			plainCounters{Int: math.MaxInt32 + 1, Int64: math.MinInt64, MinInt64: math.MaxInt32 + 1,
+				Uint32: math.MaxUint32, MinUint: math.MaxUint32, Uint64: math.MaxInt64, Uint: math.MaxInt32 + 1,
+				Many: []int64{math.MaxInt64}},
+		},
+		{"Wrapper without Audit", &Wrapper{Kind: "k", Meta: Meta{Version: 2, Kind: "ignored"}},
+			plainWrapper{Kind: "k", Meta: Meta{Version: 2, Kind: "ignored"}}},
+		{
+			"Wrapper with Audit and inline map",
+			&Wrapper{Kind: "k", Audit: &Audit{By: "me", At: now}, Rest: map[string]interface{}{"other": "x"}},
+			plainWrapper{Kind: "k", Audit: &Audit{By: "me", At: now}, Rest: map[string]interface{}{"other": "x"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.val.MarshalBSON()
+			assert.Nil(t, err, "MarshalBSON error: %v", err)
+			want, err := bson.Marshal(tc.plain)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.True(t, bytes.Equal(want, got), "expected %v, got %v", bsoncore.Document(want),
+				bsoncore.Document(got))
+
+			assertSameDecoding(t, tc.val, tc.plain, want)
+		})
+	}
+	t.Run("encoding errors", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			val   generated
+			plain interface{}
+		}{
+			{"uint64 overflow", &Counters{Uint64: math.MaxUint64}, plainCounters{Uint64: math.MaxUint64}},
+			{"inline map conflict", &Wrapper{Rest: map[string]interface{}{"kind": 1}},
+				plainWrapper{Rest: map[string]interface{}{"kind": 1}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.val.MarshalBSON()
+				assert.NotNil(t, err, "expected MarshalBSON error, got nil")
+				_, want := bson.Marshal(tc.plain)
+				assert.NotNil(t, want, "expected Marshal error, got nil")
+				assert.Equal(t, want.Error(), err.Error(), "expected error %v, got %v", want, err)
+			})
+		}
+	})
+}
+
+func TestGeneratedDecoding(t *testing.T) {
+	doc := func(elems ...[]byte) []byte {
+		return bsoncore.BuildDocument(nil, elems...)
+	}
+
+	testCases := []struct {
+		name string
+		val  generated
+		doc  []byte
+	}{
+		{"case insensitive keys", &Person{}, doc(
+			bsoncore.AppendStringElement(nil, "NAME", "Ada"),
+			bsoncore.AppendStringElement(nil, "Status", "active"),
+		)},
+		{"unknown keys", &Person{}, doc(bsoncore.AppendStringElement(nil, "unknown", "x"))},
+		{"nulls", &Person{Name: "x", Tags: []string{"a"}, Work: &Address{}}, doc(
+			bsoncore.AppendNullElement(nil, "name"),
+			bsoncore.AppendNullElement(nil, "tags"),
+			bsoncore.AppendNullElement(nil, "work"),
+			bsoncore.AppendNullElement(nil, "home"),
+			bsoncore.AppendNullElement(nil, "labels"),
+		)},
+		{"conversions", &Person{}, doc(
+			bsoncore.AppendInt64Element(nil, "age", 12),
+			bsoncore.AppendInt32Element(nil, "score", 3),
+			bsoncore.AppendDoubleElement(nil, "ratio", 0.1),
+			bsoncore.AppendInt64Element(nil, "seen", 100),
+			bsoncore.AppendStringElement(nil, "session", "00112233-4455-6677-8899-aabbccddeeff"),
+		)},
+		{"existing values are merged", &Person{Name: "x", Home: Address{City: "c"}}, doc(
+			bsoncore.AppendDocumentElement(nil, "home", doc(bsoncore.AppendStringElement(nil, "street", "s"))),
+		)},
+		{"integer ranges", &Counters{}, doc(
+			bsoncore.AppendInt64Element(nil, "int", math.MaxInt32+1),
+			bsoncore.AppendInt32Element(nil, "int8", math.MaxInt8),
+			bsoncore.AppendInt32Element(nil, "uint16", math.MaxUint16),
+			bsoncore.AppendInt64Element(nil, "uint32", math.MaxUint32),
+			bsoncore.AppendInt32Element(nil, "uint", 0),
+			bsoncore.AppendDoubleElement(nil, "int64", 2),
+		)},
+		{"int8 overflow", &Counters{}, doc(bsoncore.AppendInt32Element(nil, "int8", math.MaxInt8+1))},
+		{"negative uint", &Counters{}, doc(bsoncore.AppendInt64Element(nil, "uint64", -1))},
+		{"fractional double", &Counters{}, doc(bsoncore.AppendDoubleElement(nil, "int32", 1.5))},
+		{"wrong type", &Person{}, doc(bsoncore.AppendStringElement(nil, "age", "1"))},
+		{"wrong element type", &Person{}, doc(
+			bsoncore.BuildArrayElement(nil, "tags", bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)}),
+		)},
+		{"inline", &Wrapper{}, doc(
+			bsoncore.AppendStringElement(nil, "kind", "k"),
+			bsoncore.AppendStringElement(nil, "by", "me"),
+			bsoncore.AppendInt32Element(nil, "version", 3),
+			bsoncore.AppendDocumentElement(nil, "extra", doc(bsoncore.AppendInt32Element(nil, "a", 1))),
+		)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plain := reflect.ValueOf(tc.val).Elem().Convert(plainType(tc.val)).Interface()
+			assertSameDecoding(t, tc.val, plain, tc.doc)
+		})
+	}
+}
+
+func plainType(val generated) reflect.Type {
+	switch val.(type) {
+	case *Person:
+		return reflect.TypeOf(plainPerson{})
+	case *Counters:
+		return reflect.TypeOf(plainCounters{})
+	case *Wrapper:
+		return reflect.TypeOf(plainWrapper{})
+	}
+	return nil
+}
+
+// assertSameDecoding decodes data with UnmarshalBSON into a copy of val and with bson.Unmarshal into a copy of plain
+// and asserts that the results are the same.
+func assertSameDecoding(t *testing.T, val generated, plain interface{}, data []byte) {
+	t.Helper()
+
+	gotPtr := reflect.New(reflect.TypeOf(val).Elem())
+	gotPtr.Elem().Set(reflect.ValueOf(val).Elem())
+	gotErr := gotPtr.Interface().(generated).UnmarshalBSON(data)
+
+	wantPtr := reflect.New(reflect.TypeOf(plain))
+	wantPtr.Elem().Set(reflect.ValueOf(plain))
+	wantErr := bson.Unmarshal(data, wantPtr.Interface())
+
+	if wantErr != nil {
+		assert.NotNil(t, gotErr, "expected error %v, got nil", wantErr)
+		return
+	}
+	assert.Nil(t, gotErr, "UnmarshalBSON error: %v", gotErr)
+	got := gotPtr.Elem().Convert(reflect.TypeOf(plain)).Interface()
+	want := wantPtr.Elem().Interface()
+	assert.True(t, reflect.DeepEqual(want, got), "expected %#v, got %#v", want, got)
+}
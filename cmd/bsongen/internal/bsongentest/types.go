@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package bsongentest contains types with generated BSON methods that are used to test bsongen.
+package bsongentest
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate go run go.mongodb.org/mongo-driver/cmd/bsongen -type Person,Address,Counters,Wrapper -output types_bson.go
+
+// Status is a string type without custom BSON methods.
+type Status string
+
+// Person exercises most of the supported field types and struct tag options.
+type Person struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Name     string
+	Nickname string `bson:"nick,omitempty"`
+	Age      int
+	Active   bool `bson:"active,omitempty"`
+	Score    float64
+	Ratio    float32 `bson:",truncate"`
+	Status   Status
+	Tags     []string          `bson:"tags,omitempty"`
+	Labels   map[string]string `bson:"labels"`
+	Created  time.Time         `bson:"created,omitempty"`
+	Updated  *time.Time        `bson:"updated,omitempty"`
+	Balance  primitive.Decimal128
+	Session  primitive.UUID     `bson:"session,omitempty"`
+	Seen     primitive.DateTime `bson:"seen"`
+	Avatar   []byte             `bson:"avatar"`
+	Home     Address            `bson:"home"`
+	Work     *Address           `bson:"work,omitempty"`
+	Previous []Address          `bson:"previous"`
+	Extra    interface{}        `bson:"extra"`
+	Binary   primitive.Binary   `bson:"binary"`
+	Ignored  string             `bson:"-"`
+	internal int
+}
+
+// Address is nested in Person.
+type Address struct {
+	Street string `bson:"street"`
+	City   string `bson:"city,omitempty"`
+}
+
+// Counters exercises integer encoding and the minsize option.
+type Counters struct {
+	Int      int
+	Int8     int8
+	Int16    int16
+	Int32    int32
+	Int64    int64
+	MinInt64 int64 `bson:",minsize"`
+	Uint8    uint8
+	Uint16   uint16
+	Uint32   uint32
+	MinUint  uint32 `bson:",minsize"`
+	Uint64   uint64
+	Uint     uint             `bson:",minsize"`
+	Many     []int64          `bson:",minsize"`
+	ByName   map[string]int64 `bson:",minsize"`
+	Ptr      *int64           `bson:",omitempty"`
+	Fixed    [2]int64         `bson:",minsize"`
+}
+
+// Meta is inlined into Wrapper.
+type Meta struct {
+	Version int    `bson:"version"`
+	Owner   string `bson:"owner,omitempty"`
+	Kind    string `bson:"kind"`
+}
+
+// Audit is inlined into Wrapper through a pointer.
+type Audit struct {
+	By  string    `bson:"by"`
+	At  time.Time `bson:"at"`
+	Doc *Meta     `bson:",inline"`
+}
+
+// Wrapper exercises inlined structs, inlined struct pointers and inlined maps.
+type Wrapper struct {
+	Kind  string                 `bson:"kind"`
+	Meta  Meta                   `bson:",inline"`
+	Audit *Audit                 `bson:",inline"`
+	Rest  map[string]interface{} `bson:",inline"`
+}
@@ -0,0 +1,819 @@
+// Code generated by "bsongen -type Person,Address,Counters,Wrapper -output types_bson.go"; DO NOT EDIT.
+
+package bsongentest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Person) MarshalBSON() ([]byte, error) {
+	return v.AppendBSON(nil)
+}
+
+// AppendBSON appends the BSON document for v to dst.
+func (v Person) AppendBSON(dst []byte) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	var err error
+	if !v.ID.IsZero() {
+		dst = bsoncore.AppendObjectIDElement(dst, "_id", v.ID)
+	}
+	dst = bsoncore.AppendStringElement(dst, "name", string(v.Name))
+	if len(v.Nickname) != 0 {
+		dst = bsoncore.AppendStringElement(dst, "nick", string(v.Nickname))
+	}
+	if v.Age >= math.MinInt32 && v.Age <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "age", int32(v.Age))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "age", int64(v.Age))
+	}
+	if v.Active {
+		dst = bsoncore.AppendBooleanElement(dst, "active", bool(v.Active))
+	}
+	dst = bsoncore.AppendDoubleElement(dst, "score", float64(v.Score))
+	dst = bsoncore.AppendDoubleElement(dst, "ratio", float64(v.Ratio))
+	dst = bsoncore.AppendStringElement(dst, "status", string(v.Status))
+	if len(v.Tags) != 0 {
+		if v.Tags == nil {
+			dst = bsoncore.AppendNullElement(dst, "tags")
+		} else {
+			var idx1 int32
+			idx1, dst = bsoncore.AppendArrayElementStart(dst, "tags")
+			for i2 := range v.Tags {
+				dst = bsoncore.AppendStringElement(dst, strconv.Itoa(i2), string(v.Tags[i2]))
+			}
+			if dst, err = bsoncore.AppendArrayEnd(dst, idx1); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if v.Labels == nil {
+		dst = bsoncore.AppendNullElement(dst, "labels")
+	} else {
+		var idx3 int32
+		idx3, dst = bsoncore.AppendDocumentElementStart(dst, "labels")
+		for k4, e5 := range v.Labels {
+			dst = bsoncore.AppendStringElement(dst, string(k4), string(e5))
+		}
+		if dst, err = bsoncore.AppendDocumentEnd(dst, idx3); err != nil {
+			return nil, err
+		}
+	}
+	if !v.Created.IsZero() {
+		dst = bsoncore.AppendTimeElement(dst, "created", v.Created)
+	}
+	if v.Updated != nil && !v.Updated.IsZero() {
+		if v.Updated == nil {
+			dst = bsoncore.AppendNullElement(dst, "updated")
+		} else {
+			dst = bsoncore.AppendTimeElement(dst, "updated", (*v.Updated))
+		}
+	}
+	dst = bsoncore.AppendDecimal128Element(dst, "balance", v.Balance)
+	if !v.Session.IsZero() {
+		dst = bsoncore.AppendBinaryElement(dst, "session", bsontype.BinaryUUID, v.Session[:])
+	}
+	dst = bsoncore.AppendDateTimeElement(dst, "seen", int64(v.Seen))
+	if v.Avatar == nil {
+		dst = bsoncore.AppendNullElement(dst, "avatar")
+	} else {
+		dst = bsoncore.AppendBinaryElement(dst, "avatar", bsontype.BinaryGeneric, v.Avatar)
+	}
+	dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, "home")
+	if dst, err = v.Home.AppendBSON(dst); err != nil {
+		return nil, err
+	}
+	if v.Work != nil {
+		if v.Work == nil {
+			dst = bsoncore.AppendNullElement(dst, "work")
+		} else {
+			dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, "work")
+			if dst, err = (*v.Work).AppendBSON(dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if v.Previous == nil {
+		dst = bsoncore.AppendNullElement(dst, "previous")
+	} else {
+		var idx6 int32
+		idx6, dst = bsoncore.AppendArrayElementStart(dst, "previous")
+		for i7 := range v.Previous {
+			dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, strconv.Itoa(i7))
+			if dst, err = v.Previous[i7].AppendBSON(dst); err != nil {
+				return nil, err
+			}
+		}
+		if dst, err = bsoncore.AppendArrayEnd(dst, idx6); err != nil {
+			return nil, err
+		}
+	}
+	if v.Extra == nil {
+		dst = bsoncore.AppendNullElement(dst, "extra")
+	} else {
+		typ, data, err := bson.MarshalValueWithContext(bsoncodec.EncodeContext{Registry: bson.DefaultRegistry}, v.Extra)
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, "extra", bsoncore.Value{Type: typ, Data: data})
+	}
+	{
+		typ, data, err := bson.MarshalValueWithContext(bsoncodec.EncodeContext{Registry: bson.DefaultRegistry}, v.Binary)
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, "binary", bsoncore.Value{Type: typ, Data: data})
+	}
+	if dst, err = bsoncore.AppendDocumentEnd(dst, idx); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Person) UnmarshalBSON(data []byte) error {
+	elems, err := bsoncore.Document(data).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		name := elem.Key()
+		val := elem.Value()
+		key := name
+	lookup:
+		switch key {
+		case "_id":
+			if oid, ok := val.ObjectIDOK(); ok {
+				v.ID = oid
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.ID); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "_id", err)
+			}
+		case "name":
+			if s, ok := val.StringValueOK(); ok {
+				v.Name = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Name); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "name", err)
+			}
+		case "nick":
+			if s, ok := val.StringValueOK(); ok {
+				v.Nickname = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Nickname); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "nick", err)
+			}
+		case "age":
+			if i32, ok := val.Int32OK(); ok {
+				v.Age = int(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int(i64)) == i64 {
+				v.Age = int(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Age); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "age", err)
+			}
+		case "active":
+			if b, ok := val.BooleanOK(); ok {
+				v.Active = bool(b)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Active); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "active", err)
+			}
+		case "score":
+			if f, ok := val.DoubleOK(); ok {
+				v.Score = float64(f)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Score); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "score", err)
+			}
+		case "ratio":
+			if f, ok := val.DoubleOK(); ok {
+				v.Ratio = float32(f)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry, Truncate: true}, &v.Ratio); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "ratio", err)
+			}
+		case "status":
+			if s, ok := val.StringValueOK(); ok {
+				v.Status = Status(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Status); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "status", err)
+			}
+		case "tags":
+			if arr, ok := val.ArrayOK(); ok {
+				vals8, err := arr.Values()
+				if err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "tags", err)
+				}
+				if v.Tags == nil {
+					v.Tags = make([]string, 0, len(vals8))
+				}
+				v.Tags = v.Tags[:0]
+				for _, val9 := range vals8 {
+					var e10 string
+					if s, ok := val9.StringValueOK(); ok {
+						e10 = string(s)
+					} else if err := (bson.RawValue{Type: val9.Type, Value: val9.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &e10); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "tags", err)
+					}
+					v.Tags = append(v.Tags, e10)
+				}
+			} else if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Tags = nil
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Tags); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "tags", err)
+			}
+		case "labels":
+			if doc, ok := val.DocumentOK(); ok {
+				elems11, err := doc.Elements()
+				if err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "labels", err)
+				}
+				if v.Labels == nil {
+					v.Labels = make(map[string]string, len(elems11))
+				}
+				for _, elem12 := range elems11 {
+					val13 := elem12.Value()
+					var e14 string
+					if s, ok := val13.StringValueOK(); ok {
+						e14 = string(s)
+					} else if err := (bson.RawValue{Type: val13.Type, Value: val13.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &e14); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "labels", err)
+					}
+					v.Labels[string(elem12.Key())] = e14
+				}
+			} else if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Labels = nil
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Labels); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "labels", err)
+			}
+		case "created":
+			if dt, ok := val.DateTimeOK(); ok {
+				v.Created = primitive.DateTime(dt).Time().UTC()
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Created); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "created", err)
+			}
+		case "updated":
+			if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Updated = nil
+			} else {
+				if v.Updated == nil {
+					v.Updated = new(time.Time)
+				}
+				if dt, ok := val.DateTimeOK(); ok {
+					(*v.Updated) = primitive.DateTime(dt).Time().UTC()
+				} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, v.Updated); err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "updated", err)
+				}
+			}
+		case "balance":
+			if d128, ok := val.Decimal128OK(); ok {
+				v.Balance = d128
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Balance); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "balance", err)
+			}
+		case "session":
+			if subtype, b, ok := val.BinaryOK(); ok && subtype == bsontype.BinaryUUID && len(b) == len(v.Session) {
+				copy(v.Session[:], b)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Session); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "session", err)
+			}
+		case "seen":
+			if dt, ok := val.DateTimeOK(); ok {
+				v.Seen = primitive.DateTime(dt)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Seen); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "seen", err)
+			}
+		case "avatar":
+			if subtype, b, ok := val.BinaryOK(); ok && subtype == bsontype.BinaryGeneric {
+				v.Avatar = make([]byte, len(b))
+				copy(v.Avatar, b)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Avatar); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "avatar", err)
+			}
+		case "home":
+			if val.Type == bsontype.EmbeddedDocument {
+				if err := v.Home.UnmarshalBSON(val.Data); err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "home", err)
+				}
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Home); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "home", err)
+			}
+		case "work":
+			if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Work = nil
+			} else {
+				if v.Work == nil {
+					v.Work = new(Address)
+				}
+				if val.Type == bsontype.EmbeddedDocument {
+					if err := (*v.Work).UnmarshalBSON(val.Data); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "work", err)
+					}
+				} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, v.Work); err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "work", err)
+				}
+			}
+		case "previous":
+			if arr, ok := val.ArrayOK(); ok {
+				vals15, err := arr.Values()
+				if err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "previous", err)
+				}
+				if v.Previous == nil {
+					v.Previous = make([]Address, 0, len(vals15))
+				}
+				v.Previous = v.Previous[:0]
+				for _, val16 := range vals15 {
+					var e17 Address
+					if val16.Type == bsontype.EmbeddedDocument {
+						if err := e17.UnmarshalBSON(val16.Data); err != nil {
+							return fmt.Errorf("error decoding key %s: %v", "previous", err)
+						}
+					} else if err := (bson.RawValue{Type: val16.Type, Value: val16.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &e17); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "previous", err)
+					}
+					v.Previous = append(v.Previous, e17)
+				}
+			} else if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Previous = nil
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Previous); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "previous", err)
+			}
+		case "extra":
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Extra); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "extra", err)
+			}
+		case "binary":
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Binary); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "binary", err)
+			}
+		default:
+			// Keys without a struct tag are matched case-insensitively, like the default struct codec.
+			if lower := strings.ToLower(key); lower != key {
+				key = lower
+				goto lookup
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Address) MarshalBSON() ([]byte, error) {
+	return v.AppendBSON(nil)
+}
+
+// AppendBSON appends the BSON document for v to dst.
+func (v Address) AppendBSON(dst []byte) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	var err error
+	dst = bsoncore.AppendStringElement(dst, "street", string(v.Street))
+	if len(v.City) != 0 {
+		dst = bsoncore.AppendStringElement(dst, "city", string(v.City))
+	}
+	if dst, err = bsoncore.AppendDocumentEnd(dst, idx); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Address) UnmarshalBSON(data []byte) error {
+	elems, err := bsoncore.Document(data).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		name := elem.Key()
+		val := elem.Value()
+		key := name
+	lookup:
+		switch key {
+		case "street":
+			if s, ok := val.StringValueOK(); ok {
+				v.Street = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Street); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "street", err)
+			}
+		case "city":
+			if s, ok := val.StringValueOK(); ok {
+				v.City = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.City); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "city", err)
+			}
+		default:
+			// Keys without a struct tag are matched case-insensitively, like the default struct codec.
+			if lower := strings.ToLower(key); lower != key {
+				key = lower
+				goto lookup
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Counters) MarshalBSON() ([]byte, error) {
+	return v.AppendBSON(nil)
+}
+
+// AppendBSON appends the BSON document for v to dst.
+func (v Counters) AppendBSON(dst []byte) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	var err error
+	if v.Int >= math.MinInt32 && v.Int <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "int", int32(v.Int))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "int", int64(v.Int))
+	}
+	dst = bsoncore.AppendInt32Element(dst, "int8", int32(v.Int8))
+	dst = bsoncore.AppendInt32Element(dst, "int16", int32(v.Int16))
+	dst = bsoncore.AppendInt32Element(dst, "int32", int32(v.Int32))
+	dst = bsoncore.AppendInt64Element(dst, "int64", int64(v.Int64))
+	if v.MinInt64 >= math.MinInt32 && v.MinInt64 <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "minint64", int32(v.MinInt64))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "minint64", int64(v.MinInt64))
+	}
+	dst = bsoncore.AppendInt32Element(dst, "uint8", int32(v.Uint8))
+	dst = bsoncore.AppendInt32Element(dst, "uint16", int32(v.Uint16))
+	{
+		dst = bsoncore.AppendInt64Element(dst, "uint32", int64(v.Uint32))
+	}
+	if v.MinUint <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "minuint", int32(v.MinUint))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "minuint", int64(v.MinUint))
+	}
+	if uint64(v.Uint64) > math.MaxInt64 {
+		return nil, fmt.Errorf("%d overflows int64", v.Uint64)
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "uint64", int64(v.Uint64))
+	}
+	if v.Uint <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "uint", int32(v.Uint))
+	} else if uint64(v.Uint) > math.MaxInt64 {
+		return nil, fmt.Errorf("%d overflows int64", v.Uint)
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "uint", int64(v.Uint))
+	}
+	if v.Many == nil {
+		dst = bsoncore.AppendNullElement(dst, "many")
+	} else {
+		var idx18 int32
+		idx18, dst = bsoncore.AppendArrayElementStart(dst, "many")
+		for i19 := range v.Many {
+			if v.Many[i19] >= math.MinInt32 && v.Many[i19] <= math.MaxInt32 {
+				dst = bsoncore.AppendInt32Element(dst, strconv.Itoa(i19), int32(v.Many[i19]))
+			} else {
+				dst = bsoncore.AppendInt64Element(dst, strconv.Itoa(i19), int64(v.Many[i19]))
+			}
+		}
+		if dst, err = bsoncore.AppendArrayEnd(dst, idx18); err != nil {
+			return nil, err
+		}
+	}
+	if v.ByName == nil {
+		dst = bsoncore.AppendNullElement(dst, "byname")
+	} else {
+		var idx20 int32
+		idx20, dst = bsoncore.AppendDocumentElementStart(dst, "byname")
+		for k21, e22 := range v.ByName {
+			if e22 >= math.MinInt32 && e22 <= math.MaxInt32 {
+				dst = bsoncore.AppendInt32Element(dst, string(k21), int32(e22))
+			} else {
+				dst = bsoncore.AppendInt64Element(dst, string(k21), int64(e22))
+			}
+		}
+		if dst, err = bsoncore.AppendDocumentEnd(dst, idx20); err != nil {
+			return nil, err
+		}
+	}
+	if v.Ptr != nil {
+		if v.Ptr == nil {
+			dst = bsoncore.AppendNullElement(dst, "ptr")
+		} else {
+			dst = bsoncore.AppendInt64Element(dst, "ptr", int64((*v.Ptr)))
+		}
+	}
+	{
+		typ, data, err := bson.MarshalValueWithContext(bsoncodec.EncodeContext{Registry: bson.DefaultRegistry, MinSize: true}, v.Fixed)
+		if err != nil {
+			return nil, err
+		}
+		dst = bsoncore.AppendValueElement(dst, "fixed", bsoncore.Value{Type: typ, Data: data})
+	}
+	if dst, err = bsoncore.AppendDocumentEnd(dst, idx); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Counters) UnmarshalBSON(data []byte) error {
+	elems, err := bsoncore.Document(data).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		name := elem.Key()
+		val := elem.Value()
+		key := name
+	lookup:
+		switch key {
+		case "int":
+			if i32, ok := val.Int32OK(); ok {
+				v.Int = int(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int(i64)) == i64 {
+				v.Int = int(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Int); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "int", err)
+			}
+		case "int8":
+			if i32, ok := val.Int32OK(); ok && int32(int8(i32)) == i32 {
+				v.Int8 = int8(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int8(i64)) == i64 {
+				v.Int8 = int8(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Int8); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "int8", err)
+			}
+		case "int16":
+			if i32, ok := val.Int32OK(); ok && int32(int16(i32)) == i32 {
+				v.Int16 = int16(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int16(i64)) == i64 {
+				v.Int16 = int16(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Int16); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "int16", err)
+			}
+		case "int32":
+			if i32, ok := val.Int32OK(); ok {
+				v.Int32 = int32(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int32(i64)) == i64 {
+				v.Int32 = int32(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Int32); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "int32", err)
+			}
+		case "int64":
+			if i32, ok := val.Int32OK(); ok {
+				v.Int64 = int64(i32)
+			} else if i64, ok := val.Int64OK(); ok {
+				v.Int64 = int64(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Int64); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "int64", err)
+			}
+		case "minint64":
+			if i32, ok := val.Int32OK(); ok {
+				v.MinInt64 = int64(i32)
+			} else if i64, ok := val.Int64OK(); ok {
+				v.MinInt64 = int64(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.MinInt64); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "minint64", err)
+			}
+		case "uint8":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 && int32(uint8(i32)) == i32 {
+				v.Uint8 = uint8(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint8(i64)) == uint64(i64) {
+				v.Uint8 = uint8(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Uint8); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "uint8", err)
+			}
+		case "uint16":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 && int32(uint16(i32)) == i32 {
+				v.Uint16 = uint16(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint16(i64)) == uint64(i64) {
+				v.Uint16 = uint16(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Uint16); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "uint16", err)
+			}
+		case "uint32":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 {
+				v.Uint32 = uint32(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint32(i64)) == uint64(i64) {
+				v.Uint32 = uint32(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Uint32); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "uint32", err)
+			}
+		case "minuint":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 {
+				v.MinUint = uint32(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint32(i64)) == uint64(i64) {
+				v.MinUint = uint32(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.MinUint); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "minuint", err)
+			}
+		case "uint64":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 {
+				v.Uint64 = uint64(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint64(i64)) == uint64(i64) {
+				v.Uint64 = uint64(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Uint64); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "uint64", err)
+			}
+		case "uint":
+			if i32, ok := val.Int32OK(); ok && i32 >= 0 {
+				v.Uint = uint(i32)
+			} else if i64, ok := val.Int64OK(); ok && i64 >= 0 && uint64(uint(i64)) == uint64(i64) {
+				v.Uint = uint(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Uint); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "uint", err)
+			}
+		case "many":
+			if arr, ok := val.ArrayOK(); ok {
+				vals23, err := arr.Values()
+				if err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "many", err)
+				}
+				if v.Many == nil {
+					v.Many = make([]int64, 0, len(vals23))
+				}
+				v.Many = v.Many[:0]
+				for _, val24 := range vals23 {
+					var e25 int64
+					if i32, ok := val24.Int32OK(); ok {
+						e25 = int64(i32)
+					} else if i64, ok := val24.Int64OK(); ok {
+						e25 = int64(i64)
+					} else if err := (bson.RawValue{Type: val24.Type, Value: val24.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &e25); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "many", err)
+					}
+					v.Many = append(v.Many, e25)
+				}
+			} else if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Many = nil
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Many); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "many", err)
+			}
+		case "byname":
+			if doc, ok := val.DocumentOK(); ok {
+				elems26, err := doc.Elements()
+				if err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "byname", err)
+				}
+				if v.ByName == nil {
+					v.ByName = make(map[string]int64, len(elems26))
+				}
+				for _, elem27 := range elems26 {
+					val28 := elem27.Value()
+					var e29 int64
+					if i32, ok := val28.Int32OK(); ok {
+						e29 = int64(i32)
+					} else if i64, ok := val28.Int64OK(); ok {
+						e29 = int64(i64)
+					} else if err := (bson.RawValue{Type: val28.Type, Value: val28.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &e29); err != nil {
+						return fmt.Errorf("error decoding key %s: %v", "byname", err)
+					}
+					v.ByName[string(elem27.Key())] = e29
+				}
+			} else if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.ByName = nil
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.ByName); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "byname", err)
+			}
+		case "ptr":
+			if val.Type == bsontype.Null || val.Type == bsontype.Undefined {
+				v.Ptr = nil
+			} else {
+				if v.Ptr == nil {
+					v.Ptr = new(int64)
+				}
+				if i32, ok := val.Int32OK(); ok {
+					(*v.Ptr) = int64(i32)
+				} else if i64, ok := val.Int64OK(); ok {
+					(*v.Ptr) = int64(i64)
+				} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, v.Ptr); err != nil {
+					return fmt.Errorf("error decoding key %s: %v", "ptr", err)
+				}
+			}
+		case "fixed":
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Fixed); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "fixed", err)
+			}
+		default:
+			// Keys without a struct tag are matched case-insensitively, like the default struct codec.
+			if lower := strings.ToLower(key); lower != key {
+				key = lower
+				goto lookup
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (v Wrapper) MarshalBSON() ([]byte, error) {
+	return v.AppendBSON(nil)
+}
+
+// AppendBSON appends the BSON document for v to dst.
+func (v Wrapper) AppendBSON(dst []byte) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	var err error
+	dst = bsoncore.AppendStringElement(dst, "kind", string(v.Kind))
+	if v.Meta.Version >= math.MinInt32 && v.Meta.Version <= math.MaxInt32 {
+		dst = bsoncore.AppendInt32Element(dst, "version", int32(v.Meta.Version))
+	} else {
+		dst = bsoncore.AppendInt64Element(dst, "version", int64(v.Meta.Version))
+	}
+	if len(v.Meta.Owner) != 0 {
+		dst = bsoncore.AppendStringElement(dst, "owner", string(v.Meta.Owner))
+	}
+	if v.Audit != nil {
+		dst = bsoncore.AppendStringElement(dst, "by", string(v.Audit.By))
+	}
+	if v.Audit != nil {
+		dst = bsoncore.AppendTimeElement(dst, "at", v.Audit.At)
+	}
+	for k30, e31 := range v.Rest {
+		switch k30 {
+		case "kind", "version", "owner", "by", "at":
+			return nil, fmt.Errorf("Key %s of inlined map conflicts with a struct field name", k30)
+		}
+		if e31 == nil {
+			dst = bsoncore.AppendNullElement(dst, k30)
+		} else {
+			typ, data, err := bson.MarshalValueWithContext(bsoncodec.EncodeContext{Registry: bson.DefaultRegistry}, e31)
+			if err != nil {
+				return nil, err
+			}
+			dst = bsoncore.AppendValueElement(dst, k30, bsoncore.Value{Type: typ, Data: data})
+		}
+	}
+	if dst, err = bsoncore.AppendDocumentEnd(dst, idx); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (v *Wrapper) UnmarshalBSON(data []byte) error {
+	elems, err := bsoncore.Document(data).Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		name := elem.Key()
+		val := elem.Value()
+		key := name
+	lookup:
+		switch key {
+		case "kind":
+			if s, ok := val.StringValueOK(); ok {
+				v.Kind = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Kind); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "kind", err)
+			}
+		case "version":
+			if i32, ok := val.Int32OK(); ok {
+				v.Meta.Version = int(i32)
+			} else if i64, ok := val.Int64OK(); ok && int64(int(i64)) == i64 {
+				v.Meta.Version = int(i64)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Meta.Version); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "version", err)
+			}
+		case "owner":
+			if s, ok := val.StringValueOK(); ok {
+				v.Meta.Owner = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Meta.Owner); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "owner", err)
+			}
+		case "by":
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if s, ok := val.StringValueOK(); ok {
+				v.Audit.By = string(s)
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Audit.By); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "by", err)
+			}
+		case "at":
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if dt, ok := val.DateTimeOK(); ok {
+				v.Audit.At = primitive.DateTime(dt).Time().UTC()
+			} else if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry}, &v.Audit.At); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", "at", err)
+			}
+		default:
+			// Keys without a struct tag are matched case-insensitively, like the default struct codec.
+			if lower := strings.ToLower(key); lower != key {
+				key = lower
+				goto lookup
+			}
+			if v.Rest == nil {
+				v.Rest = make(map[string]interface{})
+			}
+			var e32 interface{}
+			if err := (bson.RawValue{Type: val.Type, Value: val.Data}).UnmarshalWithContext(&bsoncodec.DecodeContext{Registry: bson.DefaultRegistry, Ancestor: reflect.TypeOf(v.Rest)}, &e32); err != nil {
+				return fmt.Errorf("error decoding key %s: %v", name, err)
+			}
+			v.Rest[name] = e32
+		}
+	}
+	return nil
+}
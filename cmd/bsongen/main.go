@@ -0,0 +1,172 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// bsongen generates MarshalBSON and UnmarshalBSON methods for struct types. The generated methods use the bsoncore
+// package directly instead of reflection and produce the same BSON as bson.Marshal and bson.Unmarshal with the
+// default registry, including support for the omitempty, inline, minsize and truncate struct tag options.
+//
+// bsongen is intended to be used with go generate:
+//
+//	//go:generate go run go.mongodb.org/mongo-driver/cmd/bsongen -type Person,Address
+//
+// For each type T, bsongen writes the following methods to <t>_bson.go in the package directory, where <t> is the
+// lowercase name of the first type:
+//
+//	func (v T) MarshalBSON() ([]byte, error)
+//	func (v T) AppendBSON(dst []byte) ([]byte, error)
+//	func (v *T) UnmarshalBSON(data []byte) error
+//
+// Fields with types that bsongen does not handle directly, such as interfaces, arrays and types with their own BSON
+// marshaling methods, are encoded and decoded using bson.DefaultRegistry.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	var typeNames, output string
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "bsongen generates MarshalBSON and UnmarshalBSON methods for struct types.")
+		fmt.Fprintln(fs.Output(), "usage: bsongen -type T[,T...] [flags] [directory]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&typeNames, "type", "", "comma-separated list of type names; must be set")
+	fs.StringVar(&output, "output", "", "output file name; default <directory>/<type>_bson.go")
+	err := fs.Parse(os.Args[1:])
+	if err == flag.ErrHelp {
+		fs.Usage()
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Could not parse flags: %v", err)
+	}
+	if typeNames == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	directory := "."
+	if args := fs.Args(); len(args) > 0 {
+		directory = args[0]
+	}
+	names := strings.Split(typeNames, ",")
+	if output == "" {
+		output = strings.ToLower(names[0]) + "_bson.go"
+	}
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(directory, output)
+	}
+
+	pkg, err := loadPackage(directory, output)
+	if err != nil {
+		log.Fatalf("Could not load package: %v", err)
+	}
+
+	src, err := generate(pkg, names, "bsongen "+strings.Join(os.Args[1:], " "))
+	if err != nil {
+		log.Fatalf("Could not generate code: %v", err)
+	}
+	if err = ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatalf("Could not write output: %v", err)
+	}
+}
+
+// loadPackage type checks the package in directory from source. The output file is excluded so that a stale generated
+// file does not prevent the package from being loaded. Type errors that refer to the declarations of the output file
+// are the result of excluding it and are ignored; any other type error is returned.
+func loadPackage(directory, output string) (*types.Package, error) {
+	bpkg, err := build.ImportDir(directory, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(bpkg.GoFiles))
+	for _, name := range bpkg.GoFiles {
+		path := filepath.Join(directory, name)
+		if filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	generated := declaredNames(output)
+	var errs []string
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			if terr, ok := err.(types.Error); ok && mentionsAny(terr.Msg, generated) {
+				return
+			}
+			errs = append(errs, err.Error())
+		},
+	}
+	pkg, _ := conf.Check(bpkg.ImportPath, fset, files, nil)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("type checking failed:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return pkg, nil
+}
+
+// declaredNames returns the names of the top-level declarations and methods in the Go file at path. It returns nil if
+// the file does not exist, and the names that could be parsed if the file is not valid Go.
+func declaredNames(path string) map[string]bool {
+	file, _ := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if file == nil {
+		return nil
+	}
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			names[decl.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// mentionsAny reports whether msg contains any of names as an identifier.
+func mentionsAny(msg string, names map[string]bool) bool {
+	words := strings.FieldsFunc(msg, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	for _, word := range words {
+		if names[word] {
+			return true
+		}
+	}
+	return false
+}
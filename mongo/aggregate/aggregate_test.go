@@ -0,0 +1,132 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package aggregate
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func toExtJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	b, err := bson.MarshalExtJSON(bson.D{{"v", v}}, false, false)
+	assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+	return string(b)
+}
+
+func TestAggregate(t *testing.T) {
+	f := false
+	testCases := []struct {
+		name     string
+		stage    interface{}
+		expected string
+	}{
+		{
+			"group",
+			Group(Field("customer"),
+				bson.E{"total", Sum(Field("amount"))},
+				bson.E{"orders", CountDocuments()},
+				bson.E{"items", Push(Field("item"))},
+			),
+			`{"v":{"$group":{"_id":"$customer","total":{"$sum":"$amount"},"orders":{"$sum":1},"items":{"$push":"$item"}}}}`,
+		},
+		{
+			"lookup with pipeline",
+			Lookup(LookupSpec{
+				From:     "orders",
+				Let:      bson.D{{"id", Field("_id")}},
+				Pipeline: Pipeline(Match(bson.D{{"$expr", Eq(Field("customer"), Var("id"))}})),
+				As:       "orders",
+			}),
+			`{"v":{"$lookup":{"from":"orders","let":{"id":"$_id"},` +
+				`"pipeline":[{"$match":{"$expr":{"$eq":["$customer","$$id"]}}}],"as":"orders"}}}`,
+		},
+		{
+			"lookup with fields",
+			Lookup(LookupSpec{From: "orders", LocalField: "_id", ForeignField: "customer", As: "orders"}),
+			`{"v":{"$lookup":{"from":"orders","localField":"_id","foreignField":"customer","as":"orders"}}}`,
+		},
+		{
+			"unwind",
+			Unwind("$items"),
+			`{"v":{"$unwind":"$items"}}`,
+		},
+		{
+			"unwind with options",
+			UnwindWith(UnwindSpec{Path: "$items", IncludeArrayIndex: "idx", PreserveNullAndEmptyArrays: &f}),
+			`{"v":{"$unwind":{"path":"$items","includeArrayIndex":"idx","preserveNullAndEmptyArrays":false}}}`,
+		},
+		{
+			"facet",
+			Facet(
+				bson.E{"count", Pipeline(Count("n"))},
+				bson.E{"top", Pipeline(Sort(bson.D{{"score", -1}}), Limit(3))},
+			),
+			`{"v":{"$facet":{"count":[{"$count":"n"}],"top":[{"$sort":{"score":-1}},{"$limit":3}]}}}`,
+		},
+		{
+			"merge",
+			Merge(MergeSpec{Into: "totals", On: "_id", WhenMatched: "replace", WhenNotMatched: "insert"}),
+			`{"v":{"$merge":{"into":"totals","on":"_id","whenMatched":"replace","whenNotMatched":"insert"}}}`,
+		},
+		{
+			"set window fields",
+			SetWindowFields(SetWindowFieldsSpec{
+				PartitionBy: Field("state"),
+				SortBy:      bson.D{{"date", 1}},
+				Output: bson.D{
+					{"running", Window(Sum(Field("qty")), Documents("unbounded", "current"))},
+					{"recent", Window(Avg(Field("qty")), Range(-1, 0, "day"))},
+					{"total", Window(Sum(Field("qty")), nil)},
+				},
+			}),
+			`{"v":{"$setWindowFields":{"partitionBy":"$state","sortBy":{"date":1},"output":{` +
+				`"running":{"$sum":"$qty","window":{"documents":["unbounded","current"]}},` +
+				`"recent":{"$avg":"$qty","window":{"range":[-1,0],"unit":"day"}},` +
+				`"total":{"$sum":"$qty"}}}}}`,
+		},
+		{
+			"cond",
+			Cond(Gte(Field("qty"), 250), 30, 20),
+			`{"v":{"$cond":{"if":{"$gte":["$qty",250]},"then":30,"else":20}}}`,
+		},
+		{
+			"date trunc",
+			DateTrunc(DateTruncSpec{Date: Field("orderDate"), Unit: "week", BinSize: 2, StartOfWeek: "monday"}),
+			`{"v":{"$dateTrunc":{"date":"$orderDate","unit":"week","binSize":2,"startOfWeek":"monday"}}}`,
+		},
+		{
+			"filter",
+			Filter(Field("items"), "item", Gt(Var("item.price"), 100)),
+			`{"v":{"$filter":{"input":"$items","as":"item","cond":{"$gt":["$$item.price",100]}}}}`,
+		},
+		{
+			"union with",
+			UnionWith("archive", Pipeline(Project(bson.D{{"_id", 0}}))),
+			`{"v":{"$unionWith":{"coll":"archive","pipeline":[{"$project":{"_id":0}}]}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := toExtJSON(t, tc.stage)
+			assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+		})
+	}
+
+	t.Run("pipeline", func(t *testing.T) {
+		pipeline := Pipeline(Match(bson.D{{"status", "A"}}), Limit(5))
+		expected := mongo.Pipeline{
+			{{"$match", bson.D{{"status", "A"}}}},
+			{{"$limit", int64(5)}},
+		}
+		assert.Equal(t, expected, pipeline, "expected pipeline %v, got %v", expected, pipeline)
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package aggregate
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Field returns the expression that refers to the field at the given path, such as "$item.price".
+func Field(path string) string {
+	return "$" + path
+}
+
+// Var returns the expression that refers to the variable with the given name, such as "$$ROOT".
+func Var(name string) string {
+	return "$$" + name
+}
+
+// Literal returns a $literal expression, which evaluates to v without parsing it as an expression.
+func Literal(v interface{}) bson.D {
+	return bson.D{{"$literal", v}}
+}
+
+func operator(name string, arg interface{}) bson.D {
+	return bson.D{{name, arg}}
+}
+
+func operands(name string, args []interface{}) bson.D {
+	return bson.D{{name, bson.A(args)}}
+}
+
+// Accumulators. These can be used in the fields of a $group stage and as window operators in a
+// $setWindowFields stage.
+
+// Sum returns a $sum accumulator.
+func Sum(expr interface{}) bson.D { return operator("$sum", expr) }
+
+// Avg returns an $avg accumulator.
+func Avg(expr interface{}) bson.D { return operator("$avg", expr) }
+
+// Min returns a $min accumulator.
+func Min(expr interface{}) bson.D { return operator("$min", expr) }
+
+// Max returns a $max accumulator.
+func Max(expr interface{}) bson.D { return operator("$max", expr) }
+
+// First returns a $first accumulator.
+func First(expr interface{}) bson.D { return operator("$first", expr) }
+
+// Last returns a $last accumulator.
+func Last(expr interface{}) bson.D { return operator("$last", expr) }
+
+// Push returns a $push accumulator.
+func Push(expr interface{}) bson.D { return operator("$push", expr) }
+
+// AddToSet returns an $addToSet accumulator.
+func AddToSet(expr interface{}) bson.D { return operator("$addToSet", expr) }
+
+// StdDevPop returns a $stdDevPop accumulator.
+func StdDevPop(expr interface{}) bson.D { return operator("$stdDevPop", expr) }
+
+// StdDevSamp returns a $stdDevSamp accumulator.
+func StdDevSamp(expr interface{}) bson.D { return operator("$stdDevSamp", expr) }
+
+// CountDocuments returns a {$sum: 1} accumulator, which counts the documents in each group.
+func CountDocuments() bson.D { return operator("$sum", 1) }
+
+// Comparison, boolean, and conditional operators.
+
+// Eq returns an $eq expression.
+func Eq(a, b interface{}) bson.D { return operands("$eq", []interface{}{a, b}) }
+
+// Ne returns a $ne expression.
+func Ne(a, b interface{}) bson.D { return operands("$ne", []interface{}{a, b}) }
+
+// Gt returns a $gt expression.
+func Gt(a, b interface{}) bson.D { return operands("$gt", []interface{}{a, b}) }
+
+// Gte returns a $gte expression.
+func Gte(a, b interface{}) bson.D { return operands("$gte", []interface{}{a, b}) }
+
+// Lt returns a $lt expression.
+func Lt(a, b interface{}) bson.D { return operands("$lt", []interface{}{a, b}) }
+
+// Lte returns a $lte expression.
+func Lte(a, b interface{}) bson.D { return operands("$lte", []interface{}{a, b}) }
+
+// And returns an $and expression.
+func And(exprs ...interface{}) bson.D { return operands("$and", exprs) }
+
+// Or returns an $or expression.
+func Or(exprs ...interface{}) bson.D { return operands("$or", exprs) }
+
+// Not returns a $not expression.
+func Not(expr interface{}) bson.D { return operands("$not", []interface{}{expr}) }
+
+// In returns an $in expression, which is true if expr is an element of array.
+func In(expr, array interface{}) bson.D { return operands("$in", []interface{}{expr, array}) }
+
+// Cond returns a $cond expression that evaluates to then if cond is true and to els otherwise.
+func Cond(cond, then, els interface{}) bson.D {
+	return operator("$cond", bson.D{{"if", cond}, {"then", then}, {"else", els}})
+}
+
+// IfNull returns an $ifNull expression that evaluates to replacement if expr is null or missing.
+func IfNull(expr, replacement interface{}) bson.D {
+	return operands("$ifNull", []interface{}{expr, replacement})
+}
+
+// Arithmetic and string operators.
+
+// Add returns an $add expression.
+func Add(exprs ...interface{}) bson.D { return operands("$add", exprs) }
+
+// Subtract returns a $subtract expression.
+func Subtract(a, b interface{}) bson.D { return operands("$subtract", []interface{}{a, b}) }
+
+// Multiply returns a $multiply expression.
+func Multiply(exprs ...interface{}) bson.D { return operands("$multiply", exprs) }
+
+// Divide returns a $divide expression.
+func Divide(a, b interface{}) bson.D { return operands("$divide", []interface{}{a, b}) }
+
+// Concat returns a $concat expression.
+func Concat(exprs ...interface{}) bson.D { return operands("$concat", exprs) }
+
+// ToString returns a $toString expression.
+func ToString(expr interface{}) bson.D { return operator("$toString", expr) }
+
+// Array operators.
+
+// Size returns a $size expression.
+func Size(array interface{}) bson.D { return operator("$size", array) }
+
+// ArrayElemAt returns an $arrayElemAt expression.
+func ArrayElemAt(array, index interface{}) bson.D {
+	return operands("$arrayElemAt", []interface{}{array, index})
+}
+
+// Filter returns a $filter expression that selects the elements of input for which cond is true. Within cond, the
+// current element is referenced as "$$<as>". If as is empty, the element is referenced as "$$this".
+func Filter(input interface{}, as string, cond interface{}) bson.D {
+	spec := bson.D{{"input", input}}
+	if as != "" {
+		spec = append(spec, bson.E{"as", as})
+	}
+	return operator("$filter", append(spec, bson.E{"cond", cond}))
+}
+
+// Map returns a $map expression that applies in to each element of input. Within in, the current element is
+// referenced as "$$<as>". If as is empty, the element is referenced as "$$this".
+func Map(input interface{}, as string, in interface{}) bson.D {
+	spec := bson.D{{"input", input}}
+	if as != "" {
+		spec = append(spec, bson.E{"as", as})
+	}
+	return operator("$map", append(spec, bson.E{"in", in}))
+}
+
+// Date operators.
+
+// DateTruncSpec specifies a $dateTrunc expression.
+type DateTruncSpec struct {
+	// The date to truncate.
+	Date interface{} `bson:"date"`
+
+	// The unit of time, such as "hour", "day", or "week".
+	Unit string `bson:"unit"`
+
+	// The number of units in each bin. If this is nil, the server uses 1.
+	BinSize interface{} `bson:"binSize,omitempty"`
+
+	// The timezone used for the truncation, such as "America/New_York" or "+03:00".
+	Timezone string `bson:"timezone,omitempty"`
+
+	// The first day of the week when Unit is "week", such as "monday".
+	StartOfWeek string `bson:"startOfWeek,omitempty"`
+}
+
+// DateTrunc returns a $dateTrunc expression. This operator is only valid for MongoDB versions >= 5.0.
+func DateTrunc(spec DateTruncSpec) bson.D {
+	return operator("$dateTrunc", spec)
+}
+
+// DateToString returns a $dateToString expression that formats date with the given format string, such as
+// "%Y-%m-%d".
+func DateToString(date interface{}, format string) bson.D {
+	return operator("$dateToString", bson.D{{"format", format}, {"date", date}})
+}
@@ -0,0 +1,245 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package aggregate provides constructors for aggregation pipeline stages and expression operators. Each constructor
+// returns the bson.D for a single stage or expression, so the results can be combined freely with hand-written
+// documents:
+//
+//	pipeline := aggregate.Pipeline(
+//		aggregate.Match(bson.D{{"status", "A"}}),
+//		aggregate.Group(aggregate.Field("customer"),
+//			bson.E{"total", aggregate.Sum(aggregate.Field("amount"))},
+//		),
+//		aggregate.Sort(bson.D{{"total", -1}}),
+//	)
+//	cursor, err := coll.Aggregate(ctx, pipeline)
+//
+// Stages with several optional settings, such as $lookup and $merge, take a struct whose zero-valued fields are
+// omitted from the stage. See https://docs.mongodb.com/manual/reference/operator/aggregation-pipeline/ for more
+// information about the stages and operators.
+package aggregate // import "go.mongodb.org/mongo-driver/mongo/aggregate"
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pipeline combines stages into a mongo.Pipeline, which can be passed to Collection.Aggregate, Database.Aggregate, and
+// the Watch methods.
+func Pipeline(stages ...bson.D) mongo.Pipeline {
+	return mongo.Pipeline(stages)
+}
+
+// Match returns a $match stage that filters documents with the given query filter.
+func Match(filter interface{}) bson.D {
+	return bson.D{{"$match", filter}}
+}
+
+// Project returns a $project stage with the given projection document.
+func Project(projection interface{}) bson.D {
+	return bson.D{{"$project", projection}}
+}
+
+// AddFields returns an $addFields stage that adds or replaces the given fields.
+func AddFields(fields ...bson.E) bson.D {
+	return bson.D{{"$addFields", bson.D(fields)}}
+}
+
+// Set returns a $set stage that adds or replaces the given fields. It is an alias of $addFields.
+func Set(fields ...bson.E) bson.D {
+	return bson.D{{"$set", bson.D(fields)}}
+}
+
+// Unset returns an $unset stage that removes the given fields.
+func Unset(fields ...string) bson.D {
+	return bson.D{{"$unset", fields}}
+}
+
+// Group returns a $group stage that groups documents by id and computes the given fields, which are typically
+// accumulators such as Sum or Push. An id of nil groups all documents together.
+func Group(id interface{}, fields ...bson.E) bson.D {
+	return bson.D{{"$group", append(bson.D{{"_id", id}}, fields...)}}
+}
+
+// Sort returns a $sort stage with the given sort document, such as bson.D{{"x", 1}, {"y", -1}}.
+func Sort(sort interface{}) bson.D {
+	return bson.D{{"$sort", sort}}
+}
+
+// SortByCount returns a $sortByCount stage that groups documents by the given expression and sorts the groups by
+// their size in descending order.
+func SortByCount(expr interface{}) bson.D {
+	return bson.D{{"$sortByCount", expr}}
+}
+
+// Limit returns a $limit stage.
+func Limit(n int64) bson.D {
+	return bson.D{{"$limit", n}}
+}
+
+// Skip returns a $skip stage.
+func Skip(n int64) bson.D {
+	return bson.D{{"$skip", n}}
+}
+
+// Count returns a $count stage that outputs a single document with the number of input documents in the given field.
+func Count(field string) bson.D {
+	return bson.D{{"$count", field}}
+}
+
+// Sample returns a $sample stage that randomly selects the given number of documents.
+func Sample(size int64) bson.D {
+	return bson.D{{"$sample", bson.D{{"size", size}}}}
+}
+
+// ReplaceRoot returns a $replaceRoot stage that replaces each document with the result of the given expression.
+func ReplaceRoot(newRoot interface{}) bson.D {
+	return bson.D{{"$replaceRoot", bson.D{{"newRoot", newRoot}}}}
+}
+
+// ReplaceWith returns a $replaceWith stage that replaces each document with the result of the given expression.
+func ReplaceWith(expr interface{}) bson.D {
+	return bson.D{{"$replaceWith", expr}}
+}
+
+// Out returns an $out stage that writes the results of the pipeline to the given collection in the same database.
+func Out(collection string) bson.D {
+	return bson.D{{"$out", collection}}
+}
+
+// UnionWith returns a $unionWith stage that combines the results of the pipeline with the documents of the given
+// collection, after running them through the given pipeline. The pipeline can be nil.
+func UnionWith(collection string, pipeline mongo.Pipeline) bson.D {
+	if pipeline == nil {
+		return bson.D{{"$unionWith", collection}}
+	}
+	return bson.D{{"$unionWith", bson.D{{"coll", collection}, {"pipeline", pipeline}}}}
+}
+
+// Unwind returns an $unwind stage that outputs a document for each element of the array at the given field path, such
+// as "$items".
+func Unwind(path string) bson.D {
+	return bson.D{{"$unwind", path}}
+}
+
+// UnwindSpec specifies an $unwind stage with options.
+type UnwindSpec struct {
+	// The field path of the array, such as "$items".
+	Path string `bson:"path"`
+
+	// The name of a field that will hold the array index of the element.
+	IncludeArrayIndex string `bson:"includeArrayIndex,omitempty"`
+
+	// If true, documents whose array is null, missing, or empty are also output.
+	PreserveNullAndEmptyArrays *bool `bson:"preserveNullAndEmptyArrays,omitempty"`
+}
+
+// UnwindWith returns an $unwind stage with the given options.
+func UnwindWith(spec UnwindSpec) bson.D {
+	return bson.D{{"$unwind", spec}}
+}
+
+// LookupSpec specifies a $lookup stage. Either LocalField and ForeignField, Pipeline, or all three can be set.
+type LookupSpec struct {
+	// The collection in the same database to join with.
+	From string `bson:"from"`
+
+	// The field of the input documents to match against ForeignField.
+	LocalField string `bson:"localField,omitempty"`
+
+	// The field of the From documents to match against LocalField.
+	ForeignField string `bson:"foreignField,omitempty"`
+
+	// Variables for the Pipeline, mapping variable names to expressions on the input documents.
+	Let interface{} `bson:"let,omitempty"`
+
+	// A pipeline to run on the From documents. The variables in Let can be referenced as "$$<name>".
+	Pipeline mongo.Pipeline `bson:"pipeline,omitempty"`
+
+	// The name of the array field that will hold the joined documents.
+	As string `bson:"as"`
+}
+
+// Lookup returns a $lookup stage that performs a left outer join.
+func Lookup(spec LookupSpec) bson.D {
+	return bson.D{{"$lookup", spec}}
+}
+
+// Facet returns a $facet stage that runs several pipelines on the same input documents. Each field's value must be a
+// mongo.Pipeline, and its results are output as an array in that field.
+func Facet(facets ...bson.E) bson.D {
+	return bson.D{{"$facet", bson.D(facets)}}
+}
+
+// MergeSpec specifies a $merge stage.
+type MergeSpec struct {
+	// The output collection. This can be a collection name or a document in the form {db: <db>, coll: <collection>}.
+	Into interface{} `bson:"into"`
+
+	// The field or fields that identify matching documents in the output collection. This can be a string or an array
+	// of strings.
+	On interface{} `bson:"on,omitempty"`
+
+	// Variables for a WhenMatched pipeline.
+	Let interface{} `bson:"let,omitempty"`
+
+	// The action to take when a result matches a document in the output collection. This can be "replace",
+	// "keepExisting", "merge", "fail", or an update pipeline.
+	WhenMatched interface{} `bson:"whenMatched,omitempty"`
+
+	// The action to take when a result does not match a document in the output collection. This can be "insert",
+	// "discard", or "fail".
+	WhenNotMatched string `bson:"whenNotMatched,omitempty"`
+}
+
+// Merge returns a $merge stage that writes the results of the pipeline to a collection.
+func Merge(spec MergeSpec) bson.D {
+	return bson.D{{"$merge", spec}}
+}
+
+// SetWindowFieldsSpec specifies a $setWindowFields stage.
+type SetWindowFieldsSpec struct {
+	// An expression used to partition the documents. Windows do not span partitions.
+	PartitionBy interface{} `bson:"partitionBy,omitempty"`
+
+	// The sort order of the documents in each partition.
+	SortBy interface{} `bson:"sortBy,omitempty"`
+
+	// The fields to add, which are typically created with Window.
+	Output bson.D `bson:"output"`
+}
+
+// SetWindowFields returns a $setWindowFields stage that computes window functions over partitions of the documents.
+func SetWindowFields(spec SetWindowFieldsSpec) bson.D {
+	return bson.D{{"$setWindowFields", spec}}
+}
+
+// Window adds window bounds to a window operator, such as Sum or Avg, for use in the output of a $setWindowFields stage.
+// The bounds are typically created with Documents or Range. A nil bounds leaves the operator unchanged, which makes the
+// window span the whole partition.
+func Window(operator bson.D, bounds bson.D) bson.D {
+	if bounds == nil {
+		return operator
+	}
+	return append(append(bson.D{}, operator...), bson.E{"window", bounds})
+}
+
+// Documents returns window bounds measured in documents relative to the current document. Each bound is an integer
+// offset or one of the strings "current" and "unbounded".
+func Documents(lower, upper interface{}) bson.D {
+	return bson.D{{"documents", bson.A{lower, upper}}}
+}
+
+// Range returns window bounds measured in values of the sortBy field relative to the current document. Each bound is a
+// number or one of the strings "current" and "unbounded". If unit is not empty, the bounds are a time range in that
+// unit, such as "hour" or "day".
+func Range(lower, upper interface{}, unit string) bson.D {
+	bounds := bson.D{{"range", bson.A{lower, upper}}}
+	if unit != "" {
+		bounds = append(bounds, bson.E{"unit", unit})
+	}
+	return bounds
+}
@@ -0,0 +1,132 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNoFullDocument is returned by the ChangeEvent.FullDocumentAs and ChangeEvent.FullDocumentBeforeChangeAs methods
+// if the event does not include the requested document.
+var ErrNoFullDocument = errors.New("mongo: change event does not contain the requested document")
+
+// ChangeEvent represents an event returned by a change stream. A ChangeStream can decode the current event into this
+// type directly:
+//
+//	var event mongo.ChangeEvent
+//	err := cs.Decode(&event)
+//
+// Fields that do not apply to the event's operation type are left as their zero values. See
+// https://docs.mongodb.com/manual/reference/change-events/ for more information about change events.
+type ChangeEvent struct {
+	// The resume token for the event. This can be passed to the ResumeAfter or StartAfter change stream options to
+	// resume the stream after this event.
+	ID bson.Raw `bson:"_id"`
+
+	// The type of operation that caused the event, such as "insert", "update", "replace", "delete", "drop",
+	// "rename", "dropDatabase", or "invalidate".
+	OperationType string `bson:"operationType"`
+
+	// The namespace affected by the event. For "dropDatabase" events, only the Database field is set. This is empty for
+	// "invalidate" events.
+	Namespace ChangeEventNamespace `bson:"ns"`
+
+	// The new namespace of a renamed collection. This is only set for "rename" events.
+	To *ChangeEventNamespace `bson:"to"`
+
+	// A document containing the _id of the changed document and, for sharded collections, the shard key. This is only
+	// set for events that affect a single document.
+	DocumentKey bson.Raw `bson:"documentKey"`
+
+	// The fields that were changed by an update operation. This is only set for "update" events.
+	UpdateDescription *UpdateDescription `bson:"updateDescription"`
+
+	// The oplog timestamp of the operation that caused the event.
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+
+	// The server time at which the operation that caused the event was applied. This is only set by MongoDB versions
+	// >= 6.0.
+	WallTime time.Time `bson:"wallTime"`
+
+	// The transaction number and session ID of the transaction that contained the operation. These are only set if the
+	// operation was part of a multi-document transaction.
+	TxnNumber *int64   `bson:"txnNumber"`
+	LSID      bson.Raw `bson:"lsid"`
+
+	// The UUID of the collection affected by the event. This is only set if the ShowExpandedEvents change stream
+	// option is used.
+	CollectionUUID *primitive.Binary `bson:"collectionUUID"`
+
+	// Additional information about DDL operations, such as the index specifications for "createIndexes" events. This
+	// is only set if the ShowExpandedEvents change stream option is used.
+	OperationDescription bson.Raw `bson:"operationDescription"`
+
+	// The document created or replaced by the operation, or the current version of an updated document. See the
+	// FullDocument change stream option for which events include this document.
+	FullDocument bson.Raw `bson:"fullDocument"`
+
+	// The version of the document before it was changed. This is only set if the FullDocumentBeforeChange change stream
+	// option is used.
+	FullDocumentBeforeChange bson.Raw `bson:"fullDocumentBeforeChange"`
+}
+
+// ChangeEventNamespace represents the namespace affected by a change event.
+type ChangeEventNamespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll"`
+}
+
+// UpdateDescription represents the changes made by an update operation in a change event.
+type UpdateDescription struct {
+	// A document mapping the paths of updated fields to their new values.
+	UpdatedFields bson.Raw `bson:"updatedFields"`
+
+	// The paths of fields that were removed.
+	RemovedFields []string `bson:"removedFields"`
+
+	// The arrays that were truncated by the update. Elements past the new size of each array were removed, and any
+	// changes to the remaining elements are reported in UpdatedFields.
+	TruncatedArrays []TruncatedArray `bson:"truncatedArrays"`
+
+	// A document mapping ambiguous paths in UpdatedFields and RemovedFields, such as paths containing dots or numeric
+	// field names, to arrays of their path components. This is only set if the ShowExpandedEvents change stream option
+	// is used.
+	DisambiguatedPaths bson.Raw `bson:"disambiguatedPaths"`
+}
+
+// TruncatedArray represents an array that was shortened by an update operation.
+type TruncatedArray struct {
+	// The path of the array.
+	Field string `bson:"field"`
+
+	// The number of elements in the array after it was truncated.
+	NewSize int32 `bson:"newSize"`
+}
+
+// FullDocumentAs unmarshals the FullDocument field of the event into v. If the event does not include a full
+// document, ErrNoFullDocument is returned.
+func (ce *ChangeEvent) FullDocumentAs(v interface{}) error {
+	return unmarshalChangeEventDocument(ce.FullDocument, v)
+}
+
+// FullDocumentBeforeChangeAs unmarshals the FullDocumentBeforeChange field of the event into v. If the event does
+// not include a pre-image, ErrNoFullDocument is returned.
+func (ce *ChangeEvent) FullDocumentBeforeChangeAs(v interface{}) error {
+	return unmarshalChangeEventDocument(ce.FullDocumentBeforeChange, v)
+}
+
+func unmarshalChangeEventDocument(doc bson.Raw, v interface{}) error {
+	// Documents that are unavailable are reported as null, which unmarshals into an empty bson.Raw.
+	if len(doc) == 0 {
+		return ErrNoFullDocument
+	}
+	return bson.Unmarshal(doc, v)
+}
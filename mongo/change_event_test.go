@@ -0,0 +1,168 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+const changeStreamsTestsDir = "../data/change-streams"
+
+func TestChangeEvent(t *testing.T) {
+	for _, dir := range []string{"legacy", "unified"} {
+		for _, file := range jsonFilesInDir(t, path.Join(changeStreamsTestsDir, dir)) {
+			t.Run(path.Join(dir, file), func(t *testing.T) {
+				for _, event := range changeEventsFromSpecFile(t, path.Join(changeStreamsTestsDir, dir, file)) {
+					runChangeEventTest(t, event)
+				}
+			})
+		}
+	}
+	t.Run("unavailable full documents", func(t *testing.T) {
+		b, err := bson.Marshal(bson.D{
+			{"operationType", "delete"},
+			{"ns", bson.D{{"db", "db"}, {"coll", "coll"}}},
+			{"documentKey", bson.D{{"_id", 1}}},
+			{"fullDocument", nil},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var event ChangeEvent
+		err = bson.Unmarshal(b, &event)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		var doc bson.D
+		err = event.FullDocumentAs(&doc)
+		assert.Equal(t, ErrNoFullDocument, err, "expected error %v, got %v", ErrNoFullDocument, err)
+		err = event.FullDocumentBeforeChangeAs(&doc)
+		assert.Equal(t, ErrNoFullDocument, err, "expected error %v, got %v", ErrNoFullDocument, err)
+	})
+	t.Run("pre- and post-images", func(t *testing.T) {
+		b, err := bson.Marshal(bson.D{
+			{"operationType", "update"},
+			{"fullDocument", bson.D{{"x", int32(2)}}},
+			{"fullDocumentBeforeChange", bson.D{{"x", int32(1)}}},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var event ChangeEvent
+		err = bson.Unmarshal(b, &event)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+
+		var before, after struct{ X int32 }
+		err = event.FullDocumentBeforeChangeAs(&before)
+		assert.Nil(t, err, "FullDocumentBeforeChangeAs error: %v", err)
+		err = event.FullDocumentAs(&after)
+		assert.Nil(t, err, "FullDocumentAs error: %v", err)
+		assert.Equal(t, int32(1), before.X, "expected pre-image x to be 1, got %v", before.X)
+		assert.Equal(t, int32(2), after.X, "expected post-image x to be 2, got %v", after.X)
+	})
+}
+
+// changeEventsFromSpecFile returns the expected change events in a legacy or unified change streams spec test file.
+func changeEventsFromSpecFile(t *testing.T, filePath string) []bson.Raw {
+	t.Helper()
+
+	content, err := ioutil.ReadFile(filePath)
+	assert.Nil(t, err, "ReadFile error for %v: %v", filePath, err)
+
+	var testFile struct {
+		Tests []struct {
+			Result *struct {
+				Success []bson.Raw `bson:"success"`
+			} `bson:"result"`
+			Operations []struct {
+				Name         string        `bson:"name"`
+				ExpectResult bson.RawValue `bson:"expectResult"`
+			} `bson:"operations"`
+		} `bson:"tests"`
+	}
+	err = bson.UnmarshalExtJSON(content, false, &testFile)
+	assert.Nil(t, err, "UnmarshalExtJSON error: %v", err)
+
+	var events []bson.Raw
+	for _, test := range testFile.Tests {
+		if test.Result != nil {
+			events = append(events, test.Result.Success...)
+		}
+		for _, op := range test.Operations {
+			if op.Name == "iterateUntilDocumentOrError" {
+				events = append(events, op.ExpectResult.Document())
+			}
+		}
+	}
+	return events
+}
+
+func runChangeEventTest(t *testing.T, expected bson.Raw) {
+	t.Helper()
+
+	var event ChangeEvent
+	err := bson.Unmarshal(expected, &event)
+	assert.Nil(t, err, "Unmarshal error for event %v: %v", expected, err)
+
+	opType := expected.Lookup("operationType").StringValue()
+	assert.Equal(t, opType, event.OperationType, "expected operationType %v, got %v", opType, event.OperationType)
+
+	if ns, err := expected.LookupErr("ns"); err == nil {
+		assertChangeEventNamespace(t, ns.Document(), &event.Namespace)
+	}
+	if to, err := expected.LookupErr("to"); err == nil {
+		assert.NotNil(t, event.To, "expected To to be set for event %v", expected)
+		assertChangeEventNamespace(t, to.Document(), event.To)
+	} else {
+		assert.Nil(t, event.To, "expected To to be nil for event %v, got %v", expected, event.To)
+	}
+	if fullDoc, err := expected.LookupErr("fullDocument"); err == nil {
+		var got bson.Raw
+		err = event.FullDocumentAs(&got)
+		assert.Nil(t, err, "FullDocumentAs error: %v", err)
+		assert.Equal(t, fullDoc.Document(), got, "expected full document %v, got %v", fullDoc, got)
+	}
+
+	updateDesc, err := expected.LookupErr("updateDescription")
+	if err != nil {
+		assert.Nil(t, event.UpdateDescription, "expected UpdateDescription to be nil for event %v", expected)
+		return
+	}
+	assert.NotNil(t, event.UpdateDescription, "expected UpdateDescription to be set for event %v", expected)
+	got := event.UpdateDescription
+	if updated, err := updateDesc.Document().LookupErr("updatedFields"); err == nil {
+		assert.Equal(t, updated.Document(), got.UpdatedFields, "expected updated fields %v, got %v", updated,
+			got.UpdatedFields)
+	}
+	if truncated, err := updateDesc.Document().LookupErr("truncatedArrays"); err == nil {
+		values, err := truncated.Array().Values()
+		assert.Nil(t, err, "error reading truncatedArrays: %v", err)
+		assert.Equal(t, len(values), len(got.TruncatedArrays), "expected %v truncated arrays, got %v",
+			len(values), len(got.TruncatedArrays))
+		for i, val := range values {
+			field := val.Document().Lookup("field").StringValue()
+			newSize := val.Document().Lookup("newSize").Int32()
+			assert.Equal(t, field, got.TruncatedArrays[i].Field, "expected field %v, got %v", field,
+				got.TruncatedArrays[i].Field)
+			assert.Equal(t, newSize, got.TruncatedArrays[i].NewSize, "expected newSize %v, got %v", newSize,
+				got.TruncatedArrays[i].NewSize)
+		}
+	}
+}
+
+func assertChangeEventNamespace(t *testing.T, expected bson.Raw, got *ChangeEventNamespace) {
+	t.Helper()
+
+	db := expected.Lookup("db").StringValue()
+	assert.Equal(t, db, got.Database, "expected database %v, got %v", db, got.Database)
+	if coll, err := expected.LookupErr("coll"); err == nil {
+		assert.Equal(t, coll.StringValue(), got.Collection, "expected collection %v, got %v", coll,
+			got.Collection)
+	}
+}
@@ -7,6 +7,7 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -84,6 +85,17 @@ type ChangeStream struct {
 	selector      description.ServerSelector
 	operationTime *primitive.Timestamp
 	wireVersion   *description.VersionRange
+
+	// The fragments of a split event that have been received so far.
+	fragments []bsoncore.Document
+
+	// State for saving resume tokens to options.ResumeTokenStore. eventPending is true if an event has been returned
+	// that the application may still be processing, and uncheckpointed counts the processed events whose resume tokens
+	// have not been saved.
+	eventPending   bool
+	uncheckpointed int32
+	lastCheckpoint time.Time
+	savedToken     bson.Raw
 }
 
 type changeStreamConfig struct {
@@ -95,6 +107,7 @@ type changeStreamConfig struct {
 	collectionName string
 	databaseName   string
 	crypt          driver.Crypt
+	timeout        *time.Duration
 }
 
 func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline interface{},
@@ -114,6 +127,7 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		}),
 		cursorOptions: config.client.createBaseCursorOptions(),
 	}
+	cs.cursorOptions.Timeout = config.timeout
 
 	cs.sess = sessionFromContext(ctx)
 	if cs.sess == nil && cs.client.sessionPool != nil {
@@ -131,11 +145,14 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		ReadPreference(config.readPreference).ReadConcern(config.readConcern).
 		Deployment(cs.client.deployment).ClusterClock(cs.client.clock).
 		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
-		ServerAPI(cs.client.serverAPI).Crypt(config.crypt)
+		ServerAPI(cs.client.serverAPI).Crypt(config.crypt).Timeout(config.timeout)
 
 	if cs.options.Collation != nil {
 		cs.aggregate.Collation(bsoncore.Document(cs.options.Collation.ToDocument()))
 	}
+	if cs.options.Comment != nil {
+		cs.aggregate.Comment(*cs.options.Comment)
+	}
 	if cs.options.BatchSize != nil {
 		cs.aggregate.BatchSize(*cs.options.BatchSize)
 		cs.cursorOptions.BatchSize = *cs.options.BatchSize
@@ -156,6 +173,23 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		return nil, fmt.Errorf("must supply a valid StreamType in config, instead of %v", cs.streamType)
 	}
 
+	// If a resume token store is set and the application has not chosen a starting point, start after the stored
+	// token.
+	if store := cs.options.ResumeTokenStore; store != nil && cs.options.ResumeAfter == nil &&
+		cs.options.StartAfter == nil && cs.options.StartAtOperationTime == nil {
+
+		var token bson.Raw
+		if token, cs.err = store.Load(ctx); cs.err != nil {
+			closeImplicitSession(cs.sess)
+			return nil, cs.Err()
+		}
+		if token != nil {
+			cs.options.SetStartAfter(token)
+			cs.savedToken = token
+		}
+	}
+	cs.lastCheckpoint = time.Now()
+
 	// When starting a change stream, cache startAfter as the first resume token if it is set. If not, cache
 	// resumeAfter. If neither is set, do not cache a resume token.
 	resumeToken := cs.options.StartAfter
@@ -212,6 +246,9 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	cs.aggregate.Deployment(cs.createOperationDeployment(server, conn))
 
 	if resuming {
+		// The cached resume token is not advanced while a split event is buffered, so the new stream starts before
+		// the first fragment and the buffered fragments will be received again.
+		cs.fragments = nil
 		cs.replaceOptions(cs.wireVersion)
 
 		csOptDoc := cs.createPipelineOptionsDoc()
@@ -292,8 +329,9 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 
 // Updates the post batch resume token after a successful aggregate or getMore operation.
 func (cs *ChangeStream) updatePbrtFromCommand() {
-	// Only cache the pbrt if an empty batch was returned and a pbrt was included
-	if pbrt := cs.cursor.PostBatchResumeToken(); cs.emptyBatch() && pbrt != nil {
+	// Only cache the pbrt if an empty batch was returned and a pbrt was included. The pbrt is not cached while a split
+	// event is buffered so that resuming restarts from the first fragment.
+	if pbrt := cs.cursor.PostBatchResumeToken(); cs.emptyBatch() && pbrt != nil && len(cs.fragments) == 0 {
 		cs.resumeToken = bson.Raw(pbrt)
 	}
 }
@@ -352,6 +390,13 @@ func (cs *ChangeStream) buildPipelineSlice(pipeline interface{}) error {
 		cs.pipelineSlice = append(cs.pipelineSlice, elem)
 	}
 
+	// The split stage must be the last stage of the pipeline.
+	if split := cs.options.SplitLargeEvents; split != nil && *split {
+		cs.pipelineSlice = append(cs.pipelineSlice, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "$changeStreamSplitLargeEvent", bsoncore.BuildDocument(nil)),
+		))
+	}
+
 	return cs.err
 }
 
@@ -366,6 +411,11 @@ func (cs *ChangeStream) createPipelineOptionsDoc() bsoncore.Document {
 		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocument", string(*cs.options.FullDocument))
 	}
 
+	if cs.options.FullDocumentBeforeChange != nil {
+		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange",
+			string(*cs.options.FullDocumentBeforeChange))
+	}
+
 	if cs.options.ResumeAfter != nil {
 		var raDoc bsoncore.Document
 		raDoc, cs.err = transformBsoncoreDocument(cs.registry, cs.options.ResumeAfter, true, "resumeAfter")
@@ -386,6 +436,10 @@ func (cs *ChangeStream) createPipelineOptionsDoc() bsoncore.Document {
 		plDoc = bsoncore.AppendDocumentElement(plDoc, "startAfter", saDoc)
 	}
 
+	if cs.options.ShowExpandedEvents != nil {
+		plDoc = bsoncore.AppendBooleanElement(plDoc, "showExpandedEvents", *cs.options.ShowExpandedEvents)
+	}
+
 	if cs.options.StartAtOperationTime != nil {
 		plDoc = bsoncore.AppendTimestampElement(plDoc, "startAtOperationTime", cs.options.StartAtOperationTime.T, cs.options.StartAtOperationTime.I)
 	}
@@ -491,6 +545,50 @@ func (cs *ChangeStream) ResumeToken() bson.Raw {
 	return cs.resumeToken
 }
 
+// Checkpoint saves the current resume token to the ResumeTokenStore set in the options used to create the change
+// stream. The saved token covers every event returned by Next or TryNext so far, so Checkpoint should only be called
+// once the application has finished processing those events. If no ResumeTokenStore was set or the token has not
+// changed since it was last saved, Checkpoint does nothing.
+func (cs *ChangeStream) Checkpoint(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if cs.options == nil || cs.options.ResumeTokenStore == nil || cs.resumeToken == nil {
+		return nil
+	}
+
+	if !bytes.Equal(cs.resumeToken, cs.savedToken) {
+		if err := cs.options.ResumeTokenStore.Save(ctx, cs.resumeToken); err != nil {
+			return err
+		}
+		// The resume token may reference the cursor's batch, so a copy is kept.
+		cs.savedToken = append(bson.Raw(nil), cs.resumeToken...)
+	}
+	cs.eventPending = false
+	cs.uncheckpointed = 0
+	cs.lastCheckpoint = time.Now()
+	return nil
+}
+
+// checkpointIfDue saves the resume token if the number of processed events or the time since the last save has
+// reached the limits set by the CheckpointEvery and CheckpointInterval options.
+func (cs *ChangeStream) checkpointIfDue(ctx context.Context) error {
+	if cs.options == nil || cs.options.ResumeTokenStore == nil || cs.uncheckpointed == 0 {
+		return nil
+	}
+
+	every, interval := cs.options.CheckpointEvery, cs.options.CheckpointInterval
+	switch {
+	case every == nil && interval == nil:
+		return cs.Checkpoint(ctx)
+	case every != nil && cs.uncheckpointed >= *every:
+		return cs.Checkpoint(ctx)
+	case interval != nil && time.Since(cs.lastCheckpoint) >= *interval:
+		return cs.Checkpoint(ctx)
+	}
+	return nil
+}
+
 // Next gets the next event for this change stream. It returns true if there were no errors and the next event document
 // is available.
 //
@@ -527,26 +625,102 @@ func (cs *ChangeStream) next(ctx context.Context, nonBlocking bool) bool {
 		ctx = context.Background()
 	}
 
-	if len(cs.batch) == 0 {
-		cs.loopNext(ctx, nonBlocking)
-		if cs.err != nil {
-			cs.err = replaceErrors(cs.err)
-			return false
-		}
+	// Asking for the next event means the application has finished processing the previous one.
+	if cs.eventPending {
+		cs.eventPending = false
+		cs.uncheckpointed++
+	}
+	if cs.err = cs.checkpointIfDue(ctx); cs.err != nil {
+		return false
+	}
+
+	for {
 		if len(cs.batch) == 0 {
+			cs.loopNext(ctx, nonBlocking)
+			if cs.err != nil {
+				cs.err = replaceErrors(cs.err)
+				return false
+			}
+			if len(cs.batch) == 0 {
+				return false
+			}
+		}
+
+		// successfully got non-empty batch
+		doc := cs.batch[0]
+		cs.batch = cs.batch[1:]
+		if _, err := doc.LookupErr("splitEvent"); err != nil {
+			cs.Current = bson.Raw(doc)
+			break
+		}
+
+		var event bsoncore.Document
+		if event, cs.err = cs.addFragment(doc); cs.err != nil {
 			return false
 		}
+		if event != nil {
+			cs.Current = bson.Raw(event)
+			break
+		}
 	}
 
-	// successfully got non-empty batch
-	cs.Current = bson.Raw(cs.batch[0])
-	cs.batch = cs.batch[1:]
 	if cs.err = cs.storeResumeToken(); cs.err != nil {
 		return false
 	}
+	cs.eventPending = true
 	return true
 }
 
+// addFragment buffers a fragment of a split event. Once the last fragment has been received, the fragments are
+// merged into a single event, which is returned. The merged event has the _id of the last fragment, which is the resume
+// token for the whole event.
+func (cs *ChangeStream) addFragment(doc bsoncore.Document) (bsoncore.Document, error) {
+	split, ok := doc.Lookup("splitEvent").DocumentOK()
+	if !ok {
+		return nil, errors.New("splitEvent field in change event is not a document")
+	}
+	fragment, ok := split.Lookup("fragment").AsInt64OK()
+	if !ok {
+		return nil, errors.New("splitEvent document is missing the fragment number")
+	}
+	of, ok := split.Lookup("of").AsInt64OK()
+	if !ok {
+		return nil, errors.New("splitEvent document is missing the number of fragments")
+	}
+	if fragment != int64(len(cs.fragments))+1 || fragment > of {
+		return nil, fmt.Errorf("expected fragment %d of split event, got fragment %d of %d",
+			len(cs.fragments)+1, fragment, of)
+	}
+
+	// The batch may be reused by the cursor, so fragments are copied.
+	cs.fragments = append(cs.fragments, append(bsoncore.Document(nil), doc...))
+	if fragment < of {
+		return nil, nil
+	}
+
+	id, err := doc.LookupErr("_id")
+	if err != nil {
+		return nil, ErrMissingResumeToken
+	}
+
+	idx, event := bsoncore.AppendDocumentStart(nil)
+	event = bsoncore.AppendValueElement(event, "_id", id)
+	for _, frag := range cs.fragments {
+		elems, err := frag.Elements()
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range elems {
+			if key := elem.Key(); key == "_id" || key == "splitEvent" {
+				continue
+			}
+			event = append(event, elem...)
+		}
+	}
+	cs.fragments = nil
+	return bsoncore.AppendDocumentEnd(event, idx)
+}
+
 func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 	for {
 		if cs.cursor == nil {
@@ -569,6 +743,11 @@ func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 			// If a getMore was done but the batch was empty, the batch cursor will return false with no error.
 			// Update the tracked resume token to catch the post batch resume token from the server response.
 			cs.updatePbrtFromCommand()
+			if pbrt := cs.options.CheckpointPostBatchResumeToken; pbrt != nil && *pbrt {
+				if cs.err = cs.Checkpoint(ctx); cs.err != nil {
+					return
+				}
+			}
 			if nonBlocking {
 				// stop after a successful getMore, even though the batch was empty
 				return
@@ -7,9 +7,18 @@
 package mongo
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 func TestChangeStream(t *testing.T) {
@@ -26,4 +35,210 @@ func TestChangeStream(t *testing.T) {
 		err = cs.Close(bgCtx)
 		assert.Nil(t, err, "Close error: %v", err)
 	})
+	t.Run("aggregate command", func(t *testing.T) {
+		opts := options.ChangeStream().SetFullDocument(options.WhenAvailable).
+			SetFullDocumentBeforeChange(options.Required).SetShowExpandedEvents(true).SetComment("cdc")
+		cmd := watchCommand(t, opts)
+
+		comment, err := cmd.LookupErr("comment")
+		assert.Nil(t, err, "expected comment in command %v", cmd)
+		assert.Equal(t, "cdc", comment.StringValue(), "expected comment cdc, got %v", comment)
+
+		stage, err := cmd.LookupErr("pipeline", "0", "$changeStream")
+		assert.Nil(t, err, "expected $changeStream stage in command %v", cmd)
+		expected := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendBooleanElement(nil, "allChangesForCluster", true),
+			bsoncore.AppendStringElement(nil, "fullDocument", "whenAvailable"),
+			bsoncore.AppendStringElement(nil, "fullDocumentBeforeChange", "required"),
+			bsoncore.AppendBooleanElement(nil, "showExpandedEvents", true),
+		))
+		assert.Equal(t, expected, stage.Document(), "expected $changeStream stage %v, got %v", expected,
+			stage.Document())
+	})
+	t.Run("checkpointing", func(t *testing.T) {
+		token := func(n int32) bson.Raw {
+			b, err := bson.Marshal(bson.D{{"_data", n}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+		// process simulates the application processing an event and asking for the next one.
+		process := func(cs *ChangeStream, n int32) {
+			cs.resumeToken = token(n)
+			cs.uncheckpointed++
+			err := cs.checkpointIfDue(bgCtx)
+			assert.Nil(t, err, "checkpointIfDue error: %v", err)
+		}
+		newChangeStream := func(opts *options.ChangeStreamOptions) (*ChangeStream, *memoryResumeTokenStore) {
+			store := &memoryResumeTokenStore{}
+			return &ChangeStream{
+				options:        options.MergeChangeStreamOptions(opts.SetResumeTokenStore(store)),
+				lastCheckpoint: time.Now(),
+			}, store
+		}
+
+		t.Run("every event by default", func(t *testing.T) {
+			cs, store := newChangeStream(options.ChangeStream())
+			process(cs, 1)
+			process(cs, 2)
+			assert.Equal(t, []bson.Raw{token(1), token(2)}, store.saved, "expected two saved tokens, got %v",
+				store.saved)
+		})
+		t.Run("every n events", func(t *testing.T) {
+			cs, store := newChangeStream(options.ChangeStream().SetCheckpointEvery(3))
+			for i := int32(1); i <= 7; i++ {
+				process(cs, i)
+			}
+			assert.Equal(t, []bson.Raw{token(3), token(6)}, store.saved, "expected tokens 3 and 6, got %v",
+				store.saved)
+		})
+		t.Run("interval", func(t *testing.T) {
+			cs, store := newChangeStream(options.ChangeStream().SetCheckpointInterval(time.Hour))
+			process(cs, 1)
+			assert.Equal(t, 0, len(store.saved), "expected no saved tokens, got %v", store.saved)
+
+			cs.lastCheckpoint = time.Now().Add(-2 * time.Hour)
+			process(cs, 2)
+			assert.Equal(t, []bson.Raw{token(2)}, store.saved, "expected token 2, got %v", store.saved)
+		})
+		t.Run("unchanged token", func(t *testing.T) {
+			cs, store := newChangeStream(options.ChangeStream())
+			cs.resumeToken = token(1)
+			for i := 0; i < 2; i++ {
+				err := cs.Checkpoint(bgCtx)
+				assert.Nil(t, err, "Checkpoint error: %v", err)
+			}
+			assert.Equal(t, []bson.Raw{token(1)}, store.saved, "expected token 1 once, got %v", store.saved)
+		})
+	})
+	t.Run("split events", func(t *testing.T) {
+		fragment := func(n, of int32, fields ...bson.E) bsoncore.Document {
+			doc := bson.D{
+				{"_id", bson.D{{"_data", n}}},
+				{"splitEvent", bson.D{{"fragment", n}, {"of", of}}},
+			}
+			b, err := bson.Marshal(append(doc, fields...))
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+		newSplitStream := func(batches ...[]bsoncore.Document) *ChangeStream {
+			return &ChangeStream{
+				cursor:   &testChangeStreamCursor{batches: batches},
+				registry: bson.DefaultRegistry,
+				options:  options.MergeChangeStreamOptions(options.ChangeStream().SetSplitLargeEvents(true)),
+			}
+		}
+
+		t.Run("reassembled across batches", func(t *testing.T) {
+			cs := newSplitStream(
+				[]bsoncore.Document{
+					fragment(1, 3, bson.E{"operationType", "update"}),
+					fragment(2, 3, bson.E{"fullDocumentBeforeChange", bson.D{{"x", "before"}}}),
+				},
+				[]bsoncore.Document{fragment(3, 3, bson.E{"fullDocument", bson.D{{"x", "after"}}})},
+			)
+
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false; error: %v", cs.Err())
+			var event ChangeEvent
+			err := cs.Decode(&event)
+			assert.Nil(t, err, "Decode error: %v", err)
+			assert.Equal(t, "update", event.OperationType, "expected operationType update, got %v",
+				event.OperationType)
+			before := event.FullDocumentBeforeChange.Lookup("x").StringValue()
+			assert.Equal(t, "before", before, "expected pre-image x to be before, got %v", before)
+			after := event.FullDocument.Lookup("x").StringValue()
+			assert.Equal(t, "after", after, "expected post-image x to be after, got %v", after)
+			_, err = cs.Current.LookupErr("splitEvent")
+			assert.NotNil(t, err, "expected no splitEvent field in reassembled event %v", cs.Current)
+
+			expectedToken := bson.Raw(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "_data", 3)))
+			assert.Equal(t, expectedToken, cs.ResumeToken(), "expected resume token %v, got %v", expectedToken,
+				cs.ResumeToken())
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		})
+		t.Run("out of order fragment", func(t *testing.T) {
+			cs := newSplitStream([]bsoncore.Document{fragment(2, 2)})
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+			assert.NotNil(t, cs.Err(), "expected error for out of order fragment, got nil")
+		})
+	})
+}
+
+// testChangeStreamCursor is a changeStreamCursor that returns a fixed list of batches and is exhausted afterwards.
+type testChangeStreamCursor struct {
+	batches [][]bsoncore.Document
+	batch   *bsoncore.DocumentSequence
+}
+
+func (c *testChangeStreamCursor) ID() int64 {
+	if len(c.batches) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (c *testChangeStreamCursor) Next(context.Context) bool {
+	if len(c.batches) == 0 {
+		return false
+	}
+	var data []byte
+	for _, doc := range c.batches[0] {
+		data = append(data, doc...)
+	}
+	c.batch = &bsoncore.DocumentSequence{Style: bsoncore.SequenceStyle, Data: data}
+	c.batches = c.batches[1:]
+	return true
+}
+
+func (c *testChangeStreamCursor) Batch() *bsoncore.DocumentSequence       { return c.batch }
+func (c *testChangeStreamCursor) Server() driver.Server                   { return nil }
+func (c *testChangeStreamCursor) Err() error                              { return nil }
+func (c *testChangeStreamCursor) Close(context.Context) error             { return nil }
+func (c *testChangeStreamCursor) PostBatchResumeToken() bsoncore.Document { return nil }
+func (c *testChangeStreamCursor) KillCursor(context.Context) error        { return nil }
+
+// watchCommand opens a client change stream with the given options against a single fake connection and returns
+// the aggregate command that was sent.
+func watchCommand(t *testing.T, opts *options.ChangeStreamOptions) bsoncore.Document {
+	t.Helper()
+	reply, err := bson.Marshal(bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{{"id", int64(0)}, {"ns", "admin.$cmd.aggregate"}, {"firstBatch", bson.A{}}}},
+	})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	conn := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 1),
+		ReadResp: make(chan []byte, 1),
+		Desc:     description.Server{WireVersion: &description.VersionRange{Max: 13}},
+	}
+	conn.ReadResp <- drivertest.MakeReply(reply)
+	clientOpts := options.Client()
+	clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+	client, err := NewClient(clientOpts)
+	assert.Nil(t, err, "NewClient error: %v", err)
+
+	client.sessionPool = &session.Pool{}
+
+	cs, err := client.Watch(bgCtx, Pipeline{}, opts)
+	assert.Nil(t, err, "Watch error: %v", err)
+	defer cs.Close(bgCtx)
+
+	cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+	assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+	return cmd
+}
+
+type memoryResumeTokenStore struct {
+	saved []bson.Raw
+}
+
+func (m *memoryResumeTokenStore) Load(context.Context) (bson.Raw, error) {
+	if len(m.saved) == 0 {
+		return nil, nil
+	}
+	return m.saved[len(m.saved)-1], nil
+}
+
+func (m *memoryResumeTokenStore) Save(_ context.Context, token bson.Raw) error {
+	m.saved = append(m.saved, token)
+	return nil
 }
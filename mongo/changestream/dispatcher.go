@@ -0,0 +1,421 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package changestream provides helpers for processing the events of a mongo.ChangeStream.
+//
+// The main type defined in this package is Dispatcher. A Dispatcher reads events from a change stream and routes them
+// to handlers registered for a namespace and a set of operation types. Handlers run concurrently on a pool of
+// goroutines, but events for the same document, as identified by the documentKey field, are always handled one at a
+// time in the order they were received. Events that do not refer to a document, such as "drop" and "rename" events,
+// are handled once every earlier event has been handled and before any later event is handled.
+//
+// The Dispatcher tracks which events have been handled and only advances its resume token once an event and every
+// event before it have been handled, so a change stream resumed from that token will not skip an unhandled event.
+package changestream // import "go.mongodb.org/mongo-driver/mongo/changestream"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash/fnv"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrDispatcherRunning is returned by Dispatcher.Run if the Dispatcher is already running.
+var ErrDispatcherRunning = errors.New("the dispatcher is already running")
+
+// Stream is the subset of the mongo.ChangeStream methods used by a Dispatcher.
+type Stream interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+}
+
+var _ Stream = (*mongo.ChangeStream)(nil)
+
+// Handler processes a change event. A non-nil error stops the Dispatcher.
+type Handler func(ctx context.Context, event *mongo.ChangeEvent) error
+
+// Route specifies which change events a handler receives. Empty fields match any value.
+type Route struct {
+	// The database of the event's namespace.
+	Database string
+
+	// The collection of the event's namespace.
+	Collection string
+
+	// The operation types of the events, such as "insert" or "delete".
+	OperationTypes []string
+}
+
+func (r Route) matches(event *mongo.ChangeEvent) bool {
+	if r.Database != "" && r.Database != event.Namespace.Database {
+		return false
+	}
+	if r.Collection != "" && r.Collection != event.Namespace.Collection {
+		return false
+	}
+	if len(r.OperationTypes) == 0 {
+		return true
+	}
+	for _, opType := range r.OperationTypes {
+		if opType == event.OperationType {
+			return true
+		}
+	}
+	return false
+}
+
+type route struct {
+	Route
+	handler Handler
+}
+
+type job struct {
+	seq     uint64
+	event   *mongo.ChangeEvent
+	handler Handler
+}
+
+// Dispatcher routes the events of a change stream to handlers. Handlers must be registered with Handle before Run is
+// called.
+type Dispatcher struct {
+	stream             Stream
+	routes             []route
+	workers            int
+	store              options.ResumeTokenStore
+	checkpointInterval time.Duration
+
+	mu       sync.Mutex
+	running  bool
+	err      error
+	cancel   context.CancelFunc
+	tracker  *tracker
+	inflight sync.WaitGroup
+}
+
+// NewDispatcher creates a new Dispatcher that reads events from the given stream, which is typically a
+// *mongo.ChangeStream.
+//
+// The opts parameter can be used to specify options for the Dispatcher (see the options.DispatcherOptions
+// documentation).
+func NewDispatcher(stream Stream, opts ...*options.DispatcherOptions) *Dispatcher {
+	do := options.MergeDispatcherOptions(opts...)
+	d := &Dispatcher{
+		stream:  stream,
+		workers: runtime.NumCPU(),
+		store:   do.ResumeTokenStore,
+	}
+	if do.Workers != nil && *do.Workers > 0 {
+		d.workers = *do.Workers
+	}
+	if do.CheckpointInterval != nil {
+		d.checkpointInterval = *do.CheckpointInterval
+	}
+	return d
+}
+
+// Handle registers a handler for the events matched by the route. Each event is passed to the handler of the first
+// registered route that matches it. Events that do not match any route are skipped and count as handled.
+func (d *Dispatcher) Handle(r Route, h Handler) {
+	d.routes = append(d.routes, route{Route: r, handler: h})
+}
+
+// ResumeToken returns the resume token of the last event that, along with every event before it, has been handled, or
+// nil if no such event exists.
+func (d *Dispatcher) ResumeToken() bson.Raw {
+	d.mu.Lock()
+	t := d.tracker
+	d.mu.Unlock()
+
+	if t == nil {
+		return nil
+	}
+	return t.token()
+}
+
+// Run reads events from the stream and dispatches them until ctx is cancelled, the stream returns an error, or a
+// handler returns an error. Run waits for every dispatched handler to return and saves the latest resume token before
+// it returns. The first error encountered is returned. If Run stops because ctx was cancelled, the context's error is
+// returned.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	d.mu.Lock()
+	if d.running {
+		d.mu.Unlock()
+		return ErrDispatcherRunning
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d.running = true
+	d.err = nil
+	d.cancel = cancel
+	d.tracker = newTracker()
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		d.running = false
+		d.mu.Unlock()
+	}()
+
+	queues := make([]chan job, d.workers)
+	var workers sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan job, 16)
+		workers.Add(1)
+		go func(queue <-chan job) {
+			defer workers.Done()
+			d.work(ctx, queue)
+		}(queues[i])
+	}
+
+	checkpointDone := make(chan struct{})
+	var checkpointer sync.WaitGroup
+	if d.store != nil {
+		checkpointer.Add(1)
+		go func() {
+			defer checkpointer.Done()
+			d.checkpoint(ctx, checkpointDone)
+		}()
+	}
+
+	d.read(ctx, queues)
+	d.mu.Lock()
+	if d.err == nil {
+		// The derived context is only cancelled by fail, which records its own error, so this is the caller's error.
+		d.err = ctx.Err()
+	}
+	d.mu.Unlock()
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	workers.Wait()
+	close(checkpointDone)
+	checkpointer.Wait()
+
+	// Save the final position even if ctx was cancelled so that a graceful shutdown does not replay events.
+	if d.store != nil {
+		if err := d.save(context.Background()); err != nil {
+			d.fail(err)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
+
+// read dispatches events from the stream until it is exhausted or ctx is cancelled.
+func (d *Dispatcher) read(ctx context.Context, queues []chan job) {
+	for d.stream.Next(ctx) {
+		event := new(mongo.ChangeEvent)
+		if err := d.stream.Decode(event); err != nil {
+			d.fail(err)
+			return
+		}
+
+		seq := d.tracker.add(event.ID)
+		handler := d.handler(event)
+		if handler == nil {
+			d.tracker.ack(seq)
+			continue
+		}
+
+		// Events that do not refer to a single document act as barriers for every other event.
+		if len(event.DocumentKey) == 0 {
+			d.inflight.Wait()
+			if ctx.Err() != nil {
+				return
+			}
+			d.run(ctx, job{seq: seq, event: event, handler: handler})
+			continue
+		}
+
+		d.inflight.Add(1)
+		select {
+		case queues[queueIndex(event.DocumentKey, len(queues))] <- job{seq: seq, event: event, handler: handler}:
+		case <-ctx.Done():
+			d.inflight.Done()
+			return
+		}
+	}
+
+	if err := d.stream.Err(); err != nil && ctx.Err() == nil {
+		d.fail(err)
+	}
+}
+
+func (d *Dispatcher) work(ctx context.Context, queue <-chan job) {
+	for j := range queue {
+		// Once the dispatcher has failed, remaining events are left unacknowledged so they are replayed on restart.
+		if ctx.Err() == nil {
+			d.run(ctx, j)
+		}
+		d.inflight.Done()
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context, j job) {
+	if err := j.handler(ctx, j.event); err != nil {
+		d.fail(err)
+		return
+	}
+	d.tracker.ack(j.seq)
+}
+
+func (d *Dispatcher) handler(event *mongo.ChangeEvent) Handler {
+	for _, r := range d.routes {
+		if r.matches(event) {
+			return r.handler
+		}
+	}
+	return nil
+}
+
+// checkpoint saves the resume token whenever it advances, waiting at least the checkpoint interval between saves.
+func (d *Dispatcher) checkpoint(ctx context.Context, done <-chan struct{}) {
+	var last time.Time
+	for {
+		select {
+		case <-d.tracker.advanced:
+		case <-done:
+			return
+		}
+
+		if wait := d.checkpointInterval - time.Since(last); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-done:
+				timer.Stop()
+				return
+			}
+		}
+
+		if err := d.save(ctx); err != nil {
+			d.fail(err)
+			return
+		}
+		last = time.Now()
+	}
+}
+
+// save saves the current resume token if it has changed since it was last saved.
+func (d *Dispatcher) save(ctx context.Context) error {
+	token, saved := d.tracker.token(), d.tracker.savedToken()
+	if token == nil || bytes.Equal(token, saved) {
+		return nil
+	}
+	if err := d.store.Save(ctx, token); err != nil {
+		return err
+	}
+	d.tracker.setSaved(token)
+	return nil
+}
+
+// fail records the first error encountered and stops the dispatcher.
+func (d *Dispatcher) fail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.err == nil {
+		d.err = err
+	}
+	d.cancel()
+}
+
+func queueIndex(documentKey bson.Raw, n int) int {
+	h := fnv.New32a()
+	_, _ = h.Write(documentKey)
+	return int(h.Sum32() % uint32(n))
+}
+
+// tracker records which events have been acknowledged and computes the resume token of the last event before the
+// first unacknowledged event.
+type tracker struct {
+	mu        sync.Mutex
+	next      uint64
+	low       uint64
+	tokens    map[uint64]bson.Raw
+	acked     map[uint64]bool
+	committed bson.Raw
+	saved     bson.Raw
+
+	// advanced receives a value when the committed token changes. It is buffered so acknowledgements never block.
+	advanced chan struct{}
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		tokens:   make(map[uint64]bson.Raw),
+		acked:    make(map[uint64]bool),
+		advanced: make(chan struct{}, 1),
+	}
+}
+
+// add records a dispatched event and returns its sequence number.
+func (t *tracker) add(token bson.Raw) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seq := t.next
+	t.next++
+	t.tokens[seq] = token
+	return seq
+}
+
+// ack marks the event with the given sequence number as handled and advances the committed token past every
+// contiguous handled event.
+func (t *tracker) ack(seq uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.acked[seq] = true
+	advanced := false
+	for t.acked[t.low] {
+		t.committed = t.tokens[t.low]
+		delete(t.acked, t.low)
+		delete(t.tokens, t.low)
+		t.low++
+		advanced = true
+	}
+
+	if advanced {
+		select {
+		case t.advanced <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *tracker) token() bson.Raw {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.committed
+}
+
+func (t *tracker) savedToken() bson.Raw {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.saved
+}
+
+func (t *tracker) setSaved(token bson.Raw) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.saved = token
+}
@@ -0,0 +1,195 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package changestream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sliceStream struct {
+	events  []bson.Raw
+	current bson.Raw
+}
+
+func (s *sliceStream) Next(ctx context.Context) bool {
+	if len(s.events) == 0 || ctx.Err() != nil {
+		return false
+	}
+	s.current, s.events = s.events[0], s.events[1:]
+	return true
+}
+
+func (s *sliceStream) Decode(val interface{}) error {
+	return bson.Unmarshal(s.current, val)
+}
+
+func (s *sliceStream) Err() error {
+	return nil
+}
+
+type memoryStore struct {
+	mu    sync.Mutex
+	saved []bson.Raw
+}
+
+func (m *memoryStore) Load(context.Context) (bson.Raw, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) Save(_ context.Context, token bson.Raw) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = append(m.saved, token)
+	return nil
+}
+
+func resumeToken(t *testing.T, n int) bson.Raw {
+	t.Helper()
+
+	b, err := bson.Marshal(bson.D{{"_data", int32(n)}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
+
+// newEvent creates a change event with the given resume token number. If key is nil, the event does not have a
+// documentKey.
+func newEvent(t *testing.T, n int, opType string, key interface{}) bson.Raw {
+	t.Helper()
+
+	doc := bson.D{
+		{"_id", resumeToken(t, n)},
+		{"operationType", opType},
+		{"ns", bson.D{{"db", "db"}, {"coll", "coll"}}},
+	}
+	if key != nil {
+		doc = append(doc, bson.E{"documentKey", bson.D{{"_id", key}}})
+	}
+	b, err := bson.Marshal(doc)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
+
+func documentID(event *mongo.ChangeEvent) string {
+	return event.DocumentKey.Lookup("_id").StringValue()
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Run("per-key ordering", func(t *testing.T) {
+		var events []bson.Raw
+		for i := 0; i < 60; i++ {
+			events = append(events, newEvent(t, i, "update", []string{"a", "b", "c"}[i%3]))
+		}
+
+		var mu sync.Mutex
+		seen := make(map[string][]int32)
+		d := NewDispatcher(&sliceStream{events: events}, options.Dispatcher().SetWorkers(4))
+		d.Handle(Route{}, func(_ context.Context, event *mongo.ChangeEvent) error {
+			// Delay the first documents so later events for other documents overtake them.
+			if documentID(event) == "a" {
+				time.Sleep(time.Millisecond)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			key := documentID(event)
+			seen[key] = append(seen[key], event.ID.Lookup("_data").Int32())
+			return nil
+		})
+
+		err := d.Run(context.Background())
+		assert.Nil(t, err, "Run error: %v", err)
+		for key, order := range seen {
+			assert.Equal(t, 20, len(order), "expected 20 events for %v, got %v", key, len(order))
+			for i := 1; i < len(order); i++ {
+				assert.True(t, order[i-1] < order[i], "events for %v handled out of order: %v", key, order)
+			}
+		}
+		assert.Equal(t, resumeToken(t, 59), d.ResumeToken(), "expected resume token %v, got %v",
+			resumeToken(t, 59), d.ResumeToken())
+	})
+	t.Run("routing and barriers", func(t *testing.T) {
+		events := []bson.Raw{
+			newEvent(t, 1, "insert", "a"),
+			newEvent(t, 2, "delete", "b"),
+			newEvent(t, 3, "drop", nil),
+			newEvent(t, 4, "insert", "c"),
+		}
+
+		var mu sync.Mutex
+		var handled []string
+		record := func(name string) Handler {
+			return func(_ context.Context, event *mongo.ChangeEvent) error {
+				if event.OperationType == "insert" && documentID(event) == "a" {
+					time.Sleep(5 * time.Millisecond)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, name+":"+event.OperationType)
+				return nil
+			}
+		}
+		d := NewDispatcher(&sliceStream{events: events}, options.Dispatcher().SetWorkers(2))
+		d.Handle(Route{Collection: "other"}, record("other"))
+		d.Handle(Route{Database: "db", OperationTypes: []string{"insert", "drop"}}, record("writes"))
+
+		err := d.Run(context.Background())
+		assert.Nil(t, err, "Run error: %v", err)
+		expected := []string{"writes:insert", "writes:drop", "writes:insert"}
+		assert.Equal(t, expected, handled, "expected handled events %v, got %v", expected, handled)
+		assert.Equal(t, resumeToken(t, 4), d.ResumeToken(), "expected resume token %v, got %v",
+			resumeToken(t, 4), d.ResumeToken())
+	})
+	t.Run("handler error", func(t *testing.T) {
+		events := []bson.Raw{
+			newEvent(t, 1, "insert", "a"),
+			newEvent(t, 2, "insert", "b"),
+			newEvent(t, 3, "insert", "c"),
+		}
+		handlerErr := errors.New("handler error")
+		store := &memoryStore{}
+
+		d := NewDispatcher(&sliceStream{events: events}, options.Dispatcher().SetWorkers(1).SetResumeTokenStore(store))
+		d.Handle(Route{}, func(_ context.Context, event *mongo.ChangeEvent) error {
+			if documentID(event) == "b" {
+				return handlerErr
+			}
+			return nil
+		})
+
+		err := d.Run(context.Background())
+		assert.Equal(t, handlerErr, err, "expected error %v, got %v", handlerErr, err)
+		assert.Equal(t, resumeToken(t, 1), d.ResumeToken(), "expected resume token %v, got %v",
+			resumeToken(t, 1), d.ResumeToken())
+		assert.True(t, len(store.saved) > 0, "expected a saved resume token")
+		last := store.saved[len(store.saved)-1]
+		assert.Equal(t, resumeToken(t, 1), last, "expected saved resume token %v, got %v", resumeToken(t, 1), last)
+	})
+	t.Run("tracker", func(t *testing.T) {
+		tr := newTracker()
+		seqs := make([]uint64, 3)
+		for i := range seqs {
+			seqs[i] = tr.add(resumeToken(t, i))
+		}
+
+		tr.ack(seqs[1])
+		assert.Nil(t, tr.token(), "expected no committed token, got %v", tr.token())
+		tr.ack(seqs[0])
+		assert.Equal(t, resumeToken(t, 1), tr.token(), "expected committed token %v, got %v", resumeToken(t, 1),
+			tr.token())
+		tr.ack(seqs[2])
+		assert.Equal(t, resumeToken(t, 2), tr.token(), "expected committed token %v, got %v", resumeToken(t, 2),
+			tr.token())
+	})
+}
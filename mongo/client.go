@@ -63,6 +63,7 @@ type Client struct {
 	serverAPI       *driver.ServerAPIOptions
 	serverMonitor   *event.ServerMonitor
 	sessionPool     *session.Pool
+	timeout         *time.Duration
 
 	// client-side encryption fields
 	keyVaultClientFLE *Client
@@ -609,6 +610,8 @@ func (c *Client) configure(opts *options.ClientOptions) error {
 			func(time.Duration) time.Duration { return *opts.ServerSelectionTimeout },
 		))
 	}
+	// Timeout
+	c.timeout = opts.Timeout
 	// SocketTimeout
 	if opts.SocketTimeout != nil {
 		connOpts = append(
@@ -878,7 +881,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 	op := operation.NewListDatabases(filterDoc).
 		Session(sess).ReadPreference(c.readPreference).CommandMonitor(c.monitor).
 		ServerSelector(selector).ClusterClock(c.clock).Database("admin").Deployment(c.deployment).Crypt(c.cryptFLE).
-		ServerAPI(c.serverAPI)
+		ServerAPI(c.serverAPI).Timeout(c.timeout)
 
 	if ldo.NameOnly != nil {
 		op = op.NameOnly(*ldo.NameOnly)
@@ -928,6 +931,74 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 	return names, nil
 }
 
+// BulkWrite performs a bulk write operation (https://docs.mongodb.com/manual/reference/command/bulkWrite/) that can
+// write to multiple collections and databases in a single command. This requires server version 8.0 or higher.
+//
+// The models parameter must be a slice of ClientBulkWrite values, each of which pairs a write model with the namespace
+// it applies to. The slice cannot be nil or empty. All of the models must be non-nil.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ClientBulkWriteOptions
+// documentation.)
+//
+// If any write or write concern errors occur, the returned error will be a ClientBulkWriteException. Its PartialResult
+// field contains the results of any writes that were executed successfully.
+func (c *Client) BulkWrite(ctx context.Context, models []ClientBulkWrite,
+	opts ...*options.ClientBulkWriteOptions) (*ClientBulkWriteResult, error) {
+
+	if len(models) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if c.cryptFLE != nil {
+		return nil, ErrClientBulkWriteEncryption
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && c.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(c.sessionPool, c.id, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+		defer sess.EndSession()
+	}
+
+	err := c.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := c.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, description.CompositeSelector([]description.ServerSelector{
+		description.WriteSelector(),
+		description.LatencySelector(c.localThreshold),
+	}))
+
+	op := clientBulkWrite{
+		models:       models,
+		client:       c,
+		session:      sess,
+		selector:     selector,
+		writeConcern: wc,
+		opts:         options.MergeClientBulkWriteOptions(opts...),
+	}
+
+	err = op.execute(ctx)
+
+	return &op.result, replaceErrors(err)
+}
+
 // WithSession creates a new SessionContext from the ctx and sess parameters and uses it to call the fn callback. The
 // SessionContext must be used as the Context parameter for any operations in the fn callback that should be executed
 // under the session.
@@ -988,6 +1059,7 @@ func (c *Client) Watch(ctx context.Context, pipeline interface{},
 		registry:       c.registry,
 		streamType:     ClientStream,
 		crypt:          c.cryptFLE,
+		timeout:        c.timeout,
 	}
 
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
@@ -1004,5 +1076,6 @@ func (c *Client) createBaseCursorOptions() driver.CursorOptions {
 		CommandMonitor: c.monitor,
 		Crypt:          c.cryptFLE,
 		ServerAPI:      c.serverAPI,
+		Timeout:        c.timeout,
 	}
 }
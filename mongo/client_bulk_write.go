@@ -0,0 +1,370 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// ErrClientBulkWriteEncryption is returned by Client.BulkWrite if the Client was configured with automatic encryption.
+var ErrClientBulkWriteEncryption = errors.New("client-level bulk writes are not supported with automatic encryption")
+
+// ClientBulkWrite pairs a write model with the namespace it applies to. A slice of ClientBulkWrite values can be passed
+// to Client.BulkWrite to write to multiple collections in a single operation.
+type ClientBulkWrite struct {
+	// The name of the database the model applies to. This field is required.
+	Database string
+
+	// The name of the collection the model applies to. This field is required.
+	Collection string
+
+	// The write to perform. This can be an *InsertOneModel, *UpdateOneModel, *UpdateManyModel, *ReplaceOneModel,
+	// *DeleteOneModel, or *DeleteManyModel. This field is required.
+	Model WriteModel
+}
+
+// clientBulkWrite performs a client-level bulkWrite operation.
+type clientBulkWrite struct {
+	models       []ClientBulkWrite
+	client       *Client
+	session      *session.Client
+	selector     description.ServerSelector
+	writeConcern *writeconcern.WriteConcern
+	opts         *options.ClientBulkWriteOptions
+	insertedIDs  map[int]interface{}
+	result       ClientBulkWriteResult
+}
+
+func (bw *clientBulkWrite) execute(ctx context.Context) error {
+	namespaces := make([]string, 0)
+	nsIndexes := make(map[string]int)
+	docs := make([]bsoncore.Document, 0, len(bw.models))
+	bw.insertedIDs = make(map[int]interface{})
+	canRetry := true
+	for i, m := range bw.models {
+		if m.Model == nil {
+			return ErrNilDocument
+		}
+		if m.Database == "" || m.Collection == "" {
+			return fmt.Errorf("database and collection names must be specified for the model at index %d", i)
+		}
+
+		ns := m.Database + "." + m.Collection
+		nsIdx, ok := nsIndexes[ns]
+		if !ok {
+			nsIdx = len(namespaces)
+			nsIndexes[ns] = nsIdx
+			namespaces = append(namespaces, ns)
+		}
+
+		doc, id, multi, err := createClientBulkWriteDoc(m.Model, int32(nsIdx), bw.client.registry)
+		if err != nil {
+			return err
+		}
+		if id != nil {
+			bw.insertedIDs[i] = id
+		}
+		canRetry = canRetry && !multi
+		docs = append(docs, doc)
+	}
+
+	op := operation.NewClientBulkWrite(namespaces, docs...).
+		Session(bw.session).WriteConcern(bw.writeConcern).CommandMonitor(bw.client.monitor).
+		ServerSelector(bw.selector).ClusterClock(bw.client.clock).Deployment(bw.client.deployment).
+		ServerAPI(bw.client.serverAPI).Timeout(bw.client.timeout)
+	if bw.opts.BypassDocumentValidation != nil && *bw.opts.BypassDocumentValidation {
+		op = op.BypassDocumentValidation(*bw.opts.BypassDocumentValidation)
+	}
+	if bw.opts.Comment != nil {
+		comment, err := transformValue(bw.client.registry, bw.opts.Comment, true, "comment")
+		if err != nil {
+			return err
+		}
+		op = op.Comment(comment)
+	}
+	if bw.opts.Let != nil {
+		let, err := transformBsoncoreDocument(bw.client.registry, bw.opts.Let, true, "let")
+		if err != nil {
+			return err
+		}
+		op = op.Let(let)
+	}
+	if bw.opts.Ordered != nil {
+		op = op.Ordered(*bw.opts.Ordered)
+	}
+	verbose := bw.opts.VerboseResults != nil && *bw.opts.VerboseResults
+	op = op.ErrorsOnly(!verbose)
+
+	retry := driver.RetryNone
+	if bw.client.retryWrites && canRetry {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	err := op.Execute(ctx)
+	if err == driver.ErrUnacknowledgedWrite {
+		return ErrUnacknowledgedWrite
+	}
+
+	res := op.Result()
+	bw.result = ClientBulkWriteResult{
+		InsertedCount: res.NInserted,
+		MatchedCount:  res.NMatched,
+		ModifiedCount: res.NModified,
+		DeletedCount:  res.NDeleted,
+		UpsertedCount: res.NUpserted,
+	}
+	if verbose {
+		bw.result.InsertResults = make(map[int]ClientInsertResult)
+		bw.result.UpdateResults = make(map[int]ClientUpdateResult)
+		bw.result.DeleteResults = make(map[int]ClientDeleteResult)
+	}
+
+	exception := ClientBulkWriteException{
+		WriteErrors: make(map[int]WriteError),
+	}
+	switch tt := err.(type) {
+	case nil:
+	case driver.WriteCommandError:
+		if wce := convertDriverWriteConcernError(tt.WriteConcernError); wce != nil {
+			exception.WriteConcernErrors = append(exception.WriteConcernErrors, *wce)
+		}
+		exception.Labels = tt.Labels
+	default:
+		// If no batches have completed there are no partial results to report.
+		if len(op.ResultCursorResponses()) == 0 {
+			return replaceErrors(err)
+		}
+		exception.TopLevelError = replaceErrors(err)
+	}
+
+	cursorOpts := bw.client.createBaseCursorOptions()
+	for _, cr := range op.ResultCursorResponses() {
+		if err := bw.processResults(ctx, cr, cursorOpts, verbose, exception.WriteErrors); err != nil {
+			exception.TopLevelError = replaceErrors(err)
+			break
+		}
+	}
+
+	if exception.TopLevelError != nil || len(exception.WriteConcernErrors) > 0 || len(exception.WriteErrors) > 0 {
+		exception.PartialResult = &bw.result
+		return exception
+	}
+	return nil
+}
+
+// processResults iterates the cursor over the individual operation results for one batch and records them in the
+// result or in writeErrors.
+func (bw *clientBulkWrite) processResults(ctx context.Context, cr operation.ClientBulkWriteCursorResponse,
+	cursorOpts driver.CursorOptions, verbose bool, writeErrors map[int]WriteError) error {
+
+	bc, err := driver.NewBatchCursor(cr.Response, bw.session, bw.client.clock, cursorOpts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = bc.Close(ctx) }()
+
+	for bc.Next(ctx) {
+		docs, err := bc.Batch().Documents()
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if err := bw.processResult(doc, cr.Offset, verbose, writeErrors); err != nil {
+				return err
+			}
+		}
+	}
+	return bc.Err()
+}
+
+func (bw *clientBulkWrite) processResult(doc bsoncore.Document, offset int, verbose bool,
+	writeErrors map[int]WriteError) error {
+
+	var res struct {
+		Ok        float64  `bson:"ok"`
+		Idx       int32    `bson:"idx"`
+		Code      int32    `bson:"code"`
+		Errmsg    string   `bson:"errmsg"`
+		ErrInfo   bson.Raw `bson:"errInfo"`
+		N         int64    `bson:"n"`
+		NModified int64    `bson:"nModified"`
+		Upserted  *struct {
+			ID bson.RawValue `bson:"_id"`
+		} `bson:"upserted"`
+	}
+	if err := bson.Unmarshal(doc, &res); err != nil {
+		return err
+	}
+
+	idx := int(res.Idx) + offset
+	if idx < 0 || idx >= len(bw.models) {
+		return fmt.Errorf("bulkWrite result index %d is out of range", idx)
+	}
+	if res.Ok == 0 {
+		writeErrors[idx] = WriteError{
+			Index:   idx,
+			Code:    int(res.Code),
+			Message: res.Errmsg,
+			Details: res.ErrInfo,
+		}
+		return nil
+	}
+	if !verbose {
+		return nil
+	}
+
+	switch bw.models[idx].Model.(type) {
+	case *InsertOneModel:
+		bw.result.InsertResults[idx] = ClientInsertResult{InsertedID: bw.insertedIDs[idx]}
+	case *UpdateOneModel, *UpdateManyModel, *ReplaceOneModel:
+		ur := ClientUpdateResult{
+			MatchedCount:  res.N,
+			ModifiedCount: res.NModified,
+		}
+		if res.Upserted != nil {
+			if err := res.Upserted.ID.UnmarshalWithRegistry(bw.client.registry, &ur.UpsertedID); err != nil {
+				return err
+			}
+			// The server counts the upserted document as matched.
+			ur.MatchedCount--
+		}
+		bw.result.UpdateResults[idx] = ur
+	case *DeleteOneModel, *DeleteManyModel:
+		bw.result.DeleteResults[idx] = ClientDeleteResult{DeletedCount: res.N}
+	}
+	return nil
+}
+
+// createClientBulkWriteDoc creates the operation document for model that will be sent as part of the "ops" document
+// sequence of a bulkWrite command. It returns the _id of the document for inserts and whether or not the operation
+// can affect multiple documents.
+func createClientBulkWriteDoc(model WriteModel, nsIdx int32, registry *bsoncodec.Registry) (bsoncore.Document,
+	interface{}, bool, error) {
+
+	switch converted := model.(type) {
+	case *InsertOneModel:
+		doc, id, err := transformAndEnsureID(registry, converted.Document)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "insert", nsIdx),
+			bsoncore.AppendDocumentElement(nil, "document", doc),
+		), id, false, nil
+	case *UpdateOneModel:
+		doc, err := createClientUpdateDoc(nsIdx, converted.Filter, converted.Update, converted.Hint,
+			converted.ArrayFilters, converted.Collation, converted.Upsert, false, true, registry)
+		return doc, nil, false, err
+	case *UpdateManyModel:
+		doc, err := createClientUpdateDoc(nsIdx, converted.Filter, converted.Update, converted.Hint,
+			converted.ArrayFilters, converted.Collation, converted.Upsert, true, true, registry)
+		return doc, nil, true, err
+	case *ReplaceOneModel:
+		doc, err := createClientUpdateDoc(nsIdx, converted.Filter, converted.Replacement, converted.Hint,
+			nil, converted.Collation, converted.Upsert, false, false, registry)
+		return doc, nil, false, err
+	case *DeleteOneModel:
+		doc, err := createClientDeleteDoc(nsIdx, converted.Filter, converted.Collation, converted.Hint, false, registry)
+		return doc, nil, false, err
+	case *DeleteManyModel:
+		doc, err := createClientDeleteDoc(nsIdx, converted.Filter, converted.Collation, converted.Hint, true, registry)
+		return doc, nil, true, err
+	default:
+		return nil, nil, false, fmt.Errorf("unsupported write model type %T", model)
+	}
+}
+
+func createClientUpdateDoc(
+	nsIdx int32,
+	filter interface{},
+	update interface{},
+	hint interface{},
+	arrayFilters *options.ArrayFilters,
+	collation *options.Collation,
+	upsert *bool,
+	multi bool,
+	checkDollarKey bool,
+	registry *bsoncodec.Registry,
+) (bsoncore.Document, error) {
+	f, err := transformBsoncoreDocument(registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+	u, err := transformUpdateValue(registry, update, checkDollarKey)
+	if err != nil {
+		return nil, err
+	}
+
+	uidx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendInt32Element(doc, "update", nsIdx)
+	doc = bsoncore.AppendDocumentElement(doc, "filter", f)
+	doc = bsoncore.AppendValueElement(doc, "updateMods", u)
+	doc = bsoncore.AppendBooleanElement(doc, "multi", multi)
+
+	if arrayFilters != nil {
+		arr, err := arrayFilters.ToArrayDocument()
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendArrayElement(doc, "arrayFilters", arr)
+	}
+	if collation != nil {
+		doc = bsoncore.AppendDocumentElement(doc, "collation", bsoncore.Document(collation.ToDocument()))
+	}
+	if upsert != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "upsert", *upsert)
+	}
+	if hint != nil {
+		hintVal, err := transformValue(registry, hint, false, "hint")
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendValueElement(doc, "hint", hintVal)
+	}
+
+	doc, _ = bsoncore.AppendDocumentEnd(doc, uidx)
+	return doc, nil
+}
+
+func createClientDeleteDoc(nsIdx int32, filter interface{}, collation *options.Collation, hint interface{}, multi bool,
+	registry *bsoncodec.Registry) (bsoncore.Document, error) {
+
+	f, err := transformBsoncoreDocument(registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	didx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendInt32Element(doc, "delete", nsIdx)
+	doc = bsoncore.AppendDocumentElement(doc, "filter", f)
+	doc = bsoncore.AppendBooleanElement(doc, "multi", multi)
+	if collation != nil {
+		doc = bsoncore.AppendDocumentElement(doc, "collation", collation.ToDocument())
+	}
+	if hint != nil {
+		hintVal, err := transformValue(registry, hint, false, "hint")
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendValueElement(doc, "hint", hintVal)
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, didx)
+	return doc, nil
+}
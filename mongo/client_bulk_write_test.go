@@ -0,0 +1,423 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+func TestCreateClientBulkWriteDoc(t *testing.T) {
+	filter := bson.D{{"x", 1}}
+	filterDoc, err := bson.Marshal(filter)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	update := bson.D{{"$set", bson.D{{"y", 2}}}}
+	updateDoc, err := bson.Marshal(update)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	replacementDoc, err := bson.Marshal(bson.D{{"y", 2}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	testCases := []struct {
+		name     string
+		model    WriteModel
+		expected bsoncore.Document
+		multi    bool
+	}{
+		{
+			"update one",
+			NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "update", 2),
+				bsoncore.AppendDocumentElement(nil, "filter", filterDoc),
+				bsoncore.AppendDocumentElement(nil, "updateMods", updateDoc),
+				bsoncore.AppendBooleanElement(nil, "multi", false),
+				bsoncore.AppendBooleanElement(nil, "upsert", true),
+			),
+			false,
+		},
+		{
+			"update many",
+			NewUpdateManyModel().SetFilter(filter).SetUpdate(update),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "update", 2),
+				bsoncore.AppendDocumentElement(nil, "filter", filterDoc),
+				bsoncore.AppendDocumentElement(nil, "updateMods", updateDoc),
+				bsoncore.AppendBooleanElement(nil, "multi", true),
+			),
+			true,
+		},
+		{
+			"replace one",
+			NewReplaceOneModel().SetFilter(filter).SetReplacement(bson.D{{"y", 2}}),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "update", 2),
+				bsoncore.AppendDocumentElement(nil, "filter", filterDoc),
+				bsoncore.AppendDocumentElement(nil, "updateMods", replacementDoc),
+				bsoncore.AppendBooleanElement(nil, "multi", false),
+			),
+			false,
+		},
+		{
+			"delete one",
+			NewDeleteOneModel().SetFilter(filter).SetHint("x_1"),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "delete", 2),
+				bsoncore.AppendDocumentElement(nil, "filter", filterDoc),
+				bsoncore.AppendBooleanElement(nil, "multi", false),
+				bsoncore.AppendStringElement(nil, "hint", "x_1"),
+			),
+			false,
+		},
+		{
+			"delete many",
+			NewDeleteManyModel().SetFilter(filter),
+			bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "delete", 2),
+				bsoncore.AppendDocumentElement(nil, "filter", filterDoc),
+				bsoncore.AppendBooleanElement(nil, "multi", true),
+			),
+			true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, id, multi, err := createClientBulkWriteDoc(tc.model, 2, bson.DefaultRegistry)
+			assert.Nil(t, err, "createClientBulkWriteDoc error: %v", err)
+			assert.Nil(t, id, "expected nil _id, got %v", id)
+			assert.Equal(t, tc.multi, multi, "expected multi %v, got %v", tc.multi, multi)
+			assert.Equal(t, tc.expected, doc, "expected document %v, got %v", tc.expected, doc)
+		})
+	}
+	t.Run("insert one", func(t *testing.T) {
+		model := NewInsertOneModel().SetDocument(bson.D{{"_id", 1}, {"x", 1}})
+		doc, id, multi, err := createClientBulkWriteDoc(model, 0, bson.DefaultRegistry)
+		assert.Nil(t, err, "createClientBulkWriteDoc error: %v", err)
+		assert.False(t, multi, "expected multi to be false")
+		assert.Equal(t, int32(1), id, "expected _id 1, got %v", id)
+
+		insertVal, err := doc.LookupErr("insert")
+		assert.Nil(t, err, "insert key not found in %v", doc)
+		assert.Equal(t, int32(0), insertVal.Int32(), "expected namespace index 0, got %v", insertVal)
+		_, err = doc.LookupErr("document", "x")
+		assert.Nil(t, err, "document.x not found in %v", doc)
+	})
+	t.Run("replacement with update operators", func(t *testing.T) {
+		model := NewReplaceOneModel().SetFilter(filter).SetReplacement(update)
+		_, _, _, err := createClientBulkWriteDoc(model, 0, bson.DefaultRegistry)
+		assert.NotNil(t, err, "expected error for replacement containing update operators, got nil")
+	})
+}
+
+// bulkWriteConn is a driver.Connection that answers bulkWrite commands with the replies returned by respond. The
+// operation documents of every batch that is sent are recorded in batches.
+type bulkWriteConn struct {
+	*drivertest.ChannelConn
+	batches [][]bsoncore.Document
+	respond func(batch int, ops []bsoncore.Document) bson.D
+	reply   []byte
+}
+
+func (c *bulkWriteConn) WriteWireMessage(_ context.Context, wm []byte) error {
+	_, _, _, _, wm, ok := wiremessage.ReadHeader(wm)
+	if !ok {
+		return errors.New("could not read header")
+	}
+	_, wm, ok = wiremessage.ReadMsgFlags(wm)
+	if !ok {
+		return errors.New("could not read flags")
+	}
+	var ops []bsoncore.Document
+	for len(wm) > 0 {
+		var stype wiremessage.SectionType
+		stype, wm, ok = wiremessage.ReadMsgSectionType(wm)
+		if !ok {
+			return errors.New("could not read section type")
+		}
+		if stype == wiremessage.SingleDocument {
+			_, wm, ok = wiremessage.ReadMsgSectionSingleDocument(wm)
+		} else {
+			var docs []bsoncore.Document
+			_, docs, wm, ok = wiremessage.ReadMsgSectionDocumentSequence(wm)
+			for _, doc := range docs {
+				ops = append(ops, append(bsoncore.Document(nil), doc...))
+			}
+		}
+		if !ok {
+			return errors.New("could not read section")
+		}
+	}
+
+	reply, err := bson.Marshal(c.respond(len(c.batches), ops))
+	if err != nil {
+		return err
+	}
+	c.batches = append(c.batches, ops)
+	c.reply = drivertest.MakeReply(reply)
+	return nil
+}
+
+func (c *bulkWriteConn) ReadWireMessage(context.Context, []byte) ([]byte, error) {
+	return c.reply, nil
+}
+
+// bulkWriteReply creates a bulkWrite reply that reports the given individual results for a batch of inserts.
+func bulkWriteReply(results ...bson.D) bson.D {
+	var nErrors, nInserted int32
+	firstBatch := bson.A{}
+	for _, res := range results {
+		if res.Map()["ok"] == 0 {
+			nErrors++
+		} else {
+			nInserted++
+		}
+		firstBatch = append(firstBatch, res)
+	}
+	return bson.D{
+		{"ok", 1},
+		{"nErrors", nErrors},
+		{"nInserted", nInserted},
+		{"nMatched", 0},
+		{"nModified", 0},
+		{"nUpserted", 0},
+		{"nDeleted", 0},
+		{"cursor", bson.D{{"id", int64(0)}, {"ns", "admin.$cmd.bulkWrite"}, {"firstBatch", firstBatch}}},
+	}
+}
+
+// insertResults returns a successful result for every operation in ops, except for the operations at the indexes in
+// failed, which get a duplicate key error. The indexes are relative to the batch like the ones sent by the server.
+func insertResults(ops []bsoncore.Document, failed ...int) []bson.D {
+	results := make([]bson.D, 0, len(ops))
+	for i := range ops {
+		res := bson.D{{"ok", 1}, {"idx", int32(i)}, {"n", 1}}
+		for _, f := range failed {
+			if f == i {
+				res = bson.D{{"ok", 0}, {"idx", int32(i)}, {"code", 11000}, {"errmsg", "duplicate key"}}
+			}
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestClientBulkWriteExecute(t *testing.T) {
+	const numModels = 5
+	models := make([]ClientBulkWrite, 0, numModels)
+	for i := 0; i < numModels; i++ {
+		models = append(models, ClientBulkWrite{
+			Database:   "db",
+			Collection: "coll",
+			Model:      NewInsertOneModel().SetDocument(bson.D{{"_id", i}}),
+		})
+	}
+	newServerDesc := func() description.Server {
+		return description.Server{
+			WireVersion:     &description.VersionRange{Max: 25},
+			MaxBatchCount:   100000,
+			MaxDocumentSize: 16 * 1024 * 1024,
+			MaxMessageSize:  48 * 1000 * 1000,
+		}
+	}
+	bulkWrite := func(t *testing.T, conn *bulkWriteConn, opts *options.ClientBulkWriteOptions) (*ClientBulkWriteResult,
+		error) {
+
+		t.Helper()
+		clientOpts := options.Client()
+		clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		return client.BulkWrite(bgCtx, models, opts)
+	}
+	batchSizes := func(conn *bulkWriteConn) []int {
+		sizes := make([]int, 0, len(conn.batches))
+		for _, batch := range conn.batches {
+			sizes = append(sizes, len(batch))
+		}
+		return sizes
+	}
+	succeed := func(_ int, ops []bsoncore.Document) bson.D {
+		return bulkWriteReply(insertResults(ops)...)
+	}
+	verbose := options.ClientBulkWrite().SetVerboseResults(true)
+
+	t.Run("batches are split by maxWriteBatchSize", func(t *testing.T) {
+		desc := newServerDesc()
+		desc.MaxBatchCount = 2
+		conn := &bulkWriteConn{ChannelConn: &drivertest.ChannelConn{Desc: desc}, respond: succeed}
+		res, err := bulkWrite(t, conn, verbose)
+		assert.Nil(t, err, "BulkWrite error: %v", err)
+
+		want := []int{2, 2, 1}
+		got := batchSizes(conn)
+		assert.Equal(t, want, got, "expected batch sizes %v, got %v", want, got)
+		assert.Equal(t, int64(numModels), res.InsertedCount, "expected InsertedCount %d, got %d", numModels,
+			res.InsertedCount)
+	})
+	t.Run("batches are split by message size", func(t *testing.T) {
+		op, _, _, err := createClientBulkWriteDoc(models[0].Model, 0, bson.DefaultRegistry)
+		assert.Nil(t, err, "createClientBulkWriteDoc error: %v", err)
+
+		// The space for the operations is the maxMessageSizeBytes minus the maxBsonObjectSize reserved for the
+		// command document and 16KiB of overhead. Make room for two and a half operations.
+		desc := newServerDesc()
+		desc.MaxDocumentSize = 1024
+		desc.MaxMessageSize = desc.MaxDocumentSize + 16*1024 + uint32(len(op))*5/2
+		conn := &bulkWriteConn{ChannelConn: &drivertest.ChannelConn{Desc: desc}, respond: succeed}
+		_, err = bulkWrite(t, conn, nil)
+		assert.Nil(t, err, "BulkWrite error: %v", err)
+
+		want := []int{2, 2, 1}
+		got := batchSizes(conn)
+		assert.Equal(t, want, got, "expected batch sizes %v, got %v", want, got)
+	})
+	t.Run("result indexes are offset by the batch", func(t *testing.T) {
+		desc := newServerDesc()
+		desc.MaxBatchCount = 2
+		conn := &bulkWriteConn{ChannelConn: &drivertest.ChannelConn{Desc: desc}, respond: succeed}
+		res, err := bulkWrite(t, conn, verbose)
+		assert.Nil(t, err, "BulkWrite error: %v", err)
+
+		assert.Equal(t, numModels, len(res.InsertResults), "expected %d insert results, got %d", numModels,
+			len(res.InsertResults))
+		for i := 0; i < numModels; i++ {
+			got, ok := res.InsertResults[i]
+			assert.True(t, ok, "expected insert result for index %d", i)
+			assert.Equal(t, int32(i), got.InsertedID, "expected InsertedID %d at index %d, got %v", i, i,
+				got.InsertedID)
+		}
+	})
+	t.Run("ordered write error stops later batches", func(t *testing.T) {
+		desc := newServerDesc()
+		desc.MaxBatchCount = 2
+		conn := &bulkWriteConn{
+			ChannelConn: &drivertest.ChannelConn{Desc: desc},
+			respond: func(_ int, ops []bsoncore.Document) bson.D {
+				// The server stops an ordered bulkWrite at the first error, so only the first result is reported.
+				return bulkWriteReply(insertResults(ops, 1)[:2]...)
+			},
+		}
+		_, err := bulkWrite(t, conn, nil)
+		assert.NotNil(t, err, "expected error, got nil")
+
+		want := []int{2}
+		got := batchSizes(conn)
+		assert.Equal(t, want, got, "expected batch sizes %v, got %v", want, got)
+		exception, ok := err.(ClientBulkWriteException)
+		assert.True(t, ok, "expected error type %T, got %T", ClientBulkWriteException{}, err)
+		assert.Equal(t, 1, len(exception.WriteErrors), "expected 1 write error, got %v", exception.WriteErrors)
+		assert.Equal(t, 11000, exception.WriteErrors[1].Code, "expected write error at index 1, got %v",
+			exception.WriteErrors)
+		assert.Equal(t, int64(1), exception.PartialResult.InsertedCount, "expected InsertedCount 1, got %d",
+			exception.PartialResult.InsertedCount)
+	})
+	t.Run("exception", func(t *testing.T) {
+		t.Run("write errors are keyed by model index", func(t *testing.T) {
+			desc := newServerDesc()
+			desc.MaxBatchCount = 2
+			conn := &bulkWriteConn{
+				ChannelConn: &drivertest.ChannelConn{Desc: desc},
+				respond: func(batch int, ops []bsoncore.Document) bson.D {
+					if batch == 1 {
+						return bulkWriteReply(insertResults(ops, 0)...)
+					}
+					return bulkWriteReply(insertResults(ops)...)
+				},
+			}
+			_, err := bulkWrite(t, conn, options.ClientBulkWrite().SetOrdered(false).SetVerboseResults(true))
+			assert.NotNil(t, err, "expected error, got nil")
+
+			want := []int{2, 2, 1}
+			got := batchSizes(conn)
+			assert.Equal(t, want, got, "expected batch sizes %v, got %v", want, got)
+			exception, ok := err.(ClientBulkWriteException)
+			assert.True(t, ok, "expected error type %T, got %T", ClientBulkWriteException{}, err)
+			assert.Nil(t, exception.TopLevelError, "expected no top level error, got %v", exception.TopLevelError)
+			assert.Equal(t, 1, len(exception.WriteErrors), "expected 1 write error, got %v", exception.WriteErrors)
+			we, ok := exception.WriteErrors[2]
+			assert.True(t, ok, "expected write error at index 2, got %v", exception.WriteErrors)
+			assert.Equal(t, 2, we.Index, "expected write error index 2, got %d", we.Index)
+
+			partial := exception.PartialResult
+			assert.NotNil(t, partial, "expected partial result, got nil")
+			assert.Equal(t, int64(numModels-1), partial.InsertedCount, "expected InsertedCount %d, got %d",
+				numModels-1, partial.InsertedCount)
+			_, ok = partial.InsertResults[2]
+			assert.False(t, ok, "expected no insert result for the failed model")
+			assert.Equal(t, numModels-1, len(partial.InsertResults), "expected %d insert results, got %d",
+				numModels-1, len(partial.InsertResults))
+		})
+		t.Run("write concern errors", func(t *testing.T) {
+			conn := &bulkWriteConn{
+				ChannelConn: &drivertest.ChannelConn{Desc: newServerDesc()},
+				respond: func(_ int, ops []bsoncore.Document) bson.D {
+					reply := bulkWriteReply(insertResults(ops)...)
+					return append(reply, bson.E{"writeConcernError", bson.D{
+						{"code", 64},
+						{"errmsg", "waiting for replication timed out"},
+					}})
+				},
+			}
+			_, err := bulkWrite(t, conn, nil)
+			assert.NotNil(t, err, "expected error, got nil")
+
+			exception, ok := err.(ClientBulkWriteException)
+			assert.True(t, ok, "expected error type %T, got %T", ClientBulkWriteException{}, err)
+			assert.Equal(t, 1, len(exception.WriteConcernErrors), "expected 1 write concern error, got %v",
+				exception.WriteConcernErrors)
+			assert.Equal(t, 64, exception.WriteConcernErrors[0].Code, "expected code 64, got %d",
+				exception.WriteConcernErrors[0].Code)
+			assert.Equal(t, 0, len(exception.WriteErrors), "expected no write errors, got %v", exception.WriteErrors)
+			assert.NotNil(t, exception.PartialResult, "expected partial result, got nil")
+			assert.Equal(t, int64(numModels), exception.PartialResult.InsertedCount,
+				"expected InsertedCount %d, got %d", numModels, exception.PartialResult.InsertedCount)
+		})
+		t.Run("top level error after a completed batch", func(t *testing.T) {
+			desc := newServerDesc()
+			desc.MaxBatchCount = 2
+			conn := &bulkWriteConn{
+				ChannelConn: &drivertest.ChannelConn{Desc: desc},
+				respond: func(batch int, ops []bsoncore.Document) bson.D {
+					if batch == 1 {
+						return bson.D{{"ok", 0}, {"code", 2}, {"errmsg", "bad value"}}
+					}
+					return bulkWriteReply(insertResults(ops)...)
+				},
+			}
+			_, err := bulkWrite(t, conn, nil)
+			assert.NotNil(t, err, "expected error, got nil")
+
+			exception, ok := err.(ClientBulkWriteException)
+			assert.True(t, ok, "expected error type %T, got %T", ClientBulkWriteException{}, err)
+			cmdErr, ok := exception.TopLevelError.(CommandError)
+			assert.True(t, ok, "expected top level error type %T, got %T", CommandError{}, exception.TopLevelError)
+			assert.Equal(t, int32(2), cmdErr.Code, "expected code 2, got %d", cmdErr.Code)
+			assert.Equal(t, int64(2), exception.PartialResult.InsertedCount, "expected InsertedCount 2, got %d",
+				exception.PartialResult.InsertedCount)
+		})
+		t.Run("no partial result before the first batch completes", func(t *testing.T) {
+			conn := &bulkWriteConn{
+				ChannelConn: &drivertest.ChannelConn{Desc: newServerDesc()},
+				respond: func(int, []bsoncore.Document) bson.D {
+					return bson.D{{"ok", 0}, {"code", 2}, {"errmsg", "bad value"}}
+				},
+			}
+			_, err := bulkWrite(t, conn, nil)
+			_, ok := err.(CommandError)
+			assert.True(t, ok, "expected error type %T, got %T", CommandError{}, err)
+		})
+	})
+}
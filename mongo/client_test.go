@@ -93,6 +93,12 @@ func TestClient(t *testing.T) {
 
 		_, err = client.ListDatabaseNames(bgCtx, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+		_, err = client.BulkWrite(bgCtx, nil)
+		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+
+		_, err = client.BulkWrite(bgCtx, []ClientBulkWrite{{Database: "foo", Collection: "bar"}})
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
 	t.Run("read preference", func(t *testing.T) {
 		t.Run("absent", func(t *testing.T) {
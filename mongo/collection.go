@@ -38,6 +38,7 @@ type Collection struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	timeout        *time.Duration
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -54,9 +55,28 @@ type aggregateParams struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	readPreference *readpref.ReadPref
+	timeout        *time.Duration
+	explain        *explainer
 	opts           []*options.AggregateOptions
 }
 
+// explainer is passed to the helpers that build CRUD operations to run an explain command for the operation instead
+// of executing it.
+type explainer struct {
+	verbosity options.ExplainVerbosity
+	result    *ExplainResult
+}
+
+func (e *explainer) run(ctx context.Context, op operation.Explainable) error {
+	explainOp := operation.NewExplain(string(e.verbosity), op)
+	if err := explainOp.Execute(ctx); err != nil {
+		return replaceErrors(err)
+	}
+
+	e.result = new(ExplainResult)
+	return bson.Unmarshal(explainOp.Result(), e.result)
+}
+
 func closeImplicitSession(sess *session.Client) {
 	if sess != nil && sess.SessionType == session.Implicit {
 		sess.EndSession()
@@ -86,6 +106,11 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		reg = collOpt.Registry
 	}
 
+	to := db.timeout
+	if collOpt.Timeout != nil {
+		to = collOpt.Timeout
+	}
+
 	readSelector := description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(rp),
 		description.LatencySelector(db.client.localThreshold),
@@ -106,6 +131,7 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		readSelector:   readSelector,
 		writeSelector:  writeSelector,
 		registry:       reg,
+		timeout:        to,
 	}
 
 	return coll
@@ -122,6 +148,7 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		timeout:        coll.timeout,
 	}
 }
 
@@ -148,6 +175,10 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 		copyColl.registry = optsColl.Registry
 	}
 
+	if optsColl.Timeout != nil {
+		copyColl.timeout = optsColl.Timeout
+	}
+
 	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(copyColl.readPreference),
 		description.LatencySelector(copyColl.client.localThreshold),
@@ -184,6 +215,16 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		ctx = context.Background()
 	}
 
+	// Each batch is executed as a separate operation, so the deadline is derived here to bound the whole bulk write
+	// rather than every batch.
+	if coll.timeout != nil && *coll.timeout > 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *coll.timeout)
+			defer cancel()
+		}
+	}
+
 	sess := sessionFromContext(ctx)
 	if sess == nil && coll.client.sessionPool != nil {
 		var err error
@@ -280,7 +321,7 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	imo := options.MergeInsertManyOptions(opts...)
 	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
@@ -389,7 +430,7 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 }
 
 func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOne bool, expectedRr returnResult,
-	opts ...*options.DeleteOptions) (*DeleteResult, error) {
+	explain *explainer, opts ...*options.DeleteOptions) (*DeleteResult, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -450,7 +491,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	if do.Hint != nil {
 		op = op.Hint(true)
 	}
@@ -461,6 +502,9 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		retryMode = driver.RetryOncePerCommand
 	}
 	op = op.Retry(retryMode)
+	if explain != nil {
+		return nil, explain.run(ctx, op)
+	}
 	rr, err := processWriteError(op.Execute(ctx))
 	if rr&expectedRr == 0 {
 		return nil, err
@@ -481,7 +525,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 	opts ...*options.DeleteOptions) (*DeleteResult, error) {
 
-	return coll.delete(ctx, filter, true, rrOne, opts...)
+	return coll.delete(ctx, filter, true, rrOne, nil, opts...)
 }
 
 // DeleteMany executes a delete command to delete documents from the collection.
@@ -497,11 +541,11 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 	opts ...*options.DeleteOptions) (*DeleteResult, error) {
 
-	return coll.delete(ctx, filter, false, rrMany, opts...)
+	return coll.delete(ctx, filter, false, rrMany, nil, opts...)
 }
 
 func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Document, update interface{}, multi bool,
-	expectedRr returnResult, checkDollarKey bool, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	expectedRr returnResult, checkDollarKey bool, explain *explainer, opts ...*options.UpdateOptions) (*UpdateResult, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
@@ -547,7 +591,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Hint(uo.Hint != nil).
-		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI)
+		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 
 	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
@@ -558,6 +602,9 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		retry = driver.RetryOncePerCommand
 	}
 	op = op.Retry(retry)
+	if explain != nil {
+		return nil, explain.run(ctx, op)
+	}
 	err = op.Execute(ctx)
 
 	rr, err := processWriteError(err)
@@ -626,7 +673,7 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 		return nil, err
 	}
 
-	return coll.updateOrReplace(ctx, f, update, false, rrOne, true, opts...)
+	return coll.updateOrReplace(ctx, f, update, false, rrOne, true, nil, opts...)
 }
 
 // UpdateMany executes an update command to update documents in the collection.
@@ -654,7 +701,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		return nil, err
 	}
 
-	return coll.updateOrReplace(ctx, f, update, true, rrMany, true, opts...)
+	return coll.updateOrReplace(ctx, f, update, true, rrMany, true, nil, opts...)
 }
 
 // ReplaceOne executes an update command to replace at most one document in the collection.
@@ -701,7 +748,7 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		updateOptions = append(updateOptions, uOpts)
 	}
 
-	return coll.updateOrReplace(ctx, f, r, false, rrOne, false, updateOptions...)
+	return coll.updateOrReplace(ctx, f, r, false, rrOne, false, nil, updateOptions...)
 }
 
 // Aggregate executes an aggregate command against the collection and returns a cursor over the resulting documents.
@@ -717,7 +764,14 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 // For more information about the command, see https://docs.mongodb.com/manual/reference/command/aggregate/.
 func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	opts ...*options.AggregateOptions) (*Cursor, error) {
-	a := aggregateParams{
+
+	return aggregate(coll.newAggregateParams(ctx, pipeline, opts))
+}
+
+func (coll *Collection) newAggregateParams(ctx context.Context, pipeline interface{},
+	opts []*options.AggregateOptions) aggregateParams {
+
+	return aggregateParams{
 		ctx:            ctx,
 		pipeline:       pipeline,
 		client:         coll.client,
@@ -730,9 +784,9 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		readPreference: coll.readPreference,
+		timeout:        coll.timeout,
 		opts:           opts,
 	}
-	return aggregate(a)
 }
 
 // aggreate is the helper method for Aggregate
@@ -779,6 +833,7 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 
 	ao := options.MergeAggregateOptions(a.opts...)
 	cursorOpts := a.client.createBaseCursorOptions()
+	cursorOpts.Timeout = a.timeout
 
 	op := operation.NewAggregate(pipelineArr).
 		Session(sess).
@@ -792,7 +847,7 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 		Collection(a.col).
 		Deployment(a.client.deployment).
 		Crypt(a.client.cryptFLE).
-		ServerAPI(a.client.serverAPI).
+		ServerAPI(a.client.serverAPI).Timeout(a.timeout).
 		HasOutputStage(hasOutputStage)
 
 	if ao.AllowDiskUse != nil {
@@ -841,6 +896,12 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 	}
 	op = op.Retry(retry)
 
+	if a.explain != nil {
+		err = a.explain.run(a.ctx, op)
+		closeImplicitSession(sess)
+		return nil, err
+	}
+
 	err = op.Execute(a.ctx)
 	if err != nil {
 		closeImplicitSession(sess)
@@ -870,6 +931,12 @@ func aggregate(a aggregateParams) (*Cursor, error) {
 func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	opts ...*options.CountOptions) (int64, error) {
 
+	return coll.countDocuments(ctx, filter, nil, opts...)
+}
+
+func (coll *Collection) countDocuments(ctx context.Context, filter interface{}, explain *explainer,
+	opts ...*options.CountOptions) (int64, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -901,7 +968,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
-		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	if countOpts.Collation != nil {
 		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
 	}
@@ -921,6 +988,10 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	}
 	op = op.Retry(retry)
 
+	if explain != nil {
+		return 0, explain.run(ctx, op)
+	}
+
 	err = op.Execute(ctx)
 	if err != nil {
 		return 0, replaceErrors(err)
@@ -954,6 +1025,12 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
 
+	return coll.estimatedDocumentCount(ctx, nil, opts...)
+}
+
+func (coll *Collection) estimatedDocumentCount(ctx context.Context, explain *explainer,
+	opts ...*options.EstimatedDocumentCountOptions) (int64, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -983,7 +1060,7 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	op := operation.NewCount().Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 
 	co := options.MergeEstimatedDocumentCountOptions(opts...)
 	if co.MaxTime != nil {
@@ -995,6 +1072,10 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	}
 	op.Retry(retry)
 
+	if explain != nil {
+		return 0, explain.run(ctx, op)
+	}
+
 	err = op.Execute(ctx)
 
 	return op.Result().N, replaceErrors(err)
@@ -1013,6 +1094,12 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter interface{},
 	opts ...*options.DistinctOptions) ([]interface{}, error) {
 
+	return coll.distinct(ctx, fieldName, filter, nil, opts...)
+}
+
+func (coll *Collection) distinct(ctx context.Context, fieldName string, filter interface{}, explain *explainer,
+	opts ...*options.DistinctOptions) ([]interface{}, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -1049,7 +1136,7 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 
 	if option.Collation != nil {
 		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
@@ -1063,6 +1150,10 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	}
 	op = op.Retry(retry)
 
+	if explain != nil {
+		return nil, explain.run(ctx, op)
+	}
+
 	err = op.Execute(ctx)
 	if err != nil {
 		return nil, replaceErrors(err)
@@ -1102,6 +1193,12 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 func (coll *Collection) Find(ctx context.Context, filter interface{},
 	opts ...*options.FindOptions) (*Cursor, error) {
 
+	return coll.find(ctx, filter, nil, opts...)
+}
+
+func (coll *Collection) find(ctx context.Context, filter interface{}, explain *explainer,
+	opts ...*options.FindOptions) (*Cursor, error) {
+
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -1136,10 +1233,11 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).
 		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI)
+		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 
 	fo := options.MergeFindOptions(opts...)
 	cursorOpts := coll.client.createBaseCursorOptions()
+	cursorOpts.Timeout = coll.timeout
 
 	if fo.AllowDiskUse != nil {
 		op.AllowDiskUse(*fo.AllowDiskUse)
@@ -1245,6 +1343,12 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	}
 	op = op.Retry(retry)
 
+	if explain != nil {
+		err = explain.run(ctx, op)
+		closeImplicitSession(sess)
+		return nil, err
+	}
+
 	if err = op.Execute(ctx); err != nil {
 		closeImplicitSession(sess)
 		return nil, replaceErrors(err)
@@ -1378,7 +1482,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		return &SingleResult{err: err}
 	}
 	fod := options.MergeFindOneAndDeleteOptions(opts...)
-	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI)
+	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	if fod.Collation != nil {
 		op = op.Collation(bsoncore.Document(fod.Collation.ToDocument()))
 	}
@@ -1441,7 +1545,7 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 
 	fo := options.MergeFindOneAndReplaceOptions(opts...)
 	op := operation.NewFindAndModify(f).Update(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: r}).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	if fo.BypassDocumentValidation != nil && *fo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*fo.BypassDocumentValidation)
 	}
@@ -1510,7 +1614,7 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	}
 
 	fo := options.MergeFindOneAndUpdateOptions(opts...)
-	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI)
+	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 
 	u, err := transformUpdateValue(coll.registry, update, true)
 	if err != nil {
@@ -1565,6 +1669,176 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	return coll.findAndModify(ctx, op)
 }
 
+// ExplainFind executes an explain command (https://docs.mongodb.com/manual/reference/command/explain/) for the find
+// command that Find would execute with the same filter and options and returns the explain output. The find command
+// itself is not run as a query and no Cursor is created.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See Find for a description of the filter and opts parameters.
+func (coll *Collection) ExplainFind(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	opts ...*options.FindOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.find(ctx, filter, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainAggregate executes an explain command for the aggregate command that Aggregate would execute with the same
+// pipeline and options and returns the explain output. Pipelines containing $out or $merge stages are explained but
+// no documents are written.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See Aggregate for a description of the pipeline and opts parameters.
+func (coll *Collection) ExplainAggregate(ctx context.Context, verbosity options.ExplainVerbosity, pipeline interface{},
+	opts ...*options.AggregateOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	a := coll.newAggregateParams(ctx, pipeline, opts)
+	a.explain = explain
+	if _, err := aggregate(a); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainCountDocuments executes an explain command for the aggregate command that CountDocuments would execute with
+// the same filter and options and returns the explain output.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See CountDocuments for a description of the filter and opts parameters.
+func (coll *Collection) ExplainCountDocuments(ctx context.Context, verbosity options.ExplainVerbosity,
+	filter interface{}, opts ...*options.CountOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.countDocuments(ctx, filter, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainEstimatedDocumentCount executes an explain command for the count command that EstimatedDocumentCount would
+// execute with the same options and returns the explain output.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+func (coll *Collection) ExplainEstimatedDocumentCount(ctx context.Context, verbosity options.ExplainVerbosity,
+	opts ...*options.EstimatedDocumentCountOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.estimatedDocumentCount(ctx, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainDistinct executes an explain command for the distinct command that Distinct would execute with the same
+// arguments and returns the explain output.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See Distinct for a description of the fieldName, filter, and opts parameters.
+func (coll *Collection) ExplainDistinct(ctx context.Context, verbosity options.ExplainVerbosity, fieldName string,
+	filter interface{}, opts ...*options.DistinctOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.distinct(ctx, fieldName, filter, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainUpdateOne executes an explain command for the update command that UpdateOne would execute with the same
+// arguments and returns the explain output. No documents are modified, even with the options.ExplainExecutionStats
+// and options.ExplainAllPlansExecution verbosities.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See UpdateOne for a description of the filter, update, and opts parameters.
+func (coll *Collection) ExplainUpdateOne(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	update interface{}, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	return coll.explainUpdate(ctx, verbosity, filter, update, false, opts...)
+}
+
+// ExplainUpdateMany executes an explain command for the update command that UpdateMany would execute with the same
+// arguments and returns the explain output. No documents are modified, even with the options.ExplainExecutionStats
+// and options.ExplainAllPlansExecution verbosities.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See UpdateMany for a description of the filter, update, and opts parameters.
+func (coll *Collection) ExplainUpdateMany(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	update interface{}, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	return coll.explainUpdate(ctx, verbosity, filter, update, true, opts...)
+}
+
+func (coll *Collection) explainUpdate(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	update interface{}, multi bool, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err = coll.updateOrReplace(ctx, f, update, multi, rrAll, true, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainDeleteOne executes an explain command for the delete command that DeleteOne would execute with the same
+// arguments and returns the explain output. No documents are deleted, even with the options.ExplainExecutionStats
+// and options.ExplainAllPlansExecution verbosities.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See DeleteOne for a description of the filter and opts parameters.
+func (coll *Collection) ExplainDeleteOne(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	opts ...*options.DeleteOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.delete(ctx, filter, true, rrAll, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
+// ExplainDeleteMany executes an explain command for the delete command that DeleteMany would execute with the same
+// arguments and returns the explain output. No documents are deleted, even with the options.ExplainExecutionStats
+// and options.ExplainAllPlansExecution verbosities.
+//
+// The verbosity parameter specifies how much information the explain output should contain (see the
+// options.ExplainVerbosity documentation).
+//
+// See DeleteMany for a description of the filter and opts parameters.
+func (coll *Collection) ExplainDeleteMany(ctx context.Context, verbosity options.ExplainVerbosity, filter interface{},
+	opts ...*options.DeleteOptions) (*ExplainResult, error) {
+
+	explain := &explainer{verbosity: verbosity}
+	if _, err := coll.delete(ctx, filter, false, rrAll, explain, opts...); err != nil {
+		return nil, err
+	}
+	return explain.result, nil
+}
+
 // Watch returns a change stream for all changes on the corresponding collection. See
 // https://docs.mongodb.com/manual/changeStreams/ for more information about change streams.
 //
@@ -1590,6 +1864,7 @@ func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 		collectionName: coll.Name(),
 		databaseName:   coll.db.Name(),
 		crypt:          coll.client.cryptFLE,
+		timeout:        coll.timeout,
 	}
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
@@ -1636,7 +1911,7 @@ func (coll *Collection) Drop(ctx context.Context) error {
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).
-		ServerAPI(coll.client.serverAPI)
+		ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
 	err = op.Execute(ctx)
 
 	// ignore namespace not found erorrs
@@ -1647,6 +1922,55 @@ func (coll *Collection) Drop(ctx context.Context) error {
 	return nil
 }
 
+// Rename executes a renameCollection command to rename the collection to newName in the database newDatabase. The
+// newDatabase parameter can be the name of the collection's current database or of another database in the same
+// deployment. If dropTarget is true, an existing collection named newName in newDatabase is dropped before the
+// rename; otherwise the rename fails if that collection exists.
+//
+// The Collection instance is not modified and will continue to refer to the old name. Use
+// Client.Database(newDatabase).Collection(newName) to access the renamed collection.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/renameCollection/.
+func (coll *Collection) Rename(ctx context.Context, newDatabase, newName string, dropTarget bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && coll.client.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(coll.client.sessionPool, coll.client.id, session.Implicit)
+		if err != nil {
+			return err
+		}
+		defer sess.EndSession()
+	}
+
+	err := coll.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	wc := coll.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, coll.writeSelector)
+
+	op := operation.NewRenameCollection(coll.db.name+"."+coll.name, newDatabase+"."+newName).
+		DropTarget(dropTarget).
+		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
+		ServerSelector(selector).ClusterClock(coll.client.clock).
+		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).
+		ServerAPI(coll.client.serverAPI).Timeout(coll.timeout)
+
+	return replaceErrors(op.Execute(ctx))
+}
+
 // makePinnedSelector makes a selector for a pinned session with a pinned server. Will attempt to do server selection on
 // the pinned server but if that fails it will go through a list of default selectors
 func makePinnedSelector(sess *session.Client, defaultSelector description.ServerSelector) description.ServerSelectorFunc {
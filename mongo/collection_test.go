@@ -9,13 +9,18 @@ package mongo
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
 )
 
 const (
@@ -34,6 +39,8 @@ func compareColls(t *testing.T, expected *Collection, got *Collection) {
 		"mismatch; expected read concern %v, got %v", expected.readConcern, got.readConcern)
 	assert.Equal(t, expected.writeConcern, got.writeConcern,
 		"mismatch; expected write concern %v, got %v", expected.writeConcern, got.writeConcern)
+	assert.Equal(t, expected.timeout, got.timeout,
+		"mismatch; expected timeout %v, got %v", expected.timeout, got.timeout)
 }
 
 func TestCollection(t *testing.T) {
@@ -50,13 +57,15 @@ func TestCollection(t *testing.T) {
 		wc2 := writeconcern.New(writeconcern.W(10))
 		rcLocal := readconcern.Local()
 		rcMajority := readconcern.Majority()
+		timeout := 5 * time.Second
 
 		opts := options.Collection().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetWriteConcern(wc1).
-			SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2)
+			SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2).SetTimeout(timeout)
 		expected := &Collection{
 			readConcern:    rcMajority,
 			readPreference: rpSecondary,
 			writeConcern:   wc2,
+			timeout:        &timeout,
 		}
 		got := setupColl("foo", opts)
 		compareColls(t, expected, got)
@@ -65,13 +74,15 @@ func TestCollection(t *testing.T) {
 		rpPrimary := readpref.Primary()
 		rcLocal := readconcern.Local()
 		wc1 := writeconcern.New(writeconcern.W(10))
+		timeout := 5 * time.Second
 
-		db := setupDb("foo", options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal))
+		db := setupDb("foo", options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetTimeout(timeout))
 		coll := db.Collection("bar", options.Collection().SetWriteConcern(wc1))
 		expected := &Collection{
 			readPreference: rpPrimary,
 			readConcern:    rcLocal,
 			writeConcern:   wc1,
+			timeout:        &timeout,
 		}
 		compareColls(t, expected, coll)
 	})
@@ -213,4 +224,55 @@ func TestCollection(t *testing.T) {
 		_, err = coll.Watch(bgCtx, nil)
 		assert.Equal(t, aggErr, err, "expected error %v, got %v", aggErr, err)
 	})
+	t.Run("bulk write batches share one deadline", func(t *testing.T) {
+		conn := &drivertest.ChannelConn{
+			Written:  make(chan []byte, 1),
+			ReadResp: make(chan []byte, 1),
+			Desc: description.Server{
+				WireVersion:     &description.VersionRange{Max: 13},
+				MaxDocumentSize: 16 * 1024 * 1024,
+				MaxMessageSize:  48 * 1000 * 1000,
+				MaxBatchCount:   100000,
+			},
+		}
+		clientOpts := options.Client().SetTimeout(time.Second)
+		clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		coll := client.Database(testDbName).Collection("foo")
+
+		// Insert, delete, insert is executed as three batches. Every reply takes 100ms, so the maxTimeMS of the last
+		// batch is only below 900ms if all batches count against the same deadline.
+		models := []WriteModel{
+			NewInsertOneModel().SetDocument(bson.D{{"x", 1}}),
+			NewDeleteOneModel().SetFilter(bson.D{{"x", 1}}),
+			NewInsertOneModel().SetDocument(bson.D{{"x", 2}}),
+		}
+		reply := drivertest.MakeReply(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+			bsoncore.AppendInt32Element(nil, "n", 1),
+		))
+		maxTimes := make(chan int64, len(models))
+		go func() {
+			for range models {
+				cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				if err != nil {
+					maxTimes <- 0
+				} else {
+					maxTimes <- cmd.Lookup("maxTimeMS").Int64()
+				}
+				time.Sleep(100 * time.Millisecond)
+				conn.ReadResp <- reply
+			}
+		}()
+
+		_, err = coll.BulkWrite(bgCtx, models)
+		assert.Nil(t, err, "BulkWrite error: %v", err)
+		var last int64
+		for range models {
+			last = <-maxTimes
+			assert.True(t, last > 0 && last <= 1000, "expected maxTimeMS in range (0, 1000], got %d", last)
+		}
+		assert.True(t, last < 900, "expected maxTimeMS of the last batch to be below 900, got %d", last)
+	})
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -39,6 +40,7 @@ type Database struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	timeout        *time.Duration
 }
 
 func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions) *Database {
@@ -64,6 +66,11 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		reg = dbOpt.Registry
 	}
 
+	to := client.timeout
+	if dbOpt.Timeout != nil {
+		to = dbOpt.Timeout
+	}
+
 	db := &Database{
 		client:         client,
 		name:           name,
@@ -71,6 +78,7 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		readConcern:    rc,
 		writeConcern:   wc,
 		registry:       reg,
+		timeout:        to,
 	}
 
 	db.readSelector = description.CompositeSelector([]description.ServerSelector{
@@ -127,6 +135,7 @@ func (db *Database) Aggregate(ctx context.Context, pipeline interface{},
 		readSelector:   db.readSelector,
 		writeSelector:  db.writeSelector,
 		readPreference: db.readPreference,
+		timeout:        db.timeout,
 		opts:           opts,
 	}
 	return aggregate(a)
@@ -169,6 +178,7 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	switch cursorCommand {
 	case true:
 		cursorOpts := db.client.createBaseCursorOptions()
+		cursorOpts.Timeout = db.timeout
 		op = operation.NewCursorCommand(runCmdDoc, cursorOpts)
 	default:
 		op = operation.NewCommand(runCmdDoc)
@@ -176,7 +186,7 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 	return op.Session(sess).CommandMonitor(db.client.monitor).
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).ReadConcern(db.readConcern).
-		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI), sess, nil
+		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI).Timeout(db.timeout), sess, nil
 }
 
 // RunCommand executes the given command against the database. This function does not obey the Database's read
@@ -281,7 +291,7 @@ func (db *Database) Drop(ctx context.Context) error {
 		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
-		ServerAPI(db.client.serverAPI)
+		ServerAPI(db.client.serverAPI).Timeout(db.timeout)
 
 	err = op.Execute(ctx)
 
@@ -372,9 +382,10 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		Session(sess).ReadPreference(db.readPreference).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
-		ServerAPI(db.client.serverAPI)
+		ServerAPI(db.client.serverAPI).Timeout(db.timeout)
 
 	cursorOpts := db.client.createBaseCursorOptions()
+	cursorOpts.Timeout = db.timeout
 	if lco.NameOnly != nil {
 		op = op.NameOnly(*lco.NameOnly)
 	}
@@ -489,6 +500,7 @@ func (db *Database) Watch(ctx context.Context, pipeline interface{},
 		streamType:     DatabaseStream,
 		databaseName:   db.Name(),
 		crypt:          db.client.cryptFLE,
+		timeout:        db.timeout,
 	}
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
@@ -503,7 +515,17 @@ func (db *Database) Watch(ctx context.Context, pipeline interface{},
 // For more information about the command, see https://docs.mongodb.com/manual/reference/command/create/.
 func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*options.CreateCollectionOptions) error {
 	cco := options.MergeCreateCollectionOptions(opts...)
-	op := operation.NewCreate(name).ServerAPI(db.client.serverAPI)
+	if cco.Pipeline != nil && cco.ViewOn == nil {
+		return errors.New("the Pipeline option requires the ViewOn option to be set")
+	}
+	if cco.ViewOn != nil && (cco.Capped != nil || cco.ClusteredIndex != nil || cco.EncryptedFields != nil ||
+		cco.MaxDocuments != nil || cco.SizeInBytes != nil || cco.TimeSeriesOptions != nil) {
+
+		return errors.New("the Capped, ClusteredIndex, EncryptedFields, MaxDocuments, SizeInBytes, and " +
+			"TimeSeriesOptions options cannot be used when creating a view")
+	}
+
+	op := operation.NewCreate(name).ServerAPI(db.client.serverAPI).Timeout(db.timeout)
 
 	if cco.Capped != nil {
 		op.Capped(*cco.Capped)
@@ -575,10 +597,104 @@ func (db *Database) CreateCollection(ctx context.Context, name string, opts ...*
 
 		op.TimeSeries(doc)
 	}
+	if cco.ClusteredIndex != nil {
+		clusteredIndex, err := db.createClusteredIndexDoc(cco.ClusteredIndex)
+		if err != nil {
+			return err
+		}
+		op.ClusteredIndex(clusteredIndex)
+	}
+	if cco.ChangeStreamPreAndPostImages != nil {
+		op.ChangeStreamPreAndPostImages(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendBooleanElement(nil, "enabled", *cco.ChangeStreamPreAndPostImages),
+		))
+	}
+	if cco.ViewOn != nil {
+		op.ViewOn(*cco.ViewOn)
+	}
+	if cco.Pipeline != nil {
+		pipeline, _, err := transformAggregatePipeline(db.registry, cco.Pipeline)
+		if err != nil {
+			return err
+		}
+		op.Pipeline(pipeline)
+	}
+	if cco.EncryptedFields != nil {
+		encryptedFields, err := transformBsoncoreDocument(db.registry, cco.EncryptedFields, true, "encryptedFields")
+		if err != nil {
+			return err
+		}
+		op.EncryptedFields(encryptedFields)
+		return db.createEncryptedCollection(ctx, name, op, encryptedFields)
+	}
 
 	return db.executeCreateOperation(ctx, op)
 }
 
+// createClusteredIndexDoc builds the clusteredIndex document for a create command. The key defaults to {_id: 1} and
+// the index is always unique.
+func (db *Database) createClusteredIndexDoc(cio *options.ClusteredIndexOptions) (bsoncore.Document, error) {
+	key := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "_id", 1))
+	if cio.Key != nil {
+		var err error
+		key, err = transformBsoncoreDocument(db.registry, cio.Key, true, "key")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendDocumentElement(doc, "key", key)
+	doc = bsoncore.AppendBooleanElement(doc, "unique", true)
+	if cio.Name != nil {
+		doc = bsoncore.AppendStringElement(doc, "name", *cio.Name)
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
+// createEncryptedCollection creates a collection that uses Queryable Encryption. The clustered collections that hold
+// the encryption state are created first, using the names in encryptedFields if they are specified, and the index on
+// the __safeContent__ field is created once the collection itself exists.
+func (db *Database) createEncryptedCollection(ctx context.Context, name string, op *operation.Create,
+	encryptedFields bsoncore.Document) error {
+
+	stateCollections := []struct {
+		field  string
+		suffix string
+	}{
+		{"escCollection", "esc"},
+		{"eccCollection", "ecc"},
+		{"ecocCollection", "ecoc"},
+	}
+	clusteredIndex, err := db.createClusteredIndexDoc(options.ClusteredIndex())
+	if err != nil {
+		return err
+	}
+
+	for _, sc := range stateCollections {
+		stateName := "enxcol_." + name + "." + sc.suffix
+		if val, err := encryptedFields.LookupErr(sc.field); err == nil {
+			str, ok := val.StringValueOK()
+			if !ok {
+				return fmt.Errorf("the %s field in encryptedFields must be a string, got %v", sc.field, val.Type)
+			}
+			stateName = str
+		}
+
+		stateOp := operation.NewCreate(stateName).ClusteredIndex(clusteredIndex).
+			ServerAPI(db.client.serverAPI).Timeout(db.timeout)
+		if err := db.executeCreateOperation(ctx, stateOp); err != nil {
+			return err
+		}
+	}
+
+	if err := db.executeCreateOperation(ctx, op); err != nil {
+		return err
+	}
+	_, err = db.Collection(name).Indexes().CreateOne(ctx, IndexModel{Keys: bson.D{{"__safeContent__", 1}}})
+	return err
+}
+
 // CreateView executes a create command to explicitly create a view on the server. See
 // https://docs.mongodb.com/manual/core/views/ for more information about views. This method requires driver version >=
 // 1.4.0 and MongoDB version >= 3.4.
@@ -603,7 +719,7 @@ func (db *Database) CreateView(ctx context.Context, viewName, viewOn string, pip
 	op := operation.NewCreate(viewName).
 		ViewOn(viewOn).
 		Pipeline(pipelineArray).
-		ServerAPI(db.client.serverAPI)
+		ServerAPI(db.client.serverAPI).Timeout(db.timeout)
 	cvo := options.MergeCreateViewOptions(opts...)
 	if cvo.Collation != nil {
 		op.Collation(bsoncore.Document(cvo.Collation.ToDocument()))
@@ -612,6 +728,113 @@ func (db *Database) CreateView(ctx context.Context, viewName, viewOn string, pip
 	return db.executeCreateOperation(ctx, op)
 }
 
+// ModifyCollection executes a collMod command to change the options of an existing collection or view with the
+// specified name.
+//
+// The opts parameter can be used to specify the changes to make (see the options.ModifyCollectionOptions
+// documentation). Options that are not set are left unchanged on the server.
+//
+// For more information about the command, see https://docs.mongodb.com/manual/reference/command/collMod/.
+func (db *Database) ModifyCollection(ctx context.Context, name string, opts ...*options.ModifyCollectionOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	mco := options.MergeModifyCollectionOptions(opts...)
+	op := operation.NewModifyCollection(name).ServerAPI(db.client.serverAPI).Timeout(db.timeout)
+
+	if mco.Validator != nil {
+		validator, err := transformBsoncoreDocument(db.registry, mco.Validator, true, "validator")
+		if err != nil {
+			return err
+		}
+		op.Validator(validator)
+	}
+	if mco.ValidationLevel != nil {
+		op.ValidationLevel(*mco.ValidationLevel)
+	}
+	if mco.ValidationAction != nil {
+		op.ValidationAction(*mco.ValidationAction)
+	}
+	if mco.ExpireAfterSeconds != nil {
+		op.ExpireAfterSeconds(*mco.ExpireAfterSeconds)
+	}
+	if mco.Index != nil {
+		index, err := db.createModifyIndexDoc(mco.Index)
+		if err != nil {
+			return err
+		}
+		op.Index(index)
+	}
+	if mco.TimeSeriesGranularity != nil {
+		op.TimeSeries(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "granularity", *mco.TimeSeriesGranularity),
+		))
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && db.client.sessionPool != nil {
+		var err error
+		sess, err = session.NewClientSession(db.client.sessionPool, db.client.id, session.Implicit)
+		if err != nil {
+			return err
+		}
+		defer sess.EndSession()
+	}
+
+	err := db.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	wc := db.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, db.writeSelector)
+	op = op.Session(sess).
+		WriteConcern(wc).
+		CommandMonitor(db.client.monitor).
+		ServerSelector(selector).
+		ClusterClock(db.client.clock).
+		Database(db.name).
+		Deployment(db.client.deployment).
+		Crypt(db.client.cryptFLE)
+
+	return replaceErrors(op.Execute(ctx))
+}
+
+// createModifyIndexDoc creates the "index" document for a collMod command. The index is identified by either its
+// keyPattern or its name.
+func (db *Database) createModifyIndexDoc(mio *options.ModifyIndexOptions) (bsoncore.Document, error) {
+	if (mio.Name == nil) == (mio.Keys == nil) {
+		return nil, errors.New("exactly one of Name and Keys must be specified to modify an index")
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	if mio.Name != nil {
+		doc = bsoncore.AppendStringElement(doc, "name", *mio.Name)
+	}
+	if mio.Keys != nil {
+		keys, err := transformBsoncoreDocument(db.registry, mio.Keys, false, "keys")
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendDocumentElement(doc, "keyPattern", keys)
+	}
+	if mio.ExpireAfterSeconds != nil {
+		doc = bsoncore.AppendInt64Element(doc, "expireAfterSeconds", *mio.ExpireAfterSeconds)
+	}
+	if mio.Hidden != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "hidden", *mio.Hidden)
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
 func (db *Database) executeCreateOperation(ctx context.Context, op *operation.Create) error {
 	sess := sessionFromContext(ctx)
 	if sess == nil && db.client.sessionPool != nil {
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
@@ -18,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func setupDb(name string, opts ...*options.DatabaseOptions) *Database {
@@ -35,6 +37,8 @@ func compareDbs(t *testing.T, expected, got *Database) {
 		"expected write concern %v, got %v", expected.writeConcern, got.writeConcern)
 	assert.Equal(t, expected.registry, got.registry,
 		"expected write concern %v, got %v", expected.registry, got.registry)
+	assert.Equal(t, expected.timeout, got.timeout,
+		"expected timeout %v, got %v", expected.timeout, got.timeout)
 }
 
 func TestDatabase(t *testing.T) {
@@ -53,14 +57,17 @@ func TestDatabase(t *testing.T) {
 			rcLocal := readconcern.Local()
 			rcMajority := readconcern.Majority()
 			reg := bsoncodec.NewRegistryBuilder().Build()
+			timeout := 5 * time.Second
 
 			opts := options.Database().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetWriteConcern(wc1).
-				SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2).SetRegistry(reg)
+				SetReadPreference(rpSecondary).SetReadConcern(rcMajority).SetWriteConcern(wc2).SetRegistry(reg).
+				SetTimeout(timeout)
 			expected := &Database{
 				readPreference: rpSecondary,
 				readConcern:    rcMajority,
 				writeConcern:   wc2,
 				registry:       reg,
+				timeout:        &timeout,
 			}
 			got := setupDb("foo", opts)
 			compareDbs(t, expected, got)
@@ -70,14 +77,17 @@ func TestDatabase(t *testing.T) {
 			rcLocal := readconcern.Local()
 			wc1 := writeconcern.New(writeconcern.W(10))
 			reg := bsoncodec.NewRegistryBuilder().Build()
+			timeout := 5 * time.Second
 
-			client := setupClient(options.Client().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetRegistry(reg))
+			client := setupClient(options.Client().SetReadPreference(rpPrimary).SetReadConcern(rcLocal).SetRegistry(reg).
+				SetTimeout(timeout))
 			got := client.Database("foo", options.Database().SetWriteConcern(wc1))
 			expected := &Database{
 				readPreference: rpPrimary,
 				readConcern:    rcLocal,
 				writeConcern:   wc1,
 				registry:       reg,
+				timeout:        &timeout,
 			}
 			compareDbs(t, expected, got)
 		})
@@ -110,4 +120,71 @@ func TestDatabase(t *testing.T) {
 		_, err = db.ListCollectionNames(context.Background(), nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("modify collection index", func(t *testing.T) {
+		db := setupDb("foo")
+		indexErr := errors.New("exactly one of Name and Keys must be specified to modify an index")
+
+		testCases := []struct {
+			name  string
+			index *options.ModifyIndexOptions
+		}{
+			{"neither name nor keys", options.ModifyIndex().SetHidden(true)},
+			{"both name and keys", options.ModifyIndex().SetName("x_1").SetKeys(bson.D{{"x", 1}}).SetHidden(true)},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := db.ModifyCollection(bgCtx, "bar", options.ModifyCollection().SetIndex(tc.index))
+				assert.Equal(t, indexErr, err, "expected error %v, got %v", indexErr, err)
+			})
+		}
+		t.Run("keys", func(t *testing.T) {
+			doc, err := db.createModifyIndexDoc(options.ModifyIndex().SetKeys(bson.D{{"x", 1}}).SetExpireAfterSeconds(60))
+			assert.Nil(t, err, "createModifyIndexDoc error: %v", err)
+
+			expected := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "keyPattern", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendInt32Element(nil, "x", 1),
+				)),
+				bsoncore.AppendInt64Element(nil, "expireAfterSeconds", 60),
+			))
+			assert.Equal(t, expected, doc, "expected document %v, got %v", expected, doc)
+		})
+	})
+	t.Run("create collection view options", func(t *testing.T) {
+		db := setupDb("foo")
+		viewErr := errors.New("the Capped, ClusteredIndex, EncryptedFields, MaxDocuments, SizeInBytes, and " +
+			"TimeSeriesOptions options cannot be used when creating a view")
+
+		testCases := []struct {
+			name string
+			opts *options.CreateCollectionOptions
+			err  error
+		}{
+			{"pipeline without viewOn", options.CreateCollection().SetPipeline(bson.A{}),
+				errors.New("the Pipeline option requires the ViewOn option to be set")},
+			{"capped view", options.CreateCollection().SetViewOn("bar").SetCapped(true), viewErr},
+			{"clustered view", options.CreateCollection().SetViewOn("bar").SetClusteredIndex(options.ClusteredIndex()),
+				viewErr},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := db.CreateCollection(bgCtx, "baz", tc.opts)
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("clustered index document", func(t *testing.T) {
+		db := setupDb("foo")
+		doc, err := db.createClusteredIndexDoc(options.ClusteredIndex().SetName("clustered"))
+		assert.Nil(t, err, "createClusteredIndexDoc error: %v", err)
+
+		expected := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "key", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "_id", 1),
+			)),
+			bsoncore.AppendBooleanElement(nil, "unique", true),
+			bsoncore.AppendStringElement(nil, "name", "clustered"),
+		))
+		assert.Equal(t, expected, doc, "expected document %v, got %v", expected, doc)
+	})
 }
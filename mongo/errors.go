@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -209,6 +210,7 @@ type ServerError interface {
 var _ ServerError = CommandError{}
 var _ ServerError = WriteException{}
 var _ ServerError = BulkWriteException{}
+var _ ServerError = ClientBulkWriteException{}
 
 // CommandError represents a server error during execution of a command. This can be returned by any operation.
 type CommandError struct {
@@ -529,6 +531,133 @@ func (bwe BulkWriteException) HasErrorCodeWithMessage(code int, message string)
 // serverError implements the ServerError interface.
 func (bwe BulkWriteException) serverError() {}
 
+// ClientBulkWriteException is the error type returned by a client-level BulkWrite operation.
+type ClientBulkWriteException struct {
+	// The error that caused the operation to stop before all models were executed, or nil if there was none. If set,
+	// PartialResult contains the results of the batches that completed before the error occurred.
+	TopLevelError error
+
+	// The write concern errors that occurred.
+	WriteConcernErrors []WriteConcernError
+
+	// A map of model index to the write error that occurred for that model.
+	WriteErrors map[int]WriteError
+
+	// The results of the operations that were executed successfully.
+	PartialResult *ClientBulkWriteResult
+
+	// The categories to which the exception belongs.
+	Labels []string
+}
+
+// Error implements the error interface.
+func (bwe ClientBulkWriteException) Error() string {
+	causes := make([]string, 0, 3)
+	if bwe.TopLevelError != nil {
+		causes = append(causes, "top level error: "+bwe.TopLevelError.Error())
+	}
+	if len(bwe.WriteConcernErrors) > 0 {
+		errs := make([]error, len(bwe.WriteConcernErrors))
+		for i := 0; i < len(bwe.WriteConcernErrors); i++ {
+			errs[i] = bwe.WriteConcernErrors[i]
+		}
+		causes = append(causes, "write concern errors: "+joinBatchErrors(errs))
+	}
+	if len(bwe.WriteErrors) > 0 {
+		indexes := make([]int, 0, len(bwe.WriteErrors))
+		for idx := range bwe.WriteErrors {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+		errs := make([]error, len(indexes))
+		for i, idx := range indexes {
+			errs[i] = bwe.WriteErrors[idx]
+		}
+		causes = append(causes, "write errors: "+joinBatchErrors(errs))
+	}
+
+	message := "client bulk write exception: "
+	if len(causes) == 0 {
+		return message + "no causes"
+	}
+	return message + strings.Join(causes, ", ")
+}
+
+// Unwrap returns the top-level error, if any.
+func (bwe ClientBulkWriteException) Unwrap() error {
+	return bwe.TopLevelError
+}
+
+// HasErrorCode returns true if any of the errors have the specified code.
+func (bwe ClientBulkWriteException) HasErrorCode(code int) bool {
+	if se, ok := bwe.TopLevelError.(ServerError); ok && se.HasErrorCode(code) {
+		return true
+	}
+	for _, wce := range bwe.WriteConcernErrors {
+		if wce.Code == code {
+			return true
+		}
+	}
+	for _, we := range bwe.WriteErrors {
+		if we.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrorLabel returns true if the error contains the specified label.
+func (bwe ClientBulkWriteException) HasErrorLabel(label string) bool {
+	if se, ok := bwe.TopLevelError.(ServerError); ok && se.HasErrorLabel(label) {
+		return true
+	}
+	for _, l := range bwe.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrorMessage returns true if the error contains the specified message.
+func (bwe ClientBulkWriteException) HasErrorMessage(message string) bool {
+	if se, ok := bwe.TopLevelError.(ServerError); ok && se.HasErrorMessage(message) {
+		return true
+	}
+	for _, wce := range bwe.WriteConcernErrors {
+		if strings.Contains(wce.Message, message) {
+			return true
+		}
+	}
+	for _, we := range bwe.WriteErrors {
+		if strings.Contains(we.Message, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrorCodeWithMessage returns true if any of the contained errors have the specified code and message.
+func (bwe ClientBulkWriteException) HasErrorCodeWithMessage(code int, message string) bool {
+	if se, ok := bwe.TopLevelError.(ServerError); ok && se.HasErrorCodeWithMessage(code, message) {
+		return true
+	}
+	for _, wce := range bwe.WriteConcernErrors {
+		if wce.Code == code && strings.Contains(wce.Message, message) {
+			return true
+		}
+	}
+	for _, we := range bwe.WriteErrors {
+		if we.Code == code && strings.Contains(we.Message, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// serverError implements the ServerError interface.
+func (bwe ClientBulkWriteException) serverError() {}
+
 // returnResult is used to determine if a function calling processWriteError should return
 // the result or return nil. Since the processWriteError function is used by many different
 // methods, both *One and *Many, we need a way to differentiate if the method should return
@@ -0,0 +1,445 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package filter provides constructors for query filters. A Filter can be passed anywhere the driver accepts a filter
+// document, such as Collection.Find or Collection.UpdateOne:
+//
+//	f := filter.And(
+//		filter.Eq("status", "A"),
+//		filter.Gte("qty", 10),
+//	)
+//	if name != "" {
+//		f = filter.And(f, filter.Eq("name", name))
+//	}
+//	cursor, err := coll.Find(ctx, f)
+//
+// The zero Filter matches every document and is ignored by And, Or, and Nor, so optional clauses can be combined
+// without special cases. Constructors check the structure of the filter they build, such as empty field names or a
+// $not applied to something other than an operator expression. Such an error is returned when the Filter is marshalled,
+// so it is reported by the collection method the Filter is passed to.
+//
+// Filter values are marshalled with bson.DefaultRegistry.
+package filter // import "go.mongodb.org/mongo-driver/mongo/filter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNoClauses is the error for an Or or Nor filter without any non-empty clauses.
+var ErrNoClauses = errors.New("$or and $nor require at least one non-empty clause")
+
+// Filter is a query filter document.
+type Filter struct {
+	d   bson.D
+	err error
+}
+
+// Doc returns a Filter for a hand-written filter document.
+func Doc(d bson.D) Filter {
+	return Filter{d: d}
+}
+
+// Document returns the filter document, or the error encountered while building the Filter.
+func (f Filter) Document() (bson.D, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.d == nil {
+		return bson.D{}, nil
+	}
+	return f.d, nil
+}
+
+// Err returns the error encountered while building the Filter, if any.
+func (f Filter) Err() error {
+	return f.err
+}
+
+// IsEmpty returns true if the Filter matches every document.
+func (f Filter) IsEmpty() bool {
+	return f.err == nil && len(f.d) == 0
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (f Filter) MarshalBSON() ([]byte, error) {
+	d, err := f.Document()
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(d)
+}
+
+func errorf(format string, args ...interface{}) Filter {
+	return Filter{err: fmt.Errorf(format, args...)}
+}
+
+func checkField(field string) error {
+	if field == "" {
+		return errors.New("filter field name cannot be empty")
+	}
+	if strings.HasPrefix(field, "$") {
+		return fmt.Errorf("filter field name %q cannot begin with '$'", field)
+	}
+	return nil
+}
+
+// fieldOp returns the filter {field: {op: val}}.
+func fieldOp(field, op string, val interface{}) Filter {
+	if err := checkField(field); err != nil {
+		return Filter{err: err}
+	}
+	return Filter{d: bson.D{{field, bson.D{{op, val}}}}}
+}
+
+// Logical operators.
+
+// And returns a filter that matches documents that match every one of the given filters. Empty filters are ignored.
+// If the remaining filters do not refer to the same top-level keys, they are merged into a single document rather than
+// combined with $and.
+func And(filters ...Filter) Filter {
+	clauses, err := nonEmpty(filters)
+	if err != nil {
+		return Filter{err: err}
+	}
+	switch len(clauses) {
+	case 0:
+		return Filter{}
+	case 1:
+		return Filter{d: clauses[0]}
+	}
+
+	var merged bson.D
+	seen := make(map[string]bool)
+	for _, clause := range clauses {
+		for _, elem := range clause {
+			if seen[elem.Key] {
+				return Filter{d: bson.D{{"$and", clauses}}}
+			}
+			seen[elem.Key] = true
+			merged = append(merged, elem)
+		}
+	}
+	return Filter{d: merged}
+}
+
+// Or returns a filter that matches documents that match at least one of the given filters. Empty filters are ignored,
+// and ErrNoClauses is reported if no filters remain.
+func Or(filters ...Filter) Filter {
+	return logical("$or", filters)
+}
+
+// Nor returns a filter that matches documents that match none of the given filters. Empty filters are ignored, and
+// ErrNoClauses is reported if no filters remain.
+func Nor(filters ...Filter) Filter {
+	return logical("$nor", filters)
+}
+
+func logical(op string, filters []Filter) Filter {
+	clauses, err := nonEmpty(filters)
+	if err != nil {
+		return Filter{err: err}
+	}
+	if len(clauses) == 0 {
+		return Filter{err: ErrNoClauses}
+	}
+	return Filter{d: bson.D{{op, clauses}}}
+}
+
+func nonEmpty(filters []Filter) ([]bson.D, error) {
+	var clauses []bson.D
+	for _, f := range filters {
+		if f.err != nil {
+			return nil, f.err
+		}
+		if len(f.d) > 0 {
+			clauses = append(clauses, f.d)
+		}
+	}
+	return clauses, nil
+}
+
+// Not negates a filter on a single field whose value is an operator expression or a regular expression, such as the
+// filters returned by Gt or Regex. For example, Not(Gt("qty", 5)) returns {qty: {$not: {$gt: 5}}}.
+func Not(f Filter) Filter {
+	if f.err != nil {
+		return f
+	}
+	if len(f.d) != 1 {
+		return errorf("$not requires a filter on a single field, but got %d elements", len(f.d))
+	}
+
+	elem := f.d[0]
+	if strings.HasPrefix(elem.Key, "$") {
+		return errorf("$not cannot be applied to the top-level operator %q", elem.Key)
+	}
+	switch val := elem.Value.(type) {
+	case bson.D:
+		if len(val) == 0 || !strings.HasPrefix(val[0].Key, "$") {
+			return errorf("$not requires an operator expression for field %q", elem.Key)
+		}
+		// A $regex operator must be negated as a regular expression value.
+		if val[0].Key == "$regex" {
+			return Filter{d: bson.D{{elem.Key, bson.D{{"$not", val[0].Value}}}}}
+		}
+	case primitive.Regex:
+	default:
+		return errorf("$not requires an operator expression for field %q, but got %T", elem.Key, elem.Value)
+	}
+	return Filter{d: bson.D{{elem.Key, bson.D{{"$not", elem.Value}}}}}
+}
+
+// Comparison operators.
+
+// Eq returns a filter that matches documents where the value of field equals val.
+func Eq(field string, val interface{}) Filter {
+	return fieldOp(field, "$eq", val)
+}
+
+// Ne returns a filter that matches documents where the value of field does not equal val.
+func Ne(field string, val interface{}) Filter {
+	return fieldOp(field, "$ne", val)
+}
+
+// Gt returns a filter that matches documents where the value of field is greater than val.
+func Gt(field string, val interface{}) Filter {
+	return fieldOp(field, "$gt", val)
+}
+
+// Gte returns a filter that matches documents where the value of field is greater than or equal to val.
+func Gte(field string, val interface{}) Filter {
+	return fieldOp(field, "$gte", val)
+}
+
+// Lt returns a filter that matches documents where the value of field is less than val.
+func Lt(field string, val interface{}) Filter {
+	return fieldOp(field, "$lt", val)
+}
+
+// Lte returns a filter that matches documents where the value of field is less than or equal to val.
+func Lte(field string, val interface{}) Filter {
+	return fieldOp(field, "$lte", val)
+}
+
+// In returns a filter that matches documents where the value of field equals any of the given values.
+func In(field string, vals ...interface{}) Filter {
+	return fieldOp(field, "$in", array(vals))
+}
+
+// Nin returns a filter that matches documents where the value of field equals none of the given values.
+func Nin(field string, vals ...interface{}) Filter {
+	return fieldOp(field, "$nin", array(vals))
+}
+
+// array converts vals to a bson.A so that no values are marshalled as a BSON null.
+func array(vals []interface{}) bson.A {
+	if vals == nil {
+		return bson.A{}
+	}
+	return bson.A(vals)
+}
+
+// Element operators.
+
+// Exists returns a filter that matches documents that contain field if exists is true, or documents that do not
+// contain field if exists is false.
+func Exists(field string, exists bool) Filter {
+	return fieldOp(field, "$exists", exists)
+}
+
+// Type returns a filter that matches documents where the value of field has one of the given BSON types.
+func Type(field string, types ...bsontype.Type) Filter {
+	if len(types) == 0 {
+		return errorf("$type requires at least one type for field %q", field)
+	}
+	if len(types) == 1 {
+		return fieldOp(field, "$type", int32(types[0]))
+	}
+	numbers := make(bson.A, 0, len(types))
+	for _, t := range types {
+		numbers = append(numbers, int32(t))
+	}
+	return fieldOp(field, "$type", numbers)
+}
+
+// Evaluation operators.
+
+// Regex returns a filter that matches documents where the value of field matches the regular expression pattern with
+// the given options, such as "i" for case-insensitive matching.
+func Regex(field, pattern, options string) Filter {
+	return fieldOp(field, "$regex", primitive.Regex{Pattern: pattern, Options: options})
+}
+
+// Mod returns a filter that matches documents where the value of field divided by divisor has the given remainder.
+func Mod(field string, divisor, remainder int64) Filter {
+	if divisor == 0 {
+		return errorf("$mod divisor for field %q cannot be zero", field)
+	}
+	return fieldOp(field, "$mod", bson.A{divisor, remainder})
+}
+
+// Expr returns a filter that matches documents for which the given aggregation expression is true.
+func Expr(expr interface{}) Filter {
+	if expr == nil {
+		return errorf("$expr requires an expression")
+	}
+	return Filter{d: bson.D{{"$expr", expr}}}
+}
+
+// JSONSchema returns a filter that matches documents that satisfy the given JSON Schema.
+func JSONSchema(schema interface{}) Filter {
+	if schema == nil {
+		return errorf("$jsonSchema requires a schema")
+	}
+	return Filter{d: bson.D{{"$jsonSchema", schema}}}
+}
+
+// TextOptions specifies options for a $text filter. Zero-valued fields are omitted.
+type TextOptions struct {
+	// The language that determines the stop words and the stemmer for the search.
+	Language string
+
+	// If true, the search is case sensitive.
+	CaseSensitive bool
+
+	// If true, the search is diacritic sensitive.
+	DiacriticSensitive bool
+}
+
+// Text returns a filter that performs a text search for the given string on the text-indexed fields of the collection.
+// The opts parameter can be nil.
+func Text(search string, opts *TextOptions) Filter {
+	if search == "" {
+		return errorf("$text requires a non-empty search string")
+	}
+	spec := bson.D{{"$search", search}}
+	if opts != nil {
+		if opts.Language != "" {
+			spec = append(spec, bson.E{"$language", opts.Language})
+		}
+		if opts.CaseSensitive {
+			spec = append(spec, bson.E{"$caseSensitive", true})
+		}
+		if opts.DiacriticSensitive {
+			spec = append(spec, bson.E{"$diacriticSensitive", true})
+		}
+	}
+	return Filter{d: bson.D{{"$text", spec}}}
+}
+
+// Array operators.
+
+// All returns a filter that matches documents where the array at field contains every one of the given values.
+func All(field string, vals ...interface{}) Filter {
+	return fieldOp(field, "$all", array(vals))
+}
+
+// ElemMatch returns a filter that matches documents where the array at field contains at least one element that
+// matches cond. For arrays of documents, cond is typically a Filter on the fields of the elements. For arrays of
+// other values, cond is an operator document such as bson.D{{"$gte", 80}, {"$lt", 85}}.
+func ElemMatch(field string, cond interface{}) Filter {
+	if f, ok := cond.(Filter); ok {
+		if f.err != nil {
+			return f
+		}
+		if len(f.d) == 0 {
+			return errorf("$elemMatch requires a non-empty condition for field %q", field)
+		}
+		cond = f.d
+	}
+	if cond == nil {
+		return errorf("$elemMatch requires a non-empty condition for field %q", field)
+	}
+	return fieldOp(field, "$elemMatch", cond)
+}
+
+// Size returns a filter that matches documents where the array at field has the given number of elements.
+func Size(field string, size int32) Filter {
+	if size < 0 {
+		return errorf("$size for field %q cannot be negative", field)
+	}
+	return fieldOp(field, "$size", size)
+}
+
+// Geospatial operators.
+
+// Geometry is a GeoJSON geometry.
+type Geometry struct {
+	Type        string      `bson:"type"`
+	Coordinates interface{} `bson:"coordinates"`
+}
+
+// Point returns a GeoJSON point with the given longitude and latitude.
+func Point(longitude, latitude float64) Geometry {
+	return Geometry{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// Polygon returns a GeoJSON polygon with the given rings. Each ring is a list of [longitude, latitude] positions whose
+// first and last positions are equal.
+func Polygon(rings ...[][]float64) Geometry {
+	return Geometry{Type: "Polygon", Coordinates: rings}
+}
+
+// GeoWithin returns a filter that matches documents where the geospatial data at field is entirely within the given
+// GeoJSON geometry.
+func GeoWithin(field string, geometry Geometry) Filter {
+	return fieldOp(field, "$geoWithin", bson.D{{"$geometry", geometry}})
+}
+
+// GeoWithinBox returns a filter that matches documents where the legacy coordinate pair at field is within the
+// rectangle with the given bottom left and upper right corners.
+func GeoWithinBox(field string, bottomLeft, upperRight [2]float64) Filter {
+	return fieldOp(field, "$geoWithin", bson.D{{"$box", bson.A{bottomLeft[:], upperRight[:]}}})
+}
+
+// GeoWithinCenterSphere returns a filter that matches documents where the geospatial data at field is within the
+// spherical cap with the given center and radius in radians.
+func GeoWithinCenterSphere(field string, center [2]float64, radius float64) Filter {
+	if radius < 0 {
+		return errorf("$centerSphere radius for field %q cannot be negative", field)
+	}
+	return fieldOp(field, "$geoWithin", bson.D{{"$centerSphere", bson.A{center[:], radius}}})
+}
+
+// GeoIntersects returns a filter that matches documents where the geospatial data at field intersects the given
+// GeoJSON geometry.
+func GeoIntersects(field string, geometry Geometry) Filter {
+	return fieldOp(field, "$geoIntersects", bson.D{{"$geometry", geometry}})
+}
+
+// Near returns a filter that matches documents in order of their distance from the given GeoJSON point. The distances
+// are in meters, and a distance of zero is omitted.
+func Near(field string, point Geometry, minDistance, maxDistance float64) Filter {
+	return near(field, "$near", point, minDistance, maxDistance)
+}
+
+// NearSphere is like Near, but it calculates distances using spherical geometry.
+func NearSphere(field string, point Geometry, minDistance, maxDistance float64) Filter {
+	return near(field, "$nearSphere", point, minDistance, maxDistance)
+}
+
+func near(field, op string, point Geometry, minDistance, maxDistance float64) Filter {
+	if point.Type != "Point" {
+		return errorf("%s requires a GeoJSON point for field %q, but got %q", op, field, point.Type)
+	}
+	if minDistance < 0 || maxDistance < 0 || (maxDistance > 0 && minDistance > maxDistance) {
+		return errorf("invalid %s distances for field %q: min %v, max %v", op, field, minDistance, maxDistance)
+	}
+
+	spec := bson.D{{"$geometry", point}}
+	if minDistance > 0 {
+		spec = append(spec, bson.E{"$minDistance", minDistance})
+	}
+	if maxDistance > 0 {
+		spec = append(spec, bson.E{"$maxDistance", maxDistance})
+	}
+	return fieldOp(field, op, spec)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected string
+	}{
+		{"empty", Filter{}, `{}`},
+		{"eq", Eq("status", "A"), `{"status":{"$eq":"A"}}`},
+		{"in", In("qty", 5, 15), `{"qty":{"$in":[5,15]}}`},
+		{"in without values", In("qty"), `{"qty":{"$in":[]}}`},
+		{
+			"and merges distinct fields",
+			And(Eq("status", "A"), Filter{}, Lt("qty", 30)),
+			`{"status":{"$eq":"A"},"qty":{"$lt":30}}`,
+		},
+		{
+			"and with repeated fields",
+			And(Gt("qty", 5), Lt("qty", 30)),
+			`{"$and":[{"qty":{"$gt":5}},{"qty":{"$lt":30}}]}`,
+		},
+		{"and with one clause", And(Filter{}, Exists("a", true)), `{"a":{"$exists":true}}`},
+		{"or", Or(Eq("a", 1), Filter{}, Eq("b", 2)), `{"$or":[{"a":{"$eq":1}},{"b":{"$eq":2}}]}`},
+		{"not", Not(Gt("price", 1.99)), `{"price":{"$not":{"$gt":1.99}}}`},
+		{"not regex", Not(Regex("item", "^p", "i")), `{"item":{"$not":{"$regularExpression":{"pattern":"^p","options":"i"}}}}`},
+		{"type", Type("zip", bsontype.String, bsontype.Int32), `{"zip":{"$type":[2,16]}}`},
+		{
+			"elemMatch filter",
+			ElemMatch("results", And(Eq("product", "xyz"), Gte("score", 8))),
+			`{"results":{"$elemMatch":{"product":{"$eq":"xyz"},"score":{"$gte":8}}}}`,
+		},
+		{
+			"elemMatch operators",
+			ElemMatch("results", bson.D{{"$gte", 80}, {"$lt", 85}}),
+			`{"results":{"$elemMatch":{"$gte":80,"$lt":85}}}`,
+		},
+		{
+			"text",
+			Text("coffee", &TextOptions{Language: "es", CaseSensitive: true}),
+			`{"$text":{"$search":"coffee","$language":"es","$caseSensitive":true}}`,
+		},
+		{
+			"near",
+			Near("location", Point(-73.9667, 40.78), 0, 1000),
+			`{"location":{"$near":{"$geometry":{"type":"Point","coordinates":[-73.9667,40.78]},"$maxDistance":1000.0}}}`,
+		},
+		{
+			"geoWithin box",
+			GeoWithinBox("loc", [2]float64{0, 0}, [2]float64{100, 100}),
+			`{"loc":{"$geoWithin":{"$box":[[0.0,0.0],[100.0,100.0]]}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := bson.MarshalExtJSON(tc.filter, false, false)
+			assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+			assert.Equal(t, tc.expected, string(b), "expected %v, got %v", tc.expected, string(b))
+		})
+	}
+
+	errorCases := []struct {
+		name   string
+		filter Filter
+	}{
+		{"empty field name", Eq("", 1)},
+		{"operator field name", Eq("$where", 1)},
+		{"not with multiple fields", Not(Doc(bson.D{{"a", 1}, {"b", 2}}))},
+		{"not with value", Not(Doc(bson.D{{"a", 1}}))},
+		{"not with top-level operator", Not(Expr(bson.D{{"$eq", bson.A{"$a", 1}}}))},
+		{"or without clauses", Or(Filter{})},
+		{"nested error", And(Eq("a", 1), Or())},
+		{"empty elemMatch", ElemMatch("a", Filter{})},
+		{"zero mod divisor", Mod("a", 0, 1)},
+		{"near with polygon", Near("loc", Polygon(), 0, 0)},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NotNil(t, tc.filter.Err(), "expected error, got nil")
+			_, err := bson.Marshal(tc.filter)
+			assert.NotNil(t, err, "expected Marshal error, got nil")
+		})
+	}
+}
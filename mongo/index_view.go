@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -95,9 +96,10 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		Session(sess).CommandMonitor(iv.coll.client.monitor).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
-		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI)
+		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).Timeout(iv.coll.timeout)
 
 	cursorOpts := iv.coll.client.createBaseCursorOptions()
+	cursorOpts.Timeout = iv.coll.timeout
 	lio := options.MergeListIndexesOptions(opts...)
 	if lio.BatchSize != nil {
 		op = op.BatchSize(*lio.BatchSize)
@@ -256,7 +258,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	op := operation.NewCreateIndexes(indexes).
 		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
-		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI)
+		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).Timeout(iv.coll.timeout)
 
 	if option.MaxTime != nil {
 		op.MaxTimeMS(int64(*option.MaxTime / time.Millisecond))
@@ -400,7 +402,7 @@ func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.Drop
 		Session(sess).WriteConcern(wc).CommandMonitor(iv.coll.client.monitor).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
-		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI)
+		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).Timeout(iv.coll.timeout)
 	if dio.MaxTime != nil {
 		op.MaxTimeMS(int64(*dio.MaxTime / time.Millisecond))
 	}
@@ -448,6 +450,308 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 	return iv.drop(ctx, "*", opts...)
 }
 
+// IndexSyncChange describes a change that IndexView.Sync makes to an existing index.
+type IndexSyncChange struct {
+	// The existing index.
+	Existing *IndexSpecification
+
+	// The declared model that the existing index is reconciled with.
+	Model IndexModel
+}
+
+// IndexSyncPlan describes the changes made by IndexView.Sync. If Sync was called with the DryRun option, the plan
+// describes the changes that would have been made.
+type IndexSyncPlan struct {
+	// Declared indexes that do not exist on the collection and will be created.
+	Create []IndexModel
+
+	// Existing indexes whose keys, name, uniqueness, sparseness, partial filter, collation, or presence of a TTL differ
+	// from the declared model. These indexes will be dropped and recreated if the RebuildChanged option is set.
+	Rebuild []IndexSyncChange
+
+	// Existing indexes whose TTL or hidden setting differs from the declared model. These indexes will be changed in
+	// place with a collMod command.
+	Modify []IndexSyncChange
+
+	// The names of undeclared indexes that will be hidden.
+	Hide []string
+
+	// The names of undeclared indexes that will be dropped.
+	Drop []string
+}
+
+// Sync reconciles the indexes on the collection with the declared models and returns the plan of changes that were
+// made.
+//
+// Each model is matched to an existing index by its keys document or, failing that, by its name. Declared indexes
+// that do not exist are created. The TTL and hidden settings of existing indexes are changed in place when those are
+// the only differences from the model. Options that are not set on a model, such as the collation, are not compared.
+// Indexes that are not declared are left in place unless the HideUndeclared or DropUndeclared options are used.
+//
+// Any other difference requires the existing index to be dropped and recreated. Because the index is missing until
+// the new one has been built, and is lost if it cannot be built, rebuilds are only done if the RebuildChanged option
+// is set. Otherwise, Sync returns the plan and an error without changing any indexes.
+//
+// The opts parameter can be used to specify options for this operation (see the options.SyncIndexesOptions
+// documentation). The DryRun option can be used to compute the plan without changing any indexes.
+func (iv IndexView) Sync(ctx context.Context, models []IndexModel,
+	opts ...*options.SyncIndexesOptions) (*IndexSyncPlan, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sio := options.MergeSyncIndexesOptions(opts...)
+	specs, err := iv.ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hide := sio.HideUndeclared != nil && *sio.HideUndeclared
+	drop := sio.DropUndeclared != nil && *sio.DropUndeclared
+	plan, err := iv.planSync(models, specs, hide, drop)
+	if err != nil {
+		return nil, err
+	}
+	if sio.DryRun != nil && *sio.DryRun {
+		return plan, nil
+	}
+	if len(plan.Rebuild) > 0 && (sio.RebuildChanged == nil || !*sio.RebuildChanged) {
+		names := make([]string, 0, len(plan.Rebuild))
+		for _, change := range plan.Rebuild {
+			names = append(names, change.Existing.Name)
+		}
+		return plan, fmt.Errorf("indexes %s differ from their declared models and must be rebuilt; set the "+
+			"RebuildChanged option to drop and recreate them", strings.Join(names, ", "))
+	}
+	return plan, iv.applySyncPlan(ctx, plan)
+}
+
+func (iv IndexView) planSync(models []IndexModel, specs []*IndexSpecification, hide, drop bool) (*IndexSyncPlan, error) {
+	plan := &IndexSyncPlan{}
+	matched := make(map[string]bool)
+	for _, model := range models {
+		if model.Keys == nil {
+			return nil, fmt.Errorf("index model keys cannot be nil")
+		}
+
+		keys, err := transformBsoncoreDocument(iv.coll.registry, model.Keys, false, "keys")
+		if err != nil {
+			return nil, err
+		}
+		name, err := getOrGenerateIndexName(keys, model)
+		if err != nil {
+			return nil, err
+		}
+
+		spec := findIndexSpecification(specs, keys, name)
+		if spec == nil {
+			plan.Create = append(plan.Create, model)
+			continue
+		}
+		matched[spec.Name] = true
+
+		rebuild, modify, err := iv.compareIndex(spec, keys, model.Options)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case rebuild:
+			plan.Rebuild = append(plan.Rebuild, IndexSyncChange{Existing: spec, Model: model})
+		case modify:
+			plan.Modify = append(plan.Modify, IndexSyncChange{Existing: spec, Model: model})
+		}
+	}
+
+	for _, spec := range specs {
+		if spec.Name == "_id_" || matched[spec.Name] {
+			continue
+		}
+
+		// When both options are set, visible indexes are hidden first and only dropped by a later Sync.
+		hidden := spec.Hidden != nil && *spec.Hidden
+		switch {
+		case hide && !hidden:
+			plan.Hide = append(plan.Hide, spec.Name)
+		case drop:
+			plan.Drop = append(plan.Drop, spec.Name)
+		}
+	}
+	return plan, nil
+}
+
+// compareIndex reports whether the existing index must be rebuilt or modified with collMod to match the declared
+// keys and options.
+func (iv IndexView) compareIndex(spec *IndexSpecification, keys bsoncore.Document,
+	opts *options.IndexOptions) (rebuild bool, modify bool, err error) {
+
+	if opts == nil {
+		opts = options.Index()
+	}
+
+	// Text indexes are reported with internal _fts and _ftsx keys rather than the declared keys.
+	if !indexKeysEqual(bsoncore.Document(spec.KeysDocument), keys) && !isTextIndexKeys(spec.KeysDocument) {
+		return true, false, nil
+	}
+	if opts.Name != nil && *opts.Name != spec.Name {
+		return true, false, nil
+	}
+	if boolValue(opts.Unique) != boolValue(spec.Unique) || boolValue(opts.Sparse) != boolValue(spec.Sparse) {
+		return true, false, nil
+	}
+
+	var partialFilter bsoncore.Document
+	if opts.PartialFilterExpression != nil {
+		partialFilter, err = transformBsoncoreDocument(iv.coll.registry, opts.PartialFilterExpression, true,
+			"partialFilterExpression")
+		if err != nil {
+			return false, false, err
+		}
+	}
+	if !bytes.Equal(partialFilter, spec.PartialFilterExpression) {
+		return true, false, nil
+	}
+	if opts.Collation != nil && !collationMatches(bsoncore.Document(opts.Collation.ToDocument()),
+		bsoncore.Document(spec.Collation)) {
+
+		return true, false, nil
+	}
+
+	// collMod can change an existing TTL but cannot add or remove one.
+	if (opts.ExpireAfterSeconds == nil) != (spec.ExpireAfterSeconds == nil) {
+		return true, false, nil
+	}
+	if opts.ExpireAfterSeconds != nil && *opts.ExpireAfterSeconds != *spec.ExpireAfterSeconds {
+		modify = true
+	}
+	if boolValue(opts.Hidden) != boolValue(spec.Hidden) {
+		modify = true
+	}
+	return false, modify, nil
+}
+
+func (iv IndexView) applySyncPlan(ctx context.Context, plan *IndexSyncPlan) error {
+	for _, change := range plan.Rebuild {
+		if _, err := iv.DropOne(ctx, change.Existing.Name); err != nil {
+			return err
+		}
+		if _, err := iv.CreateOne(ctx, change.Model); err != nil {
+			return err
+		}
+	}
+	for _, change := range plan.Modify {
+		mio := options.ModifyIndex().SetName(change.Existing.Name)
+		if opts := change.Model.Options; opts != nil && opts.ExpireAfterSeconds != nil &&
+			*opts.ExpireAfterSeconds != *change.Existing.ExpireAfterSeconds {
+
+			mio.SetExpireAfterSeconds(int64(*opts.ExpireAfterSeconds))
+		}
+		var hidden *bool
+		if change.Model.Options != nil {
+			hidden = change.Model.Options.Hidden
+		}
+		if boolValue(hidden) != boolValue(change.Existing.Hidden) {
+			mio.SetHidden(boolValue(hidden))
+		}
+		if err := iv.coll.db.ModifyCollection(ctx, iv.coll.name, options.ModifyCollection().SetIndex(mio)); err != nil {
+			return err
+		}
+	}
+	if len(plan.Create) > 0 {
+		if _, err := iv.CreateMany(ctx, plan.Create); err != nil {
+			return err
+		}
+	}
+	for _, name := range plan.Hide {
+		mio := options.ModifyIndex().SetName(name).SetHidden(true)
+		if err := iv.coll.db.ModifyCollection(ctx, iv.coll.name, options.ModifyCollection().SetIndex(mio)); err != nil {
+			return err
+		}
+	}
+	for _, name := range plan.Drop {
+		if _, err := iv.DropOne(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findIndexSpecification returns the specification of the existing index with the given keys or, if there is none,
+// with the given name.
+func findIndexSpecification(specs []*IndexSpecification, keys bsoncore.Document, name string) *IndexSpecification {
+	for _, spec := range specs {
+		if indexKeysEqual(bsoncore.Document(spec.KeysDocument), keys) {
+			return spec
+		}
+	}
+	for _, spec := range specs {
+		if spec.Name == name {
+			return spec
+		}
+	}
+	return nil
+}
+
+// indexKeysEqual reports whether two keys documents describe the same index. Numeric index types are compared by
+// value so that, for example, {x: 1} and {x: NumberLong(1)} are considered equal.
+func indexKeysEqual(a, b bsoncore.Document) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil || len(aElems) != len(bElems) {
+		return false
+	}
+	for i := range aElems {
+		if aElems[i].Key() != bElems[i].Key() || !indexValuesEqual(aElems[i].Value(), bElems[i].Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexValuesEqual(a, b bsoncore.Value) bool {
+	if af, ok := indexNumber(a); ok {
+		bf, ok := indexNumber(b)
+		return ok && af == bf
+	}
+	return a.Equal(b)
+}
+
+func indexNumber(v bsoncore.Value) (float64, bool) {
+	if f, ok := v.DoubleOK(); ok {
+		return f, true
+	}
+	i, ok := v.AsInt64OK()
+	return float64(i), ok
+}
+
+func isTextIndexKeys(keys bson.Raw) bool {
+	_, err := keys.LookupErr("_fts")
+	return err == nil
+}
+
+// collationMatches reports whether every field set in the declared collation has the same value in the existing
+// collation. The server reports collations with all fields filled in, so fields that were not declared are ignored.
+func collationMatches(declared, existing bsoncore.Document) bool {
+	elems, err := declared.Elements()
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		val, err := existing.LookupErr(elem.Key())
+		if err != nil || !indexValuesEqual(elem.Value(), val) {
+			return false
+		}
+	}
+	return true
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
 func getOrGenerateIndexName(keySpecDocument bsoncore.Document, model IndexModel) (string, error) {
 	if model.Options != nil && model.Options.Name != nil {
 		return *model.Options.Name, nil
@@ -0,0 +1,152 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+)
+
+func TestIndexViewPlanSync(t *testing.T) {
+	iv := setupColl("foo").Indexes()
+	newSpec := func(name string, keys bson.D) *IndexSpecification {
+		raw, err := bson.Marshal(keys)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return &IndexSpecification{Name: name, KeysDocument: raw}
+	}
+	hidden := true
+	ttl := int32(60)
+
+	idSpec := newSpec("_id_", bson.D{{"_id", 1}})
+	xSpec := newSpec("x_1", bson.D{{"x", int64(1)}})
+	ySpec := newSpec("y_1", bson.D{{"y", 1}})
+	ySpec.ExpireAfterSeconds = &ttl
+	hiddenSpec := newSpec("z_1", bson.D{{"z", 1}})
+	hiddenSpec.Hidden = &hidden
+	textSpec := newSpec("body_text", bson.D{{"_fts", "text"}, {"_ftsx", 1}})
+	specs := []*IndexSpecification{idSpec, xSpec, ySpec, hiddenSpec, textSpec}
+
+	t.Run("matching indexes", func(t *testing.T) {
+		models := []IndexModel{
+			{Keys: bson.D{{"x", 1}}},
+			{Keys: bson.D{{"y", 1}}, Options: options.Index().SetExpireAfterSeconds(60)},
+			{Keys: bson.D{{"z", 1}}, Options: options.Index().SetHidden(true)},
+			{Keys: bson.D{{"body", "text"}}, Options: options.Index().SetName("body_text")},
+		}
+		plan, err := iv.planSync(models, specs, false, true)
+		assert.Nil(t, err, "planSync error: %v", err)
+		assert.Equal(t, &IndexSyncPlan{}, plan, "expected empty plan, got %+v", plan)
+	})
+	t.Run("changes", func(t *testing.T) {
+		models := []IndexModel{
+			{Keys: bson.D{{"x", 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{"y", 1}}, Options: options.Index().SetExpireAfterSeconds(120)},
+			{Keys: bson.D{{"w", 1}}},
+		}
+		plan, err := iv.planSync(models, specs, false, true)
+		assert.Nil(t, err, "planSync error: %v", err)
+
+		expected := &IndexSyncPlan{
+			Create:  []IndexModel{models[2]},
+			Rebuild: []IndexSyncChange{{Existing: xSpec, Model: models[0]}},
+			Modify:  []IndexSyncChange{{Existing: ySpec, Model: models[1]}},
+			Drop:    []string{"z_1", "body_text"},
+		}
+		assert.Equal(t, expected, plan, "expected plan %+v, got %+v", expected, plan)
+	})
+	t.Run("adding a ttl rebuilds", func(t *testing.T) {
+		models := []IndexModel{{Keys: bson.D{{"x", 1}}, Options: options.Index().SetExpireAfterSeconds(60)}}
+		plan, err := iv.planSync(models, specs, false, false)
+		assert.Nil(t, err, "planSync error: %v", err)
+		assert.Equal(t, 1, len(plan.Rebuild), "expected 1 rebuild, got %v", len(plan.Rebuild))
+	})
+	t.Run("hide before drop", func(t *testing.T) {
+		plan, err := iv.planSync(nil, specs, true, true)
+		assert.Nil(t, err, "planSync error: %v", err)
+
+		expectedHide := []string{"x_1", "y_1", "body_text"}
+		assert.Equal(t, expectedHide, plan.Hide, "expected hidden indexes %v, got %v", expectedHide, plan.Hide)
+		expectedDrop := []string{"z_1"}
+		assert.Equal(t, expectedDrop, plan.Drop, "expected dropped indexes %v, got %v", expectedDrop, plan.Drop)
+	})
+	t.Run("nil keys", func(t *testing.T) {
+		_, err := iv.planSync([]IndexModel{{}}, specs, false, false)
+		assert.NotNil(t, err, "expected planSync error, got nil")
+	})
+}
+
+func TestIndexViewSyncRebuild(t *testing.T) {
+	listIndexesReply := bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{
+			{"id", int64(0)},
+			{"ns", "db.foo"},
+			{"firstBatch", bson.A{
+				bson.D{{"v", 2}, {"key", bson.D{{"_id", 1}}}, {"name", "_id_"}},
+				bson.D{{"v", 2}, {"key", bson.D{{"x", 1}}}, {"name", "x_1"}},
+			}},
+		}},
+	}
+	models := []IndexModel{{Keys: bson.D{{"x", 1}}, Options: options.Index().SetUnique(true)}}
+	sync := func(t *testing.T, opts *options.SyncIndexesOptions, replies ...bson.D) ([]string, error) {
+		t.Helper()
+		conn := &drivertest.ChannelConn{
+			Written:  make(chan []byte, len(replies)+1),
+			ReadResp: make(chan []byte, len(replies)),
+			Desc:     description.Server{WireVersion: &description.VersionRange{Max: 13}},
+		}
+		for _, reply := range replies {
+			doc, err := bson.Marshal(reply)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			conn.ReadResp <- drivertest.MakeReply(doc)
+		}
+		clientOpts := options.Client()
+		clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+
+		_, err = client.Database("db").Collection("foo").Indexes().Sync(bgCtx, models, opts)
+		close(conn.Written)
+		var cmds []string
+		for wm := range conn.Written {
+			cmd, cerr := drivertest.GetCommandFromMsgWireMessage(wm)
+			assert.Nil(t, cerr, "GetCommandFromMsgWireMessage error: %v", cerr)
+			cmds = append(cmds, cmd.Index(0).Key())
+		}
+		return cmds, err
+	}
+
+	t.Run("requires opt-in", func(t *testing.T) {
+		cmds, err := sync(t, nil, listIndexesReply)
+		assert.NotNil(t, err, "expected Sync error, got nil")
+		assert.True(t, strings.Contains(err.Error(), "indexes x_1 differ"), "unexpected error: %v", err)
+		want := []string{"listIndexes"}
+		assert.Equal(t, want, cmds, "expected commands %v, got %v", want, cmds)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		cmds, err := sync(t, options.SyncIndexes().SetDryRun(true), listIndexesReply)
+		assert.Nil(t, err, "Sync error: %v", err)
+		want := []string{"listIndexes"}
+		assert.Equal(t, want, cmds, "expected commands %v, got %v", want, cmds)
+	})
+	t.Run("rebuild changed", func(t *testing.T) {
+		cmds, err := sync(t, options.SyncIndexes().SetRebuildChanged(true), listIndexesReply,
+			bson.D{{"ok", 1}, {"nIndexesWas", 2}},
+			bson.D{{"ok", 1}, {"numIndexesBefore", 1}, {"numIndexesAfter", 2}},
+		)
+		assert.Nil(t, err, "Sync error: %v", err)
+		want := []string{"listIndexes", "dropIndexes", "createIndexes"}
+		assert.Equal(t, want, cmds, "expected commands %v, got %v", want, cmds)
+	})
+}
@@ -0,0 +1,586 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// expression is a compiled aggregation expression. Evaluating an expression that refers to a missing field yields the
+// zero Value.
+type expression interface {
+	eval(root bsoncore.Document) (bsoncore.Value, error)
+}
+
+type literalExpr bsoncore.Value
+
+func (e literalExpr) eval(bsoncore.Document) (bsoncore.Value, error) {
+	return bsoncore.Value(e), nil
+}
+
+// pathExpr is a field path such as "$a.b" or "$$ROOT.a.b". An empty path refers to the root document.
+type pathExpr []string
+
+func (e pathExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	val := bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: root}
+	return evalPath(val, e), nil
+}
+
+// evalPath returns the value at path in val. A path that traverses an array yields an array of the values found in
+// each element.
+func evalPath(val bsoncore.Value, path []string) bsoncore.Value {
+	if len(path) == 0 {
+		return val
+	}
+
+	switch val.Type {
+	case bsontype.EmbeddedDocument:
+		next, err := val.Document().LookupErr(path[0])
+		if err != nil {
+			return bsoncore.Value{}
+		}
+		return evalPath(next, path[1:])
+	case bsontype.Array:
+		elems, _ := val.Array().Values()
+		var results []bsoncore.Value
+		for _, elem := range elems {
+			if elem.Type != bsontype.EmbeddedDocument && elem.Type != bsontype.Array {
+				continue
+			}
+			if res := evalPath(elem, path); res.Type != 0 {
+				results = append(results, res)
+			}
+		}
+		return arrayValue(results)
+	}
+	return bsoncore.Value{}
+}
+
+// objectExpr is a document whose values are expressions.
+type objectExpr struct {
+	keys  []string
+	exprs []expression
+}
+
+func (e objectExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	elems := make([][]byte, 0, len(e.keys))
+	for i, key := range e.keys {
+		val, err := e.exprs[i].eval(root)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		if val.Type == 0 {
+			continue
+		}
+		elems = append(elems, bsoncore.AppendValueElement(nil, key, val))
+	}
+	return bsoncore.BuildDocumentValue(elems...), nil
+}
+
+type arrayExpr []expression
+
+func (e arrayExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	vals, err := evalAll(e, root)
+	if err != nil {
+		return bsoncore.Value{}, err
+	}
+	for i, val := range vals {
+		if val.Type == 0 {
+			vals[i] = nullValue
+		}
+	}
+	return arrayValue(vals), nil
+}
+
+// operatorExpr is an expression operator applied to its arguments.
+type operatorExpr struct {
+	op   string
+	args []expression
+	fn   func(args []bsoncore.Value) (bsoncore.Value, error)
+}
+
+func (e operatorExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	args, err := evalAll(e.args, root)
+	if err != nil {
+		return bsoncore.Value{}, err
+	}
+	return e.fn(args)
+}
+
+func evalAll(exprs []expression, root bsoncore.Document) ([]bsoncore.Value, error) {
+	vals := make([]bsoncore.Value, 0, len(exprs))
+	for _, expr := range exprs {
+		val, err := expr.eval(root)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+// logicalExpr implements $and and $or, which short-circuit.
+type logicalExpr struct {
+	and  bool
+	args []expression
+}
+
+func (e logicalExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	for _, arg := range e.args {
+		val, err := arg.eval(root)
+		if err != nil {
+			return bsoncore.Value{}, err
+		}
+		if truthy(val) != e.and {
+			return boolValue(!e.and), nil
+		}
+	}
+	return boolValue(e.and), nil
+}
+
+type condExpr struct {
+	cond, then, els expression
+}
+
+func (e condExpr) eval(root bsoncore.Document) (bsoncore.Value, error) {
+	cond, err := e.cond.eval(root)
+	if err != nil {
+		return bsoncore.Value{}, err
+	}
+	if truthy(cond) {
+		return e.then.eval(root)
+	}
+	return e.els.eval(root)
+}
+
+func compileExpression(val bsoncore.Value) (expression, error) {
+	switch val.Type {
+	case bsontype.String:
+		s := val.StringValue()
+		switch {
+		case strings.HasPrefix(s, "$$"):
+			parts := strings.Split(s[2:], ".")
+			if parts[0] != "ROOT" && parts[0] != "CURRENT" {
+				return nil, fmt.Errorf("unsupported variable %q", "$$"+parts[0])
+			}
+			return pathExpr(parts[1:]), nil
+		case strings.HasPrefix(s, "$"):
+			if len(s) == 1 {
+				return nil, errors.New("field path cannot be empty")
+			}
+			return pathExpr(strings.Split(s[1:], ".")), nil
+		}
+	case bsontype.Array:
+		values, err := val.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+		return compileArgs(values)
+	case bsontype.EmbeddedDocument:
+		elems, err := val.Document().Elements()
+		if err != nil {
+			return nil, err
+		}
+		if len(elems) > 0 && strings.HasPrefix(elems[0].Key(), "$") {
+			if len(elems) != 1 {
+				return nil, fmt.Errorf("an expression operator must be the only field in its document, but got %d fields",
+					len(elems))
+			}
+			return compileOperatorExpression(elems[0].Key(), elems[0].Value())
+		}
+
+		var obj objectExpr
+		for _, elem := range elems {
+			expr, err := compileExpression(elem.Value())
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, elem.Key())
+			obj.exprs = append(obj.exprs, expr)
+		}
+		return obj, nil
+	}
+	return literalExpr(val), nil
+}
+
+func compileArgs(values []bsoncore.Value) (arrayExpr, error) {
+	args := make(arrayExpr, 0, len(values))
+	for _, v := range values {
+		expr, err := compileExpression(v)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, expr)
+	}
+	return args, nil
+}
+
+// operatorArity holds the number of arguments of the operators with a fixed number of arguments.
+var operatorArity = map[string]int{
+	"$eq": 2, "$ne": 2, "$gt": 2, "$gte": 2, "$lt": 2, "$lte": 2, "$cmp": 2,
+	"$not": 1, "$in": 2, "$ifNull": 2, "$subtract": 2, "$divide": 2, "$mod": 2,
+	"$abs": 1, "$size": 1, "$toLower": 1, "$toUpper": 1,
+}
+
+func compileOperatorExpression(op string, val bsoncore.Value) (expression, error) {
+	if op == "$literal" {
+		return literalExpr(val), nil
+	}
+	if op == "$cond" {
+		return compileCond(val)
+	}
+
+	var args arrayExpr
+	if arr, ok := val.ArrayOK(); ok {
+		values, err := arr.Values()
+		if err != nil {
+			return nil, err
+		}
+		if args, err = compileArgs(values); err != nil {
+			return nil, err
+		}
+	} else {
+		arg, err := compileExpression(val)
+		if err != nil {
+			return nil, err
+		}
+		args = arrayExpr{arg}
+	}
+	if n, ok := operatorArity[op]; ok && len(args) != n {
+		return nil, fmt.Errorf("%s requires %d arguments, but got %d", op, n, len(args))
+	}
+
+	switch op {
+	case "$and", "$or":
+		return logicalExpr{and: op == "$and", args: args}, nil
+	}
+	fn, ok := operatorFuncs[op]
+	if !ok {
+		return nil, fmt.Errorf("unsupported expression operator %q", op)
+	}
+	return operatorExpr{op: op, args: args, fn: fn}, nil
+}
+
+func compileCond(val bsoncore.Value) (expression, error) {
+	var values []bsoncore.Value
+	switch val.Type {
+	case bsontype.Array:
+		values, _ = val.Array().Values()
+	case bsontype.EmbeddedDocument:
+		doc := val.Document()
+		for _, key := range []string{"if", "then", "else"} {
+			v, err := doc.LookupErr(key)
+			if err != nil {
+				return nil, fmt.Errorf("$cond requires the %q field", key)
+			}
+			values = append(values, v)
+		}
+	}
+	if len(values) != 3 {
+		return nil, errors.New("$cond requires an if, then, and else expression")
+	}
+
+	args, err := compileArgs(values)
+	if err != nil {
+		return nil, err
+	}
+	return condExpr{cond: args[0], then: args[1], els: args[2]}, nil
+}
+
+var operatorFuncs map[string]func([]bsoncore.Value) (bsoncore.Value, error)
+
+func init() {
+	comparison := func(test func(int) bool) func([]bsoncore.Value) (bsoncore.Value, error) {
+		return func(args []bsoncore.Value) (bsoncore.Value, error) {
+			return boolValue(test(bsoncore.CompareValues(args[0], args[1]))), nil
+		}
+	}
+
+	operatorFuncs = map[string]func([]bsoncore.Value) (bsoncore.Value, error){
+		"$eq":  comparison(func(c int) bool { return c == 0 }),
+		"$ne":  comparison(func(c int) bool { return c != 0 }),
+		"$gt":  comparison(func(c int) bool { return c > 0 }),
+		"$gte": comparison(func(c int) bool { return c >= 0 }),
+		"$lt":  comparison(func(c int) bool { return c < 0 }),
+		"$lte": comparison(func(c int) bool { return c <= 0 }),
+		"$cmp": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			return int32Value(int32(bsoncore.CompareValues(args[0], args[1]))), nil
+		},
+		"$not": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			return boolValue(!truthy(args[0])), nil
+		},
+		"$in": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			arr, ok := args[1].ArrayOK()
+			if !ok {
+				return bsoncore.Value{}, fmt.Errorf("$in requires an array as its second argument, but got %v",
+					typeName(args[1]))
+			}
+			elems, _ := arr.Values()
+			for _, elem := range elems {
+				if bsoncore.CompareValues(args[0], elem) == 0 {
+					return boolValue(true), nil
+				}
+			}
+			return boolValue(false), nil
+		},
+		"$ifNull": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			if args[0].Type == 0 || isNull(args[0]) {
+				return args[1], nil
+			}
+			return args[0], nil
+		},
+		"$add":      arithmetic("$add"),
+		"$subtract": arithmetic("$subtract"),
+		"$multiply": arithmetic("$multiply"),
+		"$divide":   arithmetic("$divide"),
+		"$mod":      arithmetic("$mod"),
+		"$abs":      absolute,
+		"$size": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			arr, ok := args[0].ArrayOK()
+			if !ok {
+				return bsoncore.Value{}, fmt.Errorf("$size requires an array, but got %v", typeName(args[0]))
+			}
+			elems, _ := arr.Values()
+			return int32Value(int32(len(elems))), nil
+		},
+		"$concat": func(args []bsoncore.Value) (bsoncore.Value, error) {
+			var sb strings.Builder
+			for _, arg := range args {
+				switch {
+				case arg.Type == 0 || isNull(arg):
+					return nullValue, nil
+				case arg.Type != bsontype.String:
+					return bsoncore.Value{}, fmt.Errorf("$concat requires strings, but got %v", typeName(arg))
+				}
+				sb.WriteString(arg.StringValue())
+			}
+			return stringValue(sb.String()), nil
+		},
+		"$toLower": caseConversion(strings.ToLower),
+		"$toUpper": caseConversion(strings.ToUpper),
+	}
+}
+
+func caseConversion(fn func(string) string) func([]bsoncore.Value) (bsoncore.Value, error) {
+	return func(args []bsoncore.Value) (bsoncore.Value, error) {
+		switch args[0].Type {
+		case 0, bsontype.Null, bsontype.Undefined:
+			return stringValue(""), nil
+		case bsontype.String, bsontype.Symbol:
+			return stringValue(fn(stringOf(args[0]))), nil
+		}
+		return bsoncore.Value{}, fmt.Errorf("$toLower and $toUpper require a string, but got %v", typeName(args[0]))
+	}
+}
+
+// arithmetic returns the implementation of an arithmetic operator. Integer results that overflow an int64 are
+// returned as doubles, and decimal operands are converted to doubles. $add and $subtract also accept dates.
+func arithmetic(op string) func([]bsoncore.Value) (bsoncore.Value, error) {
+	return func(args []bsoncore.Value) (bsoncore.Value, error) {
+		var ints []int64
+		var floats []float64
+		var date, useFloat, useLong bool
+		for i, arg := range args {
+			switch arg.Type {
+			case 0, bsontype.Null, bsontype.Undefined:
+				return nullValue, nil
+			case bsontype.Int32:
+				ints = append(ints, int64(arg.Int32()))
+				floats = append(floats, float64(arg.Int32()))
+				continue
+			case bsontype.Int64:
+				useLong = true
+				ints = append(ints, arg.Int64())
+				floats = append(floats, float64(arg.Int64()))
+				continue
+			case bsontype.Double:
+				useFloat = true
+				ints = append(ints, 0)
+				floats = append(floats, arg.Double())
+				continue
+			case bsontype.Decimal128:
+				useFloat = true
+				ints = append(ints, 0)
+				floats = append(floats, decimalToFloat(arg))
+				continue
+			case bsontype.DateTime:
+				// A date is allowed as any argument of $add and as the first argument of $subtract.
+				if (op == "$add" && !date) || (op == "$subtract" && i == 0) {
+					date = true
+					ints = append(ints, arg.DateTime())
+					floats = append(floats, float64(arg.DateTime()))
+					continue
+				}
+			}
+			return bsoncore.Value{}, fmt.Errorf("%s only supports numeric types, but got %v", op, typeName(arg))
+		}
+
+		var res bsoncore.Value
+		var err error
+		switch op {
+		case "$divide":
+			if floats[1] == 0 {
+				return bsoncore.Value{}, errors.New("$divide cannot divide by zero")
+			}
+			return doubleValue(floats[0] / floats[1]), nil
+		case "$mod":
+			if floats[1] == 0 {
+				return bsoncore.Value{}, errors.New("$mod cannot divide by zero")
+			}
+			if useFloat {
+				return doubleValue(math.Mod(floats[0], floats[1])), nil
+			}
+			res = integerValue(ints[0]%ints[1], useLong)
+		default:
+			res, err = accumulate(op, ints, floats, useFloat, useLong)
+			if err != nil {
+				return bsoncore.Value{}, err
+			}
+		}
+
+		if date && res.IsNumber() {
+			// Date arithmetic yields a date, except for the difference between two dates.
+			if op == "$subtract" && args[1].Type == bsontype.DateTime {
+				return res, nil
+			}
+			ms, _ := res.AsInt64OK()
+			return bsoncore.Value{Type: bsontype.DateTime, Data: bsoncore.AppendDateTime(nil, ms)}, nil
+		}
+		return res, nil
+	}
+}
+
+// accumulate applies $add, $subtract, or $multiply to the arguments.
+func accumulate(op string, ints []int64, floats []float64, useFloat, useLong bool) (bsoncore.Value, error) {
+	if useFloat {
+		f := floats[0]
+		for _, x := range floats[1:] {
+			switch op {
+			case "$add":
+				f += x
+			case "$subtract":
+				f -= x
+			case "$multiply":
+				f *= x
+			}
+		}
+		return doubleValue(f), nil
+	}
+
+	if len(ints) == 0 {
+		return int32Value(int32(identity(op))), nil
+	}
+	n := ints[0]
+	for i, x := range ints[1:] {
+		var next int64
+		var overflow bool
+		switch op {
+		case "$add":
+			next = n + x
+			overflow = (x > 0 && next < n) || (x < 0 && next > n)
+		case "$subtract":
+			next = n - x
+			overflow = (x < 0 && next < n) || (x > 0 && next > n)
+		case "$multiply":
+			next = n * x
+			overflow = n != 0 && (next/n != x || (n == -1 && x == math.MinInt64))
+		}
+		if overflow {
+			return accumulate(op, nil, append([]float64{float64(n)}, floats[i+1:]...), true, false)
+		}
+		n = next
+	}
+	return integerValue(n, useLong), nil
+}
+
+func identity(op string) float64 {
+	if op == "$multiply" {
+		return 1
+	}
+	return 0
+}
+
+func absolute(args []bsoncore.Value) (bsoncore.Value, error) {
+	arg := args[0]
+	switch arg.Type {
+	case 0, bsontype.Null, bsontype.Undefined:
+		return nullValue, nil
+	case bsontype.Int32:
+		return integerValue(abs64(int64(arg.Int32())), false), nil
+	case bsontype.Int64:
+		if arg.Int64() == math.MinInt64 {
+			return bsoncore.Value{}, errors.New("$abs cannot represent the absolute value of the minimum int64")
+		}
+		return int64Value(abs64(arg.Int64())), nil
+	case bsontype.Double:
+		return doubleValue(math.Abs(arg.Double())), nil
+	case bsontype.Decimal128:
+		return doubleValue(math.Abs(decimalToFloat(arg))), nil
+	}
+	return bsoncore.Value{}, fmt.Errorf("$abs only supports numeric types, but got %v", typeName(arg))
+}
+
+func abs64(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func decimalToFloat(v bsoncore.Value) float64 {
+	// ParseFloat returns the nearest float, or an infinity, for values that are out of range.
+	f, _ := strconv.ParseFloat(v.Decimal128().String(), 64)
+	return f
+}
+
+func typeName(v bsoncore.Value) string {
+	if v.Type == 0 {
+		return "missing"
+	}
+	return v.Type.String()
+}
+
+var nullValue = bsoncore.Value{Type: bsontype.Null}
+
+func boolValue(b bool) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.Boolean, Data: bsoncore.AppendBoolean(nil, b)}
+}
+
+func int32Value(i int32) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, i)}
+}
+
+func int64Value(i int64) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.Int64, Data: bsoncore.AppendInt64(nil, i)}
+}
+
+// integerValue returns i as an int32 if it fits and long is false, and as an int64 otherwise.
+func integerValue(i int64, long bool) bsoncore.Value {
+	if !long && i >= math.MinInt32 && i <= math.MaxInt32 {
+		return int32Value(int32(i))
+	}
+	return int64Value(i)
+}
+
+func doubleValue(f float64) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.Double, Data: bsoncore.AppendDouble(nil, f)}
+}
+
+func stringValue(s string) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, s)}
+}
+
+func arrayValue(vals []bsoncore.Value) bsoncore.Value {
+	return bsoncore.Value{Type: bsontype.Array, Data: bsoncore.BuildArray(nil, vals...)}
+}
@@ -0,0 +1,648 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package matcher evaluates query filters against documents without a round trip to the server. It can be used to
+// filter documents held in memory, such as cached documents or change stream events, and to implement fakes for unit
+// tests:
+//
+//	m, err := matcher.Compile(bson.D{{"qty", bson.D{{"$gt", 20}}}, {"tags", "red"}})
+//	if err != nil {
+//		return err
+//	}
+//	ok, err := m.Match(doc)
+//
+// A Matcher follows the server's semantics for dotted paths that traverse arrays, comparisons between values of
+// different types, and queries for null and missing fields. Strings are compared by their UTF-8 bytes, as they are on
+// the server when no collation is specified.
+//
+// The supported query operators are $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $and, $or, $nor, $not, $exists,
+// $type, $regex, $mod, $all, $elemMatch, $size, $expr, and $comment. Regular expressions are evaluated with the regexp
+// package, so PCRE features that RE2 does not support, such as backreferences, are rejected by Compile. The
+// aggregation expressions supported by $expr are listed in the Compile documentation.
+package matcher // import "go.mongodb.org/mongo-driver/mongo/matcher"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Matcher is a compiled query filter. A Matcher is safe for concurrent use.
+type Matcher struct {
+	pred docPredicate
+}
+
+// Compile compiles a query filter. The filter can be any value that marshals to a BSON document, such as a bson.D or a
+// filter.Filter. It is marshalled with bson.DefaultRegistry.
+//
+// The aggregation expressions supported in $expr are field paths, the $$ROOT and $$CURRENT variables, literal values,
+// and the $literal, $eq, $ne, $gt, $gte, $lt, $lte, $cmp, $and, $or, $not, $in, $cond, $ifNull, $add, $subtract,
+// $multiply, $divide, $mod, $abs, $size, $concat, $toLower, and $toUpper operators.
+func Compile(filter interface{}) (*Matcher, error) {
+	if filter == nil {
+		return nil, errors.New("filter cannot be nil")
+	}
+	b, err := bson.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	pred, err := compileFilter(bsoncore.Document(b))
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{pred: pred}, nil
+}
+
+// Match returns true if doc matches the filter. An error is only returned if evaluating a $expr expression fails.
+func (m *Matcher) Match(doc bson.Raw) (bool, error) {
+	return m.pred.match(bsoncore.Document(doc))
+}
+
+// Match compiles filter and reports whether doc matches it.
+func Match(filter interface{}, doc bson.Raw) (bool, error) {
+	m, err := Compile(filter)
+	if err != nil {
+		return false, err
+	}
+	return m.Match(doc)
+}
+
+// docPredicate is a predicate on a whole document.
+type docPredicate interface {
+	match(doc bsoncore.Document) (bool, error)
+}
+
+// valuePredicate is a predicate on the values found at a field path.
+type valuePredicate interface {
+	match(c *candidates) bool
+}
+
+type andPredicate []docPredicate
+
+func (p andPredicate) match(doc bsoncore.Document) (bool, error) {
+	for _, pred := range p {
+		ok, err := pred.match(doc)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type orPredicate []docPredicate
+
+func (p orPredicate) match(doc bsoncore.Document) (bool, error) {
+	for _, pred := range p {
+		ok, err := pred.match(doc)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+type norPredicate []docPredicate
+
+func (p norPredicate) match(doc bsoncore.Document) (bool, error) {
+	ok, err := orPredicate(p).match(doc)
+	return !ok, err
+}
+
+type fieldPredicate struct {
+	path []string
+	pred valuePredicate
+}
+
+func (p fieldPredicate) match(doc bsoncore.Document) (bool, error) {
+	c := new(candidates)
+	c.collect(doc, p.path)
+	matched := p.pred.match(c)
+	return matched && c.err == nil, c.err
+}
+
+type exprPredicate struct {
+	expr expression
+}
+
+func (p exprPredicate) match(doc bsoncore.Document) (bool, error) {
+	val, err := p.expr.eval(doc)
+	if err != nil {
+		return false, err
+	}
+	return truthy(val), nil
+}
+
+func compileFilter(doc bsoncore.Document) (docPredicate, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	preds := make(andPredicate, 0, len(elems))
+	for _, elem := range elems {
+		key, val := elem.Key(), elem.Value()
+		switch key {
+		case "$and", "$or", "$nor":
+			clauses, err := compileClauses(key, val)
+			if err != nil {
+				return nil, err
+			}
+			switch key {
+			case "$and":
+				preds = append(preds, andPredicate(clauses))
+			case "$or":
+				preds = append(preds, orPredicate(clauses))
+			default:
+				preds = append(preds, norPredicate(clauses))
+			}
+		case "$expr":
+			expr, err := compileExpression(val)
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, exprPredicate{expr: expr})
+		case "$comment":
+		default:
+			if strings.HasPrefix(key, "$") {
+				return nil, fmt.Errorf("unsupported top-level operator %q", key)
+			}
+			pred, err := compileFieldValue(val)
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, fieldPredicate{path: strings.Split(key, "."), pred: pred})
+		}
+	}
+	return preds, nil
+}
+
+func compileClauses(op string, val bsoncore.Value) ([]docPredicate, error) {
+	arr, ok := val.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("%s requires an array, but got %v", op, val.Type)
+	}
+	values, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s requires a non-empty array", op)
+	}
+
+	clauses := make([]docPredicate, 0, len(values))
+	for _, v := range values {
+		doc, ok := v.DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("%s requires an array of documents, but got an element of type %v", op, v.Type)
+		}
+		clause, err := compileFilter(doc)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	return clauses, nil
+}
+
+// isOperatorDocument returns true if val is a document whose first key begins with '$'.
+func isOperatorDocument(val bsoncore.Value) bool {
+	doc, ok := val.DocumentOK()
+	if !ok {
+		return false
+	}
+	elem, err := doc.IndexErr(0)
+	return err == nil && strings.HasPrefix(elem.Key(), "$")
+}
+
+// compileFieldValue compiles the value of a field in a filter, which is either an operator document or a value to
+// compare for equality.
+func compileFieldValue(val bsoncore.Value) (valuePredicate, error) {
+	if isOperatorDocument(val) {
+		return compileOperators(val.Document())
+	}
+	if val.Type == bsontype.Regex {
+		return compileRegex(val.Regex())
+	}
+	return eqPredicate{val: val}, nil
+}
+
+type allOf []valuePredicate
+
+func (p allOf) match(c *candidates) bool {
+	for _, pred := range p {
+		if !pred.match(c) {
+			return false
+		}
+	}
+	return true
+}
+
+type notPredicate struct {
+	pred valuePredicate
+}
+
+func (p notPredicate) match(c *candidates) bool {
+	return !p.pred.match(c)
+}
+
+func compileOperators(doc bsoncore.Document) (valuePredicate, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	var preds allOf
+	var pattern, options *string
+	for _, elem := range elems {
+		op, val := elem.Key(), elem.Value()
+		switch op {
+		case "$regex":
+			switch val.Type {
+			case bsontype.String:
+				s := val.StringValue()
+				pattern = &s
+			case bsontype.Regex:
+				p, o := val.Regex()
+				pattern = &p
+				if options == nil {
+					options = &o
+				}
+			default:
+				return nil, fmt.Errorf("$regex requires a string or regular expression, but got %v", val.Type)
+			}
+			continue
+		case "$options":
+			s, ok := val.StringValueOK()
+			if !ok {
+				return nil, fmt.Errorf("$options requires a string, but got %v", val.Type)
+			}
+			options = &s
+			continue
+		}
+
+		pred, err := compileOperator(op, val)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+
+	if pattern == nil && options != nil {
+		return nil, errors.New("$options requires a $regex")
+	}
+	if pattern != nil {
+		var opts string
+		if options != nil {
+			opts = *options
+		}
+		pred, err := compileRegex(*pattern, opts)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	if len(preds) == 1 {
+		return preds[0], nil
+	}
+	return preds, nil
+}
+
+func compileOperator(op string, val bsoncore.Value) (valuePredicate, error) {
+	switch op {
+	case "$eq":
+		return eqPredicate{val: val}, nil
+	case "$ne":
+		return notPredicate{pred: eqPredicate{val: val}}, nil
+	case "$gt", "$gte", "$lt", "$lte":
+		return comparePredicate{op: op, val: val}, nil
+	case "$in", "$nin":
+		pred, err := compileIn(op, val)
+		if err != nil {
+			return nil, err
+		}
+		if op == "$nin" {
+			return notPredicate{pred: pred}, nil
+		}
+		return pred, nil
+	case "$exists":
+		return existsPredicate(truthy(val)), nil
+	case "$type":
+		return compileType(val)
+	case "$not":
+		if val.Type == bsontype.Regex {
+			pred, err := compileRegex(val.Regex())
+			if err != nil {
+				return nil, err
+			}
+			return notPredicate{pred: pred}, nil
+		}
+		if !isOperatorDocument(val) {
+			return nil, fmt.Errorf("$not requires a regular expression or an operator document, but got %v", val.Type)
+		}
+		pred, err := compileOperators(val.Document())
+		if err != nil {
+			return nil, err
+		}
+		return notPredicate{pred: pred}, nil
+	case "$elemMatch":
+		return compileElemMatch(val)
+	case "$size":
+		size, ok := integral(val)
+		if !ok || size < 0 {
+			return nil, fmt.Errorf("$size requires a non-negative integer, but got %v", val)
+		}
+		return sizePredicate(size), nil
+	case "$all":
+		return compileAll(val)
+	case "$mod":
+		return compileMod(val)
+	}
+	return nil, fmt.Errorf("unsupported operator %q", op)
+}
+
+func compileIn(op string, val bsoncore.Value) (valuePredicate, error) {
+	arr, ok := val.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("%s requires an array, but got %v", op, val.Type)
+	}
+	values, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var pred inPredicate
+	for _, v := range values {
+		switch {
+		case v.Type == bsontype.Regex:
+			re, err := compileRegex(v.Regex())
+			if err != nil {
+				return nil, err
+			}
+			pred = append(pred, re)
+		case isOperatorDocument(v):
+			return nil, fmt.Errorf("%s cannot contain an operator document", op)
+		default:
+			pred = append(pred, eqPredicate{val: v})
+		}
+	}
+	return pred, nil
+}
+
+func compileElemMatch(val bsoncore.Value) (valuePredicate, error) {
+	doc, ok := val.DocumentOK()
+	if !ok {
+		return nil, fmt.Errorf("$elemMatch requires a document, but got %v", val.Type)
+	}
+
+	// A document of query operators, such as {$gte: 80}, is applied to each element. Any other document is a filter
+	// for elements that are documents.
+	if elem, err := doc.IndexErr(0); err == nil && strings.HasPrefix(elem.Key(), "$") {
+		switch elem.Key() {
+		case "$and", "$or", "$nor", "$expr", "$comment":
+		default:
+			pred, err := compileOperators(doc)
+			if err != nil {
+				return nil, err
+			}
+			return elemMatchPredicate{value: pred}, nil
+		}
+	}
+	pred, err := compileFilter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return elemMatchPredicate{doc: pred}, nil
+}
+
+func compileAll(val bsoncore.Value) (valuePredicate, error) {
+	arr, ok := val.ArrayOK()
+	if !ok {
+		return nil, fmt.Errorf("$all requires an array, but got %v", val.Type)
+	}
+	values, err := arr.Values()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nonePredicate{}, nil
+	}
+
+	var preds allOf
+	for _, v := range values {
+		if doc, ok := v.DocumentOK(); ok && isOperatorDocument(v) {
+			elem, _ := doc.IndexErr(0)
+			if elem.Key() != "$elemMatch" {
+				return nil, fmt.Errorf("$all can only contain $elemMatch operator documents, but got %q", elem.Key())
+			}
+			pred, err := compileElemMatch(elem.Value())
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, pred)
+			continue
+		}
+		if v.Type == bsontype.Regex {
+			pred, err := compileRegex(v.Regex())
+			if err != nil {
+				return nil, err
+			}
+			preds = append(preds, pred)
+			continue
+		}
+		preds = append(preds, eqPredicate{val: v})
+	}
+	return preds, nil
+}
+
+func compileMod(val bsoncore.Value) (valuePredicate, error) {
+	var values []bsoncore.Value
+	if arr, ok := val.ArrayOK(); ok {
+		values, _ = arr.Values()
+	}
+	if len(values) != 2 {
+		return nil, errors.New("$mod requires an array of a divisor and a remainder")
+	}
+	divisor, ok := truncated(values[0])
+	if !ok || divisor == 0 {
+		return nil, fmt.Errorf("$mod requires a non-zero numeric divisor, but got %v", values[0])
+	}
+	remainder, ok := truncated(values[1])
+	if !ok {
+		return nil, fmt.Errorf("$mod requires a numeric remainder, but got %v", values[1])
+	}
+	return modPredicate{divisor: divisor, remainder: remainder}, nil
+}
+
+// typeAliases maps the string aliases accepted by $type to BSON types. The "number" alias is handled separately.
+var typeAliases = map[string]bsontype.Type{
+	"double":              bsontype.Double,
+	"string":              bsontype.String,
+	"object":              bsontype.EmbeddedDocument,
+	"array":               bsontype.Array,
+	"binData":             bsontype.Binary,
+	"undefined":           bsontype.Undefined,
+	"objectId":            bsontype.ObjectID,
+	"bool":                bsontype.Boolean,
+	"date":                bsontype.DateTime,
+	"null":                bsontype.Null,
+	"regex":               bsontype.Regex,
+	"dbPointer":           bsontype.DBPointer,
+	"javascript":          bsontype.JavaScript,
+	"symbol":              bsontype.Symbol,
+	"javascriptWithScope": bsontype.CodeWithScope,
+	"int":                 bsontype.Int32,
+	"timestamp":           bsontype.Timestamp,
+	"long":                bsontype.Int64,
+	"decimal":             bsontype.Decimal128,
+	"minKey":              bsontype.MinKey,
+	"maxKey":              bsontype.MaxKey,
+}
+
+func compileType(val bsoncore.Value) (valuePredicate, error) {
+	values := []bsoncore.Value{val}
+	if arr, ok := val.ArrayOK(); ok {
+		values, _ = arr.Values()
+	}
+	if len(values) == 0 {
+		return nil, errors.New("$type requires at least one type")
+	}
+
+	pred := typePredicate{types: make(map[bsontype.Type]bool)}
+	for _, v := range values {
+		if s, ok := v.StringValueOK(); ok {
+			if s == "number" {
+				pred.number = true
+				continue
+			}
+			t, ok := typeAliases[s]
+			if !ok {
+				return nil, fmt.Errorf("unknown $type alias %q", s)
+			}
+			pred.types[t] = true
+			continue
+		}
+
+		n, ok := integral(v)
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("$type requires a type number or alias, but got %v", v)
+		case n == -1:
+			pred.types[bsontype.MinKey] = true
+		case n >= 1 && n <= 19 || n == 127:
+			pred.types[bsontype.Type(n)] = true
+		default:
+			return nil, fmt.Errorf("invalid $type number %d", n)
+		}
+	}
+	return pred, nil
+}
+
+// integral returns the value of v if it is a number with an integral value.
+func integral(v bsoncore.Value) (int64, bool) {
+	switch v.Type {
+	case bsontype.Int32, bsontype.Int64:
+		return v.AsInt64(), true
+	case bsontype.Double:
+		f := v.Double()
+		if f != float64(int64(f)) {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// truncated returns the value of v truncated to an integer if v is a finite number.
+func truncated(v bsoncore.Value) (int64, bool) {
+	switch v.Type {
+	case bsontype.Int32, bsontype.Int64, bsontype.Double:
+		return v.AsInt64OK()
+	case bsontype.Decimal128:
+		f := decimalToFloat(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// truthy returns whether v is true according to the server's rules for $exists and aggregation expressions.
+func truthy(v bsoncore.Value) bool {
+	switch v.Type {
+	case 0, bsontype.Null, bsontype.Undefined:
+		return false
+	case bsontype.Boolean:
+		return v.Boolean()
+	case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return bsoncore.CompareValues(v, zero) != 0
+	}
+	return true
+}
+
+var zero = bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 0)}
+
+// regexOptions maps the regular expression options supported by the server to regexp flags. The "u" option has no
+// equivalent because regexp always matches UTF-8, and "x" is handled by stripping whitespace and comments.
+var regexOptions = map[rune]string{'i': "i", 'm': "m", 's': "s", 'u': "", 'x': ""}
+
+func compileRegex(pattern, options string) (valuePredicate, error) {
+	var flags string
+	for _, o := range options {
+		flag, ok := regexOptions[o]
+		if !ok {
+			return nil, fmt.Errorf("invalid regular expression option %q", o)
+		}
+		flags += flag
+	}
+	expr := pattern
+	if strings.ContainsRune(options, 'x') {
+		expr = stripExtended(expr)
+	}
+	if flags != "" {
+		expr = "(?" + flags + ")" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported regular expression %q: %v", pattern, err)
+	}
+	return regexPredicate{re: re, pattern: pattern, options: options}, nil
+}
+
+// stripExtended removes unescaped whitespace and comments from a regular expression that uses the "x" option.
+func stripExtended(pattern string) string {
+	var sb strings.Builder
+	inClass, comment := false, false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case comment:
+			comment = c != '\n'
+		case c == '\\' && i+1 < len(pattern):
+			sb.WriteByte(c)
+			sb.WriteByte(pattern[i+1])
+			i++
+		case inClass:
+			inClass = c != ']'
+			sb.WriteByte(c)
+		case c == '[':
+			inClass = true
+			sb.WriteByte(c)
+		case c == '#':
+			comment = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
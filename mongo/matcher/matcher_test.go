@@ -0,0 +1,284 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package matcher
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+const crudTestsDir = "../../data/crud/v1"
+
+func mustDoc(t *testing.T, extJSON string) bson.Raw {
+	t.Helper()
+
+	var doc bson.Raw
+	err := bson.UnmarshalExtJSON([]byte(extJSON), false, &doc)
+	assert.Nil(t, err, "UnmarshalExtJSON error for %v: %v", extJSON, err)
+	return doc
+}
+
+func TestMatcher(t *testing.T) {
+	doc := `{
+		"_id": 1,
+		"name": "Widget",
+		"qty": {"$numberLong": "25"},
+		"price": 9.5,
+		"tags": ["red", "blue"],
+		"dims": [[10, 20], [30]],
+		"nullField": null,
+		"re": {"$regularExpression": {"pattern": "^ w i d # comment", "options": "x"}},
+		"size": {"h": 14, "w": 21, "uom": "cm"},
+		"items": [
+			{"sku": "a", "qty": 5, "scores": [80, 90]},
+			{"sku": "b", "qty": 15}
+		]
+	}`
+
+	testCases := []struct {
+		name    string
+		filter  string
+		matches bool
+	}{
+		{"empty", `{}`, true},
+		{"implicit equality", `{"name": "Widget"}`, true},
+		{"numeric equality across types", `{"qty": 25.0}`, true},
+		{"equality with array element", `{"tags": "red"}`, true},
+		{"equality with whole array", `{"tags": ["red", "blue"]}`, true},
+		{"equality with reordered array", `{"tags": ["blue", "red"]}`, false},
+		{"equality with embedded document", `{"size": {"h": 14, "w": 21, "uom": "cm"}}`, true},
+		{"equality with reordered embedded document", `{"size": {"w": 21, "h": 14, "uom": "cm"}}`, false},
+		{"dotted path", `{"size.uom": "cm"}`, true},
+		{"dotted path into array", `{"items.sku": "b"}`, true},
+		{"dotted path into nested array", `{"items.scores": 90}`, true},
+		{"array index", `{"items.1.sku": "b"}`, true},
+		{"array index out of range", `{"items.5.sku": {"$exists": true}}`, false},
+		{"nested array element", `{"dims": [30]}`, true},
+		{"null matches null", `{"nullField": null}`, true},
+		{"null matches missing", `{"missing": null}`, true},
+		{"null matches missing in array element", `{"items.scores": null}`, true},
+		{"null does not match present", `{"name": null}`, false},
+		{"ne", `{"name": {"$ne": "Gadget"}}`, true},
+		{"ne with array element", `{"tags": {"$ne": "red"}}`, false},
+		{"gt", `{"qty": {"$gt": 20}}`, true},
+		{"gt decimal", `{"price": {"$gt": {"$numberDecimal": "9.49"}}}`, true},
+		{"type bracketing", `{"name": {"$gt": 5}}`, false},
+		{"string comparison", `{"name": {"$lt": "widget"}}`, true},
+		{"gt minKey", `{"name": {"$gt": {"$minKey": 1}}}`, true},
+		{"gte null matches missing", `{"missing": {"$gte": null}}`, true},
+		{"lt with array elements", `{"items.qty": {"$lt": 10}}`, true},
+		{"range satisfied by different elements", `{"items.qty": {"$gt": 6, "$lt": 10}}`, true},
+		{"in", `{"tags": {"$in": ["green", "blue"]}}`, true},
+		{"in with regex", `{"name": {"$in": [{"$regularExpression": {"pattern": "^wid", "options": "i"}}]}}`, true},
+		{"in with null", `{"missing": {"$in": [null]}}`, true},
+		{"nin", `{"tags": {"$nin": ["green", "blue"]}}`, false},
+		{"exists", `{"size.h": {"$exists": true}}`, true},
+		{"exists false", `{"size.d": {"$exists": false}}`, true},
+		{"exists null", `{"nullField": {"$exists": true}}`, true},
+		{"type alias", `{"qty": {"$type": "long"}}`, true},
+		{"type number", `{"price": {"$type": "number"}}`, true},
+		{"type array", `{"tags": {"$type": "array"}}`, true},
+		{"type array elements", `{"tags": {"$type": 2}}`, true},
+		{"type mismatch", `{"name": {"$type": ["int", "double"]}}`, false},
+		{"regex", `{"name": {"$regex": "^W.*t$"}}`, true},
+		{"regex options", `{"name": {"$regex": "^widget", "$options": "i"}}`, true},
+		{"regex extended", `{"name": {"$regex": "^ w i d # comment", "$options": "ix"}}`, true},
+		{"regex literal", `{"tags": {"$regularExpression": {"pattern": "^bl", "options": ""}}}`, true},
+		{"regex equality", `{"re": {"$regularExpression": {"pattern": "^ w i d # comment", "options": "x"}}}`, true},
+		{"regex equality stripped pattern", `{"re": {"$regularExpression": {"pattern": "^wid", "options": "x"}}}`, false},
+		{"not", `{"qty": {"$not": {"$gt": 30}}}`, true},
+		{"not regex", `{"name": {"$not": {"$regularExpression": {"pattern": "^W", "options": ""}}}}`, false},
+		{"not missing", `{"missing": {"$not": {"$gt": 30}}}`, true},
+		{"elemMatch document", `{"items": {"$elemMatch": {"sku": "a", "qty": {"$gt": 4}}}}`, true},
+		{"elemMatch document across elements", `{"items": {"$elemMatch": {"sku": "a", "qty": 15}}}`, false},
+		{"elemMatch value", `{"items.scores": {"$elemMatch": {"$gte": 85, "$lt": 95}}}`, true},
+		{"elemMatch value no match", `{"items.scores": {"$elemMatch": {"$gt": 80, "$lt": 90}}}`, false},
+		{"elemMatch value nested array", `{"dims": {"$elemMatch": {"$eq": 30}}}`, false},
+		{"elemMatch value whole nested array", `{"dims": {"$elemMatch": {"$eq": [30]}}}`, true},
+		{"elemMatch nested", `{"items": {"$elemMatch": {"scores": {"$elemMatch": {"$gt": 85}}}}}`, true},
+		{"size", `{"tags": {"$size": 2}}`, true},
+		{"size mismatch", `{"tags": {"$size": 3}}`, false},
+		{"all", `{"tags": {"$all": ["blue", "red"]}}`, true},
+		{"all missing value", `{"tags": {"$all": ["blue", "green"]}}`, false},
+		{"all empty", `{"tags": {"$all": []}}`, false},
+		{"all elemMatch", `{"items": {"$all": [{"$elemMatch": {"sku": "a"}}, {"$elemMatch": {"qty": 15}}]}}`, true},
+		{"mod", `{"qty": {"$mod": [10, 5]}}`, true},
+		{"and", `{"$and": [{"qty": {"$gt": 20}}, {"name": "Widget"}]}`, true},
+		{"or", `{"$or": [{"qty": {"$gt": 100}}, {"name": "Widget"}]}`, true},
+		{"nor", `{"$nor": [{"qty": {"$gt": 100}}, {"name": "Gadget"}]}`, true},
+		{"comment", `{"$comment": "ignored", "name": "Widget"}`, true},
+		{"expr comparison", `{"$expr": {"$gt": ["$qty", "$price"]}}`, true},
+		{"expr arithmetic", `{"$expr": {"$eq": [{"$multiply": ["$size.h", 1.5]}, 21]}}`, true},
+		{"expr cond", `{"$expr": {"$cond": {"if": {"$gte": ["$qty", 25]}, "then": true, "else": false}}}`, true},
+		{"expr missing field", `{"$expr": {"$eq": ["$missing", null]}}`, false},
+		{"expr ifNull", `{"$expr": {"$eq": [{"$ifNull": ["$missing", "default"]}, "default"]}}`, true},
+		{"expr size and in", `{"$expr": {"$and": [{"$eq": [{"$size": "$tags"}, 2]}, {"$in": ["red", "$tags"]}]}}`, true},
+		{"expr array path", `{"$expr": {"$eq": ["$items.sku", ["a", "b"]]}}`, true},
+		{"expr string", `{"$expr": {"$eq": [{"$toUpper": {"$concat": ["$name", "-", "$size.uom"]}}, "WIDGET-CM"]}}`, true},
+	}
+
+	raw := mustDoc(t, doc)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Compile(mustDoc(t, tc.filter))
+			assert.Nil(t, err, "Compile error: %v", err)
+			matched, err := m.Match(raw)
+			assert.Nil(t, err, "Match error: %v", err)
+			assert.Equal(t, tc.matches, matched, "expected match %v for filter %v, got %v", tc.matches, tc.filter,
+				matched)
+		})
+	}
+
+	compileErrors := []struct {
+		name   string
+		filter string
+	}{
+		{"unknown operator", `{"a": {"$foo": 1}}`},
+		{"unsupported top-level operator", `{"$where": "true"}`},
+		{"empty or", `{"$or": []}`},
+		{"in without array", `{"a": {"$in": 1}}`},
+		{"not with value", `{"a": {"$not": 1}}`},
+		{"options without regex", `{"a": {"$options": "i"}}`},
+		{"unsupported regex", `{"a": {"$regex": "(a)\\1"}}`},
+		{"zero mod divisor", `{"a": {"$mod": [0, 1]}}`},
+		{"unknown type alias", `{"a": {"$type": "foo"}}`},
+		{"unsupported expression operator", `{"$expr": {"$foo": 1}}`},
+		{"wrong expression arity", `{"$expr": {"$eq": [1]}}`},
+	}
+	for _, tc := range compileErrors {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(mustDoc(t, tc.filter))
+			assert.NotNil(t, err, "expected Compile error for %v, got nil", tc.filter)
+		})
+	}
+
+	t.Run("expression error", func(t *testing.T) {
+		_, err := Match(mustDoc(t, `{"$expr": {"$gt": [{"$divide": ["$qty", 0]}, 1]}}`), raw)
+		assert.NotNil(t, err, "expected Match error, got nil")
+	})
+}
+
+type crudFixture struct {
+	Data  []bson.Raw `bson:"data"`
+	Tests []struct {
+		Description string `bson:"description"`
+		Operation   struct {
+			Name      string   `bson:"name"`
+			Arguments bson.Raw `bson:"arguments"`
+		} `bson:"operation"`
+		Outcome struct {
+			Result bson.RawValue `bson:"result"`
+		} `bson:"outcome"`
+	} `bson:"tests"`
+}
+
+// TestMatcherCRUDSpec checks the matcher against the documents that the CRUD spec tests expect each filter to select.
+func TestMatcherCRUDSpec(t *testing.T) {
+	var checked int
+	for _, dir := range []string{"read", "write"} {
+		files, err := ioutil.ReadDir(path.Join(crudTestsDir, dir))
+		assert.Nil(t, err, "ReadDir error: %v", err)
+
+		for _, file := range files {
+			if !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			content, err := ioutil.ReadFile(path.Join(crudTestsDir, dir, file.Name()))
+			assert.Nil(t, err, "ReadFile error: %v", err)
+			var fixture crudFixture
+			err = bson.UnmarshalExtJSON(content, false, &fixture)
+			assert.Nil(t, err, "UnmarshalExtJSON error for %v: %v", file.Name(), err)
+
+			for _, test := range fixture.Tests {
+				args := test.Operation.Arguments
+				filter, err := args.LookupErr("filter")
+				if err != nil || len(filter.Value) == 0 {
+					continue
+				}
+				if _, err := args.LookupErr("collation"); err == nil {
+					continue
+				}
+
+				name := file.Name() + "/" + test.Description
+				if checkCRUDTest(t, name, fixture.Data, test.Operation.Name, args, filter.Document(),
+					test.Outcome.Result) {
+					checked++
+				}
+			}
+		}
+	}
+	assert.True(t, checked > 20, "expected more than 20 CRUD spec tests to be checked, got %v", checked)
+}
+
+// checkCRUDTest checks a single CRUD spec test and returns false if the test's operation is not supported.
+func checkCRUDTest(t *testing.T, name string, data []bson.Raw, op string, args, filter bson.Raw,
+	result bson.RawValue) bool {
+
+	t.Helper()
+
+	m, err := Compile(filter)
+	assert.Nil(t, err, "%v: Compile error: %v", name, err)
+	var matched []bson.Raw
+	for _, doc := range data {
+		ok, err := m.Match(doc)
+		assert.Nil(t, err, "%v: Match error: %v", name, err)
+		if ok {
+			matched = append(matched, doc)
+		}
+	}
+
+	// Documents are inserted in _id order, so the matched documents are already sorted for the tests that sort by _id.
+	if sort, err := args.LookupErr("sort"); err == nil && !bytes.Equal(sort.Document(), mustDoc(t, `{"_id": 1}`)) {
+		return false
+	}
+	if skip, err := args.LookupErr("skip"); err == nil {
+		n := int(skip.AsInt64())
+		if n > len(matched) {
+			n = len(matched)
+		}
+		matched = matched[n:]
+	}
+	if limit, err := args.LookupErr("limit"); err == nil && limit.AsInt64() > 0 && int(limit.AsInt64()) < len(matched) {
+		matched = matched[:limit.AsInt64()]
+	}
+
+	one := len(matched)
+	if one > 1 {
+		one = 1
+	}
+	switch op {
+	case "find":
+		values, err := result.Array().Values()
+		assert.Nil(t, err, "%v: Values error: %v", name, err)
+		assert.Equal(t, len(values), len(matched), "%v: expected %v documents, got %v", name, len(values), len(matched))
+		for i, v := range values {
+			assert.Equal(t, v.Document(), matched[i], "%v: expected document %v, got %v", name, v.Document(), matched[i])
+		}
+	case "count", "countDocuments":
+		assert.Equal(t, result.AsInt64(), int64(len(matched)), "%v: expected count %v, got %v", name,
+			result.AsInt64(), len(matched))
+	case "deleteMany", "deleteOne", "updateMany", "updateOne", "replaceOne":
+		field, expected := "matchedCount", len(matched)
+		if strings.HasPrefix(op, "delete") {
+			field = "deletedCount"
+		}
+		if strings.HasSuffix(op, "One") {
+			expected = one
+		}
+		got := result.Document().Lookup(field).AsInt64()
+		assert.Equal(t, int64(expected), got, "%v: expected %v %v, got %v", name, field, expected, got)
+	default:
+		return false
+	}
+	return true
+}
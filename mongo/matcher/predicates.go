@@ -0,0 +1,295 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package matcher
+
+import (
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// candidates holds the values found at a field path in a document. A path that traverses an array yields a value for
+// each element of the array that is a document containing the rest of the path.
+type candidates struct {
+	values []bsoncore.Value
+
+	// missing is true if the path is missing from the document or from any of the array elements it traverses.
+	missing bool
+
+	// err is the first error encountered while evaluating a filter nested in an $elemMatch.
+	err error
+
+	// element is true if the values are the elements of an array matched by $elemMatch, which are matched as they are
+	// rather than expanded when they are arrays themselves.
+	element bool
+}
+
+func (c *candidates) collect(doc bsoncore.Document, path []string) {
+	val, err := doc.LookupErr(path[0])
+	if err != nil {
+		c.missing = true
+		return
+	}
+	c.collectValue(val, path[1:])
+}
+
+func (c *candidates) collectValue(val bsoncore.Value, rest []string) {
+	if len(rest) == 0 {
+		c.values = append(c.values, val)
+		return
+	}
+
+	switch val.Type {
+	case bsontype.EmbeddedDocument:
+		c.collect(val.Document(), rest)
+	case bsontype.Array:
+		elems, _ := val.Array().Values()
+		// A numeric path component refers to the element at that index.
+		if idx, ok := parseIndex(rest[0]); ok {
+			if idx < len(elems) {
+				c.collectValue(elems[idx], rest[1:])
+			} else {
+				c.missing = true
+			}
+			return
+		}
+		for _, elem := range elems {
+			if doc, ok := elem.DocumentOK(); ok {
+				c.collect(doc, rest)
+			}
+		}
+	default:
+		c.missing = true
+	}
+}
+
+func parseIndex(s string) (int, bool) {
+	if s == "" || len(s) > 9 {
+		return 0, false
+	}
+	idx := 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+		idx = idx*10 + int(r-'0')
+	}
+	return idx, true
+}
+
+// any returns true if fn returns true for any of the values or, unless the values are $elemMatch elements, for any
+// element of a value that is an array.
+func (c *candidates) any(fn func(bsoncore.Value) bool) bool {
+	for _, val := range c.values {
+		if fn(val) {
+			return true
+		}
+		if c.element {
+			continue
+		}
+		if arr, ok := val.ArrayOK(); ok {
+			elems, _ := arr.Values()
+			for _, elem := range elems {
+				if fn(elem) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isNull(v bsoncore.Value) bool {
+	return v.Type == bsontype.Null || v.Type == bsontype.Undefined
+}
+
+func equal(a, b bsoncore.Value) bool {
+	return bsoncore.CompareValues(a, b) == 0
+}
+
+// bracket returns the type bracket of t. Query comparison operators only compare values in the same bracket.
+func bracket(t bsontype.Type) bsontype.Type {
+	switch t {
+	case bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return bsontype.Double
+	case bsontype.Symbol:
+		return bsontype.String
+	}
+	return t
+}
+
+func stringOf(v bsoncore.Value) string {
+	if v.Type == bsontype.Symbol {
+		return v.Symbol()
+	}
+	return v.StringValue()
+}
+
+// eqPredicate matches values equal to val. A null val also matches missing fields.
+type eqPredicate struct {
+	val bsoncore.Value
+}
+
+func (p eqPredicate) match(c *candidates) bool {
+	if isNull(p.val) {
+		return c.missing || c.any(isNull)
+	}
+	return c.any(func(v bsoncore.Value) bool { return equal(v, p.val) })
+}
+
+// comparePredicate implements $gt, $gte, $lt, and $lte. Only values in the same type bracket as val are compared,
+// unless val is MinKey or MaxKey.
+type comparePredicate struct {
+	op  string
+	val bsoncore.Value
+}
+
+func (p comparePredicate) match(c *candidates) bool {
+	if (p.op == "$gte" || p.op == "$lte") && isNull(p.val) && c.missing {
+		return true
+	}
+
+	bracketed := p.val.Type != bsontype.MinKey && p.val.Type != bsontype.MaxKey
+	return c.any(func(v bsoncore.Value) bool {
+		if bracketed && bracket(v.Type) != bracket(p.val.Type) {
+			return false
+		}
+		cmp := bsoncore.CompareValues(v, p.val)
+		switch p.op {
+		case "$gt":
+			return cmp > 0
+		case "$gte":
+			return cmp >= 0
+		case "$lt":
+			return cmp < 0
+		}
+		return cmp <= 0
+	})
+}
+
+type inPredicate []valuePredicate
+
+func (p inPredicate) match(c *candidates) bool {
+	for _, pred := range p {
+		if pred.match(c) {
+			return true
+		}
+	}
+	return false
+}
+
+type existsPredicate bool
+
+func (p existsPredicate) match(c *candidates) bool {
+	return (len(c.values) > 0) == bool(p)
+}
+
+type typePredicate struct {
+	types  map[bsontype.Type]bool
+	number bool
+}
+
+func (p typePredicate) match(c *candidates) bool {
+	return c.any(func(v bsoncore.Value) bool {
+		return p.types[v.Type] || (p.number && v.IsNumber())
+	})
+}
+
+// regexPredicate matches strings and symbols that match re, and regular expressions equal to the pattern. pattern is
+// the pattern as written in the filter, before any "x" option processing.
+type regexPredicate struct {
+	re      *regexp.Regexp
+	pattern string
+	options string
+}
+
+func (p regexPredicate) match(c *candidates) bool {
+	return c.any(func(v bsoncore.Value) bool {
+		switch v.Type {
+		case bsontype.String, bsontype.Symbol:
+			return p.re.MatchString(stringOf(v))
+		case bsontype.Regex:
+			pattern, options := v.Regex()
+			return pattern == p.pattern && options == p.options
+		}
+		return false
+	})
+}
+
+// elemMatchPredicate matches arrays with at least one element that matches value, or, for filters on the fields of the
+// elements, doc.
+type elemMatchPredicate struct {
+	value valuePredicate
+	doc   docPredicate
+}
+
+func (p elemMatchPredicate) match(c *candidates) bool {
+	for _, val := range c.values {
+		arr, ok := val.ArrayOK()
+		if !ok {
+			continue
+		}
+		elems, _ := arr.Values()
+		for _, elem := range elems {
+			if p.value != nil {
+				if p.value.match(&candidates{values: []bsoncore.Value{elem}, element: true}) {
+					return true
+				}
+				continue
+			}
+
+			doc, ok := elem.DocumentOK()
+			if !ok {
+				continue
+			}
+			matched, err := p.doc.match(doc)
+			if err != nil {
+				if c.err == nil {
+					c.err = err
+				}
+				return false
+			}
+			if matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type sizePredicate int64
+
+func (p sizePredicate) match(c *candidates) bool {
+	for _, val := range c.values {
+		if arr, ok := val.ArrayOK(); ok {
+			elems, _ := arr.Values()
+			if int64(len(elems)) == int64(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type modPredicate struct {
+	divisor, remainder int64
+}
+
+func (p modPredicate) match(c *candidates) bool {
+	return c.any(func(v bsoncore.Value) bool {
+		n, ok := truncated(v)
+		return ok && n%p.divisor == p.remainder
+	})
+}
+
+// nonePredicate matches nothing. It is used for an empty $all.
+type nonePredicate struct{}
+
+func (nonePredicate) match(*candidates) bool {
+	return false
+}
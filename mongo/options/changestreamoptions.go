@@ -7,26 +7,66 @@
 package options
 
 import (
+	"context"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ResumeTokenStore is an interface for persisting the resume token of a change stream so the stream can be resumed
+// from where it left off after the application restarts. It can be set on a change stream through the
+// ChangeStreamOptions.SetResumeTokenStore method.
+type ResumeTokenStore interface {
+	// Load returns the most recently saved resume token. If no token has been saved, Load must return (nil, nil).
+	Load(ctx context.Context) (bson.Raw, error)
+
+	// Save persists the given resume token, replacing any previously saved token.
+	Save(ctx context.Context, token bson.Raw) error
+}
+
 // ChangeStreamOptions represents options that can be used to configure a Watch operation.
 type ChangeStreamOptions struct {
 	// The maximum number of documents to be included in each batch returned by the server.
 	BatchSize *int32
 
+	// The number of events after which the resume token is saved to the ResumeTokenStore. The default is nil, which
+	// means that the token is saved after every event unless CheckpointInterval is set.
+	CheckpointEvery *int32
+
+	// The minimum amount of time between saves of the resume token to the ResumeTokenStore. If this is set, the token
+	// is saved on the first call to Next or TryNext after the interval has elapsed if events have been processed since
+	// the last save. The default is nil.
+	CheckpointInterval *time.Duration
+
+	// If true, the resume token is saved to the ResumeTokenStore whenever the server returns an empty batch with a
+	// post-batch resume token. This lets an idle change stream keep its saved position current. The default is nil,
+	// which is treated as false.
+	CheckpointPostBatchResumeToken *bool
+
 	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
 	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// A string that will be included in server logs, profiling logs, and currentOp queries to help trace the operation.
+	// The default is nil, which means that no comment will be included in the logs.
+	Comment *string
+
 	// Specifies whether the updated document should be returned in change notifications for update operations along
 	// with the deltas describing the changes made to the document. The default is options.Default, which means that
-	// the updated document will not be included in the change notification.
+	// the updated document will not be included in the change notification. The options.WhenAvailable and
+	// options.Required values return the post-image recorded for the change and are only valid for MongoDB versions
+	// >= 6.0.
 	FullDocument *FullDocument
 
+	// Specifies whether the pre-image of the modified document should be returned in change notifications for update,
+	// replace, and delete operations. Valid values are options.Off, options.WhenAvailable, and options.Required. Pre-images
+	// are only available if the collection was created or modified with changeStreamPreAndPostImages enabled. This
+	// option is only valid for MongoDB versions >= 6.0. The default is nil, which means the server default of
+	// options.Off will be used.
+	FullDocumentBeforeChange *FullDocument
+
 	// The maximum amount of time that the server should wait for new documents to satisfy a tailable cursor query.
 	MaxAwaitTime *time.Duration
 
@@ -35,6 +75,25 @@ type ChangeStreamOptions struct {
 	// StartAfter must not be set.
 	ResumeAfter interface{}
 
+	// A store for the resume token of the change stream. If this is set and none of ResumeAfter, StartAfter, and
+	// StartAtOperationTime are set, the change stream is started with the StartAfter option set to the token returned
+	// by the store's Load method. As events are consumed, the resume token is saved to the store according to the
+	// CheckpointEvery, CheckpointInterval, and CheckpointPostBatchResumeToken options. The token for an event is only
+	// saved once Next or TryNext is called again, which indicates that the application has finished processing the
+	// event. The ChangeStream.Checkpoint method can be used to save the current token explicitly. The default is nil.
+	ResumeTokenStore ResumeTokenStore
+
+	// If true, the change stream will include events for DDL operations such as createIndexes and will include
+	// additional fields in existing events. This option is only valid for MongoDB versions >= 6.0. The default is nil,
+	// which means the server default of false will be used.
+	ShowExpandedEvents *bool
+
+	// If true, a $changeStreamSplitLargeEvent stage is appended to the pipeline so the server splits events that
+	// exceed the maximum BSON document size into fragments instead of failing the change stream. The change stream
+	// buffers the fragments of each split event and returns them as a single reassembled event. This option is only
+	// valid for MongoDB versions >= 7.0. The default is nil, which means that large events are not split.
+	SplitLargeEvents *bool
+
 	// If specified, the change stream will only return changes that occurred at or after the given timestamp. This
 	// option is only valid for MongoDB versions >= 4.0. If this is specified, ResumeAfter and StartAfter must not be
 	// set.
@@ -61,18 +120,48 @@ func (cso *ChangeStreamOptions) SetBatchSize(i int32) *ChangeStreamOptions {
 	return cso
 }
 
+// SetCheckpointEvery sets the value for the CheckpointEvery field.
+func (cso *ChangeStreamOptions) SetCheckpointEvery(n int32) *ChangeStreamOptions {
+	cso.CheckpointEvery = &n
+	return cso
+}
+
+// SetCheckpointInterval sets the value for the CheckpointInterval field.
+func (cso *ChangeStreamOptions) SetCheckpointInterval(d time.Duration) *ChangeStreamOptions {
+	cso.CheckpointInterval = &d
+	return cso
+}
+
+// SetCheckpointPostBatchResumeToken sets the value for the CheckpointPostBatchResumeToken field.
+func (cso *ChangeStreamOptions) SetCheckpointPostBatchResumeToken(b bool) *ChangeStreamOptions {
+	cso.CheckpointPostBatchResumeToken = &b
+	return cso
+}
+
 // SetCollation sets the value for the Collation field.
 func (cso *ChangeStreamOptions) SetCollation(c Collation) *ChangeStreamOptions {
 	cso.Collation = &c
 	return cso
 }
 
+// SetComment sets the value for the Comment field.
+func (cso *ChangeStreamOptions) SetComment(comment string) *ChangeStreamOptions {
+	cso.Comment = &comment
+	return cso
+}
+
 // SetFullDocument sets the value for the FullDocument field.
 func (cso *ChangeStreamOptions) SetFullDocument(fd FullDocument) *ChangeStreamOptions {
 	cso.FullDocument = &fd
 	return cso
 }
 
+// SetFullDocumentBeforeChange sets the value for the FullDocumentBeforeChange field.
+func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fdbc FullDocument) *ChangeStreamOptions {
+	cso.FullDocumentBeforeChange = &fdbc
+	return cso
+}
+
 // SetMaxAwaitTime sets the value for the MaxAwaitTime field.
 func (cso *ChangeStreamOptions) SetMaxAwaitTime(d time.Duration) *ChangeStreamOptions {
 	cso.MaxAwaitTime = &d
@@ -85,6 +174,24 @@ func (cso *ChangeStreamOptions) SetResumeAfter(rt interface{}) *ChangeStreamOpti
 	return cso
 }
 
+// SetResumeTokenStore sets the value for the ResumeTokenStore field.
+func (cso *ChangeStreamOptions) SetResumeTokenStore(store ResumeTokenStore) *ChangeStreamOptions {
+	cso.ResumeTokenStore = store
+	return cso
+}
+
+// SetShowExpandedEvents sets the value for the ShowExpandedEvents field.
+func (cso *ChangeStreamOptions) SetShowExpandedEvents(see bool) *ChangeStreamOptions {
+	cso.ShowExpandedEvents = &see
+	return cso
+}
+
+// SetSplitLargeEvents sets the value for the SplitLargeEvents field.
+func (cso *ChangeStreamOptions) SetSplitLargeEvents(split bool) *ChangeStreamOptions {
+	cso.SplitLargeEvents = &split
+	return cso
+}
+
 // SetStartAtOperationTime sets the value for the StartAtOperationTime field.
 func (cso *ChangeStreamOptions) SetStartAtOperationTime(t *primitive.Timestamp) *ChangeStreamOptions {
 	cso.StartAtOperationTime = t
@@ -108,18 +215,42 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso.BatchSize != nil {
 			csOpts.BatchSize = cso.BatchSize
 		}
+		if cso.CheckpointEvery != nil {
+			csOpts.CheckpointEvery = cso.CheckpointEvery
+		}
+		if cso.CheckpointInterval != nil {
+			csOpts.CheckpointInterval = cso.CheckpointInterval
+		}
+		if cso.CheckpointPostBatchResumeToken != nil {
+			csOpts.CheckpointPostBatchResumeToken = cso.CheckpointPostBatchResumeToken
+		}
 		if cso.Collation != nil {
 			csOpts.Collation = cso.Collation
 		}
+		if cso.Comment != nil {
+			csOpts.Comment = cso.Comment
+		}
 		if cso.FullDocument != nil {
 			csOpts.FullDocument = cso.FullDocument
 		}
+		if cso.FullDocumentBeforeChange != nil {
+			csOpts.FullDocumentBeforeChange = cso.FullDocumentBeforeChange
+		}
 		if cso.MaxAwaitTime != nil {
 			csOpts.MaxAwaitTime = cso.MaxAwaitTime
 		}
 		if cso.ResumeAfter != nil {
 			csOpts.ResumeAfter = cso.ResumeAfter
 		}
+		if cso.ResumeTokenStore != nil {
+			csOpts.ResumeTokenStore = cso.ResumeTokenStore
+		}
+		if cso.ShowExpandedEvents != nil {
+			csOpts.ShowExpandedEvents = cso.ShowExpandedEvents
+		}
+		if cso.SplitLargeEvents != nil {
+			csOpts.SplitLargeEvents = cso.SplitLargeEvents
+		}
 		if cso.StartAtOperationTime != nil {
 			csOpts.StartAtOperationTime = cso.StartAtOperationTime
 		}
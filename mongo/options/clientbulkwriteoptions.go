@@ -0,0 +1,97 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ClientBulkWriteOptions represents options that can be used to configure a client-level BulkWrite operation.
+type ClientBulkWriteOptions struct {
+	// If true, writes executed as part of the operation will opt out of document-level validation on the server. The
+	// default value is false. See https://docs.mongodb.com/manual/core/schema-validation/ for more information about
+	// document validation.
+	BypassDocumentValidation *bool
+
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// Specifies parameters for all update and delete operations of the BulkWrite. This must be a document mapping
+	// parameter names to values. Values must be constant or closed expressions that do not reference document fields.
+	// Parameters can then be accessed as variables in an aggregate expression context (e.g. "$$var"). The default
+	// value is nil, which means that no parameters will be used.
+	Let interface{}
+
+	// If true, no writes will be executed after one fails. The default value is true.
+	Ordered *bool
+
+	// If true, the result will contain a separate result for each successful insert, update, and delete operation.
+	// The default value is false, which means that only the summary counts will be reported.
+	VerboseResults *bool
+}
+
+// ClientBulkWrite creates a new *ClientBulkWriteOptions instance.
+func ClientBulkWrite() *ClientBulkWriteOptions {
+	return &ClientBulkWriteOptions{
+		Ordered: &DefaultOrdered,
+	}
+}
+
+// SetBypassDocumentValidation sets the value for the BypassDocumentValidation field.
+func (c *ClientBulkWriteOptions) SetBypassDocumentValidation(bypass bool) *ClientBulkWriteOptions {
+	c.BypassDocumentValidation = &bypass
+	return c
+}
+
+// SetComment sets the value for the Comment field.
+func (c *ClientBulkWriteOptions) SetComment(comment interface{}) *ClientBulkWriteOptions {
+	c.Comment = comment
+	return c
+}
+
+// SetLet sets the value for the Let field.
+func (c *ClientBulkWriteOptions) SetLet(let interface{}) *ClientBulkWriteOptions {
+	c.Let = let
+	return c
+}
+
+// SetOrdered sets the value for the Ordered field.
+func (c *ClientBulkWriteOptions) SetOrdered(ordered bool) *ClientBulkWriteOptions {
+	c.Ordered = &ordered
+	return c
+}
+
+// SetVerboseResults sets the value for the VerboseResults field.
+func (c *ClientBulkWriteOptions) SetVerboseResults(verboseResults bool) *ClientBulkWriteOptions {
+	c.VerboseResults = &verboseResults
+	return c
+}
+
+// MergeClientBulkWriteOptions combines the given ClientBulkWriteOptions instances into a single
+// ClientBulkWriteOptions in a last-one-wins fashion.
+func MergeClientBulkWriteOptions(opts ...*ClientBulkWriteOptions) *ClientBulkWriteOptions {
+	c := ClientBulkWrite()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.BypassDocumentValidation != nil {
+			c.BypassDocumentValidation = opt.BypassDocumentValidation
+		}
+		if opt.Comment != nil {
+			c.Comment = opt.Comment
+		}
+		if opt.Let != nil {
+			c.Let = opt.Let
+		}
+		if opt.Ordered != nil {
+			c.Ordered = opt.Ordered
+		}
+		if opt.VerboseResults != nil {
+			c.VerboseResults = opt.VerboseResults
+		}
+	}
+
+	return c
+}
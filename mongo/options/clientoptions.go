@@ -123,6 +123,7 @@ type ClientOptions struct {
 	SocketTimeout            *time.Duration
 	SRVMaxHosts              *int
 	SRVServiceName           *string
+	Timeout                  *time.Duration
 	TLSConfig                *tls.Config
 	WriteConcern             *writeconcern.WriteConcern
 	ZlibLevel                *int
@@ -368,6 +369,10 @@ func (c *ClientOptions) ApplyURI(uri string) *ClientOptions {
 		c.SRVServiceName = &cs.SRVServiceName
 	}
 
+	if cs.TimeoutSet {
+		c.Timeout = &cs.Timeout
+	}
+
 	if cs.SSL {
 		tlsConfig := new(tls.Config)
 
@@ -694,6 +699,21 @@ func (c *ClientOptions) SetSocketTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetTimeout specifies the amount of time that a single operation run on this Client can execute before returning an
+// error. The timeout covers the entire operation, including server selection, checking out a connection from the
+// connection pool, any retries, and the network round trips to the server. While a timeout is set, the driver also
+// sends a maxTimeMS value derived from the remaining time with each command so the server stops working on the
+// operation once the client has given up. If the Context passed to an operation already has a deadline, that deadline
+// is used instead. This can also be set through the "timeoutMS" URI option (e.g. "timeoutMS=1000"). The default value
+// is nil, meaning operations do not inherit a timeout from the Client. A value of 0 also means that no timeout is used.
+//
+// This option can be overridden for a Database or Collection through options.DatabaseOptions.SetTimeout and
+// options.CollectionOptions.SetTimeout.
+func (c *ClientOptions) SetTimeout(d time.Duration) *ClientOptions {
+	c.Timeout = &d
+	return c
+}
+
 // SetTLSConfig specifies a tls.Config instance to use use to configure TLS on all connections created to the cluster.
 // This can also be set through the following URI options:
 //
@@ -904,6 +924,9 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.SRVServiceName != nil {
 			c.SRVServiceName = opt.SRVServiceName
 		}
+		if opt.Timeout != nil {
+			c.Timeout = opt.Timeout
+		}
 		if opt.TLSConfig != nil {
 			c.TLSConfig = opt.TLSConfig
 		}
@@ -79,6 +79,7 @@ func TestClientOptions(t *testing.T) {
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
 			{"Direct", (*ClientOptions).SetDirect, true, "Direct", true},
 			{"SocketTimeout", (*ClientOptions).SetSocketTimeout, 5 * time.Second, "SocketTimeout", true},
+			{"Timeout", (*ClientOptions).SetTimeout, 5 * time.Second, "Timeout", true},
 			{"TLSConfig", (*ClientOptions).SetTLSConfig, &tls.Config{}, "TLSConfig", false},
 			{"WriteConcern", (*ClientOptions).SetWriteConcern, writeconcern.New(writeconcern.WMajority()), "WriteConcern", false},
 			{"ZlibLevel", (*ClientOptions).SetZlibLevel, 6, "ZlibLevel", true},
@@ -378,6 +379,11 @@ func TestClientOptions(t *testing.T) {
 				"mongodb://localhost/?socketTimeoutMS=15000",
 				baseClient().SetSocketTimeout(15 * time.Second),
 			},
+			{
+				"Timeout",
+				"mongodb://localhost/?timeoutMS=10000",
+				baseClient().SetTimeout(10 * time.Second),
+			},
 			{
 				"TLS CACertificate",
 				"mongodb://localhost/?ssl=true&sslCertificateAuthorityFile=testdata/ca.pem",
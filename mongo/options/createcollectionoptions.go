@@ -65,6 +65,36 @@ func (tso *TimeSeriesOptions) SetGranularity(granularity string) *TimeSeriesOpti
 	return tso
 }
 
+// ClusteredIndexOptions specifies the clustered index of a clustered collection (see
+// https://docs.mongodb.com/manual/core/clustered-collections/). This type can be used when creating a new collection
+// through the CreateCollectionOptions.SetClusteredIndex method. Clustered indexes are always unique, so the driver
+// always sends unique: true.
+type ClusteredIndexOptions struct {
+	// The keys specification document of the clustered index. The default value is nil, meaning the collection will be
+	// clustered by {_id: 1}, which is the only key supported by MongoDB 5.3.
+	Key interface{}
+
+	// The name of the clustered index. The default value is nil, meaning the server will generate the name.
+	Name *string
+}
+
+// ClusteredIndex creates a new ClusteredIndexOptions instance.
+func ClusteredIndex() *ClusteredIndexOptions {
+	return &ClusteredIndexOptions{}
+}
+
+// SetKey sets the value for the Key field.
+func (c *ClusteredIndexOptions) SetKey(key interface{}) *ClusteredIndexOptions {
+	c.Key = key
+	return c
+}
+
+// SetName sets the value for the Name field.
+func (c *ClusteredIndexOptions) SetName(name string) *ClusteredIndexOptions {
+	c.Name = &name
+	return c
+}
+
 // CreateCollectionOptions represents options that can be used to configure a CreateCollection operation.
 type CreateCollectionOptions struct {
 	// Specifies if the collection is capped (see https://docs.mongodb.com/manual/core/capped-collections/). If true,
@@ -125,6 +155,29 @@ type CreateCollectionOptions struct {
 	//
 	// This option is only valid for MongoDB versions >= 5.0
 	TimeSeriesOptions *TimeSeriesOptions
+
+	// Specifies that the collection is a clustered collection whose documents are stored in the order of the clustered
+	// index. This option is only valid for MongoDB versions >= 5.3. The default value is nil, meaning the collection
+	// will not be clustered.
+	ClusteredIndex *ClusteredIndexOptions
+
+	// Specifies whether change streams opened against the collection can include the pre- and post-images of modified
+	// documents. This option is only valid for MongoDB versions >= 6.0. The default value is nil, meaning the server default of false will be used.
+	ChangeStreamPreAndPostImages *bool
+
+	// A document describing the fields of the collection that are encrypted with Queryable Encryption. When this is set,
+	// the driver also creates the collections that hold the encryption state and an index on the __safeContent__ field.
+	// This option is only valid for MongoDB versions >= 6.0. The default value is nil.
+	EncryptedFields interface{}
+
+	// The name of the collection or view on which a view will be created. If this is set, a view is created instead of a
+	// collection and the Capped, ClusteredIndex, EncryptedFields, MaxDocuments, SizeInBytes, and TimeSeriesOptions
+	// options must not be set. The default value is nil, meaning a collection will be created.
+	ViewOn *string
+
+	// An aggregation pipeline that is run against the ViewOn collection to produce the view. This option requires
+	// ViewOn to be set. The default value is nil, meaning the view contains all documents of the ViewOn collection.
+	Pipeline interface{}
 }
 
 // CreateCollection creates a new CreateCollectionOptions instance.
@@ -198,6 +251,36 @@ func (c *CreateCollectionOptions) SetTimeSeriesOptions(timeSeriesOpts *TimeSerie
 	return c
 }
 
+// SetClusteredIndex sets the value for the ClusteredIndex field.
+func (c *CreateCollectionOptions) SetClusteredIndex(clusteredIndex *ClusteredIndexOptions) *CreateCollectionOptions {
+	c.ClusteredIndex = clusteredIndex
+	return c
+}
+
+// SetChangeStreamPreAndPostImages sets the value for the ChangeStreamPreAndPostImages field.
+func (c *CreateCollectionOptions) SetChangeStreamPreAndPostImages(enabled bool) *CreateCollectionOptions {
+	c.ChangeStreamPreAndPostImages = &enabled
+	return c
+}
+
+// SetEncryptedFields sets the value for the EncryptedFields field.
+func (c *CreateCollectionOptions) SetEncryptedFields(encryptedFields interface{}) *CreateCollectionOptions {
+	c.EncryptedFields = encryptedFields
+	return c
+}
+
+// SetViewOn sets the value for the ViewOn field.
+func (c *CreateCollectionOptions) SetViewOn(viewOn string) *CreateCollectionOptions {
+	c.ViewOn = &viewOn
+	return c
+}
+
+// SetPipeline sets the value for the Pipeline field.
+func (c *CreateCollectionOptions) SetPipeline(pipeline interface{}) *CreateCollectionOptions {
+	c.Pipeline = pipeline
+	return c
+}
+
 // MergeCreateCollectionOptions combines the given CreateCollectionOptions instances into a single
 // CreateCollectionOptions in a last-one-wins fashion.
 func MergeCreateCollectionOptions(opts ...*CreateCollectionOptions) *CreateCollectionOptions {
@@ -241,6 +324,21 @@ func MergeCreateCollectionOptions(opts ...*CreateCollectionOptions) *CreateColle
 		if opt.TimeSeriesOptions != nil {
 			cc.TimeSeriesOptions = opt.TimeSeriesOptions
 		}
+		if opt.ClusteredIndex != nil {
+			cc.ClusteredIndex = opt.ClusteredIndex
+		}
+		if opt.ChangeStreamPreAndPostImages != nil {
+			cc.ChangeStreamPreAndPostImages = opt.ChangeStreamPreAndPostImages
+		}
+		if opt.EncryptedFields != nil {
+			cc.EncryptedFields = opt.EncryptedFields
+		}
+		if opt.ViewOn != nil {
+			cc.ViewOn = opt.ViewOn
+		}
+		if opt.Pipeline != nil {
+			cc.Pipeline = opt.Pipeline
+		}
 	}
 
 	return cc
@@ -0,0 +1,74 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"time"
+)
+
+// DispatcherOptions represents options that can be used to configure a change stream Dispatcher.
+type DispatcherOptions struct {
+	// The number of goroutines that run handlers concurrently. Events for the same document are always handled by the
+	// same goroutine in the order they were received. The default value is the number of CPUs.
+	Workers *int
+
+	// A store for the resume token of the last event that, along with every event before it, has been handled. The
+	// change stream used by the Dispatcher should not have its own ResumeTokenStore, because the change stream saves
+	// tokens as soon as the next event is read rather than once it has been handled. The default value is nil, which
+	// means that resume tokens are not saved.
+	ResumeTokenStore ResumeTokenStore
+
+	// The minimum amount of time between saves of the resume token to the ResumeTokenStore. The latest token is
+	// always saved when the Dispatcher stops. The default value is 0, which means that the token is saved every time it
+	// advances.
+	CheckpointInterval *time.Duration
+}
+
+// Dispatcher creates a new DispatcherOptions instance.
+func Dispatcher() *DispatcherOptions {
+	return &DispatcherOptions{}
+}
+
+// SetWorkers sets the value for the Workers field.
+func (d *DispatcherOptions) SetWorkers(workers int) *DispatcherOptions {
+	d.Workers = &workers
+	return d
+}
+
+// SetResumeTokenStore sets the value for the ResumeTokenStore field.
+func (d *DispatcherOptions) SetResumeTokenStore(store ResumeTokenStore) *DispatcherOptions {
+	d.ResumeTokenStore = store
+	return d
+}
+
+// SetCheckpointInterval sets the value for the CheckpointInterval field.
+func (d *DispatcherOptions) SetCheckpointInterval(interval time.Duration) *DispatcherOptions {
+	d.CheckpointInterval = &interval
+	return d
+}
+
+// MergeDispatcherOptions combines the given DispatcherOptions instances into a single DispatcherOptions in a
+// last-one-wins fashion.
+func MergeDispatcherOptions(opts ...*DispatcherOptions) *DispatcherOptions {
+	d := Dispatcher()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Workers != nil {
+			d.Workers = opt.Workers
+		}
+		if opt.ResumeTokenStore != nil {
+			d.ResumeTokenStore = opt.ResumeTokenStore
+		}
+		if opt.CheckpointInterval != nil {
+			d.CheckpointInterval = opt.CheckpointInterval
+		}
+	}
+
+	return d
+}
@@ -167,6 +167,84 @@ func MergeListIndexesOptions(opts ...*ListIndexesOptions) *ListIndexesOptions {
 	return c
 }
 
+// SyncIndexesOptions represents options that can be used to configure an IndexView.Sync operation.
+type SyncIndexesOptions struct {
+	// If true, indexes that exist on the collection but are not declared in the models passed to Sync will be dropped.
+	// If HideUndeclared is also true, only undeclared indexes that are already hidden will be dropped, so an index is
+	// always hidden by one call to Sync before it is dropped by a later one. The _id index is never dropped. The
+	// default value is false.
+	DropUndeclared *bool
+
+	// If true, indexes that exist on the collection but are not declared in the models passed to Sync will be hidden
+	// from the query planner. Hidden indexes are still maintained by the server and can be unhidden without a rebuild.
+	// This option is only valid for MongoDB versions >= 4.4. The default value is false.
+	HideUndeclared *bool
+
+	// If true, existing indexes that must be rebuilt to match their declared models are dropped and recreated. The
+	// index does not exist between the drop and the end of the new build, so queries cannot use it and a unique index
+	// does not enforce uniqueness during that time. If the new index cannot be created, the old one is not restored. If
+	// this is false and an index must be rebuilt, Sync returns an error without changing any indexes. The default
+	// value is false.
+	RebuildChanged *bool
+
+	// If true, Sync will compute and return the plan of changes without making any changes. The default value is false.
+	DryRun *bool
+}
+
+// SyncIndexes creates a new SyncIndexesOptions instance.
+func SyncIndexes() *SyncIndexesOptions {
+	return &SyncIndexesOptions{}
+}
+
+// SetDropUndeclared sets the value for the DropUndeclared field.
+func (s *SyncIndexesOptions) SetDropUndeclared(drop bool) *SyncIndexesOptions {
+	s.DropUndeclared = &drop
+	return s
+}
+
+// SetHideUndeclared sets the value for the HideUndeclared field.
+func (s *SyncIndexesOptions) SetHideUndeclared(hide bool) *SyncIndexesOptions {
+	s.HideUndeclared = &hide
+	return s
+}
+
+// SetRebuildChanged sets the value for the RebuildChanged field.
+func (s *SyncIndexesOptions) SetRebuildChanged(rebuild bool) *SyncIndexesOptions {
+	s.RebuildChanged = &rebuild
+	return s
+}
+
+// SetDryRun sets the value for the DryRun field.
+func (s *SyncIndexesOptions) SetDryRun(dryRun bool) *SyncIndexesOptions {
+	s.DryRun = &dryRun
+	return s
+}
+
+// MergeSyncIndexesOptions combines the given SyncIndexesOptions instances into a single SyncIndexesOptions in a
+// last-one-wins fashion.
+func MergeSyncIndexesOptions(opts ...*SyncIndexesOptions) *SyncIndexesOptions {
+	s := SyncIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DropUndeclared != nil {
+			s.DropUndeclared = opt.DropUndeclared
+		}
+		if opt.HideUndeclared != nil {
+			s.HideUndeclared = opt.HideUndeclared
+		}
+		if opt.RebuildChanged != nil {
+			s.RebuildChanged = opt.RebuildChanged
+		}
+		if opt.DryRun != nil {
+			s.DryRun = opt.DryRun
+		}
+	}
+
+	return s
+}
+
 // IndexOptions represents options that can be used to configure a new index created through the IndexView.CreateOne
 // or IndexView.CreateMany operations.
 type IndexOptions struct {
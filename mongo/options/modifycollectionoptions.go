@@ -0,0 +1,157 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ModifyIndexOptions specifies changes to an existing index. This type can be used to modify an index through the
+// ModifyCollectionOptions.SetIndex method. Exactly one of Name and Keys must be set to identify the index.
+type ModifyIndexOptions struct {
+	// The name of the index to modify.
+	Name *string
+
+	// The keys specification document of the index to modify. This must be a document mapping field names to index
+	// types, such as bson.D{{"x", 1}}.
+	Keys interface{}
+
+	// The new number of seconds after which documents are deleted by a TTL index.
+	ExpireAfterSeconds *int64
+
+	// Specifies whether the index should be hidden from the query planner. This option is only valid for MongoDB
+	// versions >= 4.4.
+	Hidden *bool
+}
+
+// ModifyIndex creates a new ModifyIndexOptions instance.
+func ModifyIndex() *ModifyIndexOptions {
+	return &ModifyIndexOptions{}
+}
+
+// SetName sets the value for the Name field.
+func (m *ModifyIndexOptions) SetName(name string) *ModifyIndexOptions {
+	m.Name = &name
+	return m
+}
+
+// SetKeys sets the value for the Keys field.
+func (m *ModifyIndexOptions) SetKeys(keys interface{}) *ModifyIndexOptions {
+	m.Keys = keys
+	return m
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (m *ModifyIndexOptions) SetExpireAfterSeconds(seconds int64) *ModifyIndexOptions {
+	m.ExpireAfterSeconds = &seconds
+	return m
+}
+
+// SetHidden sets the value for the Hidden field.
+func (m *ModifyIndexOptions) SetHidden(hidden bool) *ModifyIndexOptions {
+	m.Hidden = &hidden
+	return m
+}
+
+// ModifyCollectionOptions represents options that can be used to configure a ModifyCollection operation.
+type ModifyCollectionOptions struct {
+	// A document specifying the new validation rules for the collection. See
+	// https://docs.mongodb.com/manual/core/schema-validation/ for more information about schema validation. The
+	// default value is nil, meaning the validator will not be changed.
+	Validator interface{}
+
+	// Specifies how strictly the server applies validation rules to existing documents in the collection during update
+	// operations. Valid values are "off", "strict", and "moderate". The default value is nil, meaning the validation
+	// level will not be changed.
+	ValidationLevel *string
+
+	// Specifies what should happen if a document being inserted or updated does not pass validation. Valid values are
+	// "error" and "warn". The default value is nil, meaning the validation action will not be changed.
+	ValidationAction *string
+
+	// The new number of seconds after which documents in a time-series or clustered collection are deleted. This
+	// option is only valid for MongoDB versions >= 5.0.
+	ExpireAfterSeconds *int64
+
+	// Changes to make to an existing index, such as its TTL or whether it is hidden. The default value is nil, meaning
+	// no index will be modified.
+	Index *ModifyIndexOptions
+
+	// The new granularity for a time-series collection. Valid values are "seconds", "minutes", and "hours", and the
+	// granularity can only be increased. This option is only valid for MongoDB versions >= 5.0.
+	TimeSeriesGranularity *string
+}
+
+// ModifyCollection creates a new ModifyCollectionOptions instance.
+func ModifyCollection() *ModifyCollectionOptions {
+	return &ModifyCollectionOptions{}
+}
+
+// SetValidator sets the value for the Validator field.
+func (m *ModifyCollectionOptions) SetValidator(validator interface{}) *ModifyCollectionOptions {
+	m.Validator = validator
+	return m
+}
+
+// SetValidationLevel sets the value for the ValidationLevel field.
+func (m *ModifyCollectionOptions) SetValidationLevel(level string) *ModifyCollectionOptions {
+	m.ValidationLevel = &level
+	return m
+}
+
+// SetValidationAction sets the value for the ValidationAction field.
+func (m *ModifyCollectionOptions) SetValidationAction(action string) *ModifyCollectionOptions {
+	m.ValidationAction = &action
+	return m
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (m *ModifyCollectionOptions) SetExpireAfterSeconds(eas int64) *ModifyCollectionOptions {
+	m.ExpireAfterSeconds = &eas
+	return m
+}
+
+// SetIndex sets the value for the Index field.
+func (m *ModifyCollectionOptions) SetIndex(index *ModifyIndexOptions) *ModifyCollectionOptions {
+	m.Index = index
+	return m
+}
+
+// SetTimeSeriesGranularity sets the value for the TimeSeriesGranularity field.
+func (m *ModifyCollectionOptions) SetTimeSeriesGranularity(granularity string) *ModifyCollectionOptions {
+	m.TimeSeriesGranularity = &granularity
+	return m
+}
+
+// MergeModifyCollectionOptions combines the given ModifyCollectionOptions instances into a single
+// ModifyCollectionOptions in a last-one-wins fashion.
+func MergeModifyCollectionOptions(opts ...*ModifyCollectionOptions) *ModifyCollectionOptions {
+	mc := ModifyCollection()
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Validator != nil {
+			mc.Validator = opt.Validator
+		}
+		if opt.ValidationLevel != nil {
+			mc.ValidationLevel = opt.ValidationLevel
+		}
+		if opt.ValidationAction != nil {
+			mc.ValidationAction = opt.ValidationAction
+		}
+		if opt.ExpireAfterSeconds != nil {
+			mc.ExpireAfterSeconds = opt.ExpireAfterSeconds
+		}
+		if opt.Index != nil {
+			mc.Index = opt.Index
+		}
+		if opt.TimeSeriesGranularity != nil {
+			mc.TimeSeriesGranularity = opt.TimeSeriesGranularity
+		}
+	}
+
+	return mc
+}
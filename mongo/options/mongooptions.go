@@ -99,6 +99,27 @@ const (
 	// UpdateLookup includes a delta describing the changes to the document and a copy of the entire document that
 	// was changed
 	UpdateLookup FullDocument = "updateLookup"
+	// Off does not include a pre-image of the document. This is only valid for the FullDocumentBeforeChange option.
+	Off FullDocument = "off"
+	// WhenAvailable includes a post-image or pre-image of the document if one is available
+	WhenAvailable FullDocument = "whenAvailable"
+	// Required includes a post-image or pre-image of the document and causes the server to return an error if one is
+	// not available
+	Required FullDocument = "required"
+)
+
+// ExplainVerbosity specifies how much information an explain command should return. See
+// https://docs.mongodb.com/manual/reference/command/explain/ for more information.
+type ExplainVerbosity string
+
+const (
+	// ExplainQueryPlanner returns the plan selected by the query optimizer without running it.
+	ExplainQueryPlanner ExplainVerbosity = "queryPlanner"
+	// ExplainExecutionStats runs the selected plan and returns statistics describing its execution.
+	ExplainExecutionStats ExplainVerbosity = "executionStats"
+	// ExplainAllPlansExecution runs the selected plan and returns statistics for both the selected plan and the
+	// candidate plans that were considered during plan selection.
+	ExplainAllPlansExecution ExplainVerbosity = "allPlansExecution"
 )
 
 // ArrayFilters is used to hold filters for the array filters CRUD option. If a registry is nil, bson.DefaultRegistry
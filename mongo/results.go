@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
@@ -35,6 +36,54 @@ type BulkWriteResult struct {
 	UpsertedIDs map[int64]interface{}
 }
 
+// ClientBulkWriteResult is the result type returned by a client-level BulkWrite operation.
+type ClientBulkWriteResult struct {
+	// The number of documents inserted.
+	InsertedCount int64
+
+	// The number of documents matched by filters in update and replace operations.
+	MatchedCount int64
+
+	// The number of documents modified by update and replace operations.
+	ModifiedCount int64
+
+	// The number of documents deleted.
+	DeletedCount int64
+
+	// The number of documents upserted by update and replace operations.
+	UpsertedCount int64
+
+	// A map of model index to the result of each successful insert. This is only populated if the VerboseResults
+	// option was set to true.
+	InsertResults map[int]ClientInsertResult
+
+	// A map of model index to the result of each successful update or replace. This is only populated if the
+	// VerboseResults option was set to true.
+	UpdateResults map[int]ClientUpdateResult
+
+	// A map of model index to the result of each successful delete. This is only populated if the VerboseResults
+	// option was set to true.
+	DeleteResults map[int]ClientDeleteResult
+}
+
+// ClientInsertResult is the result of an individual insert in a client-level BulkWrite operation.
+type ClientInsertResult struct {
+	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
+	InsertedID interface{}
+}
+
+// ClientUpdateResult is the result of an individual update or replace in a client-level BulkWrite operation.
+type ClientUpdateResult struct {
+	MatchedCount  int64       // The number of documents matched by the filter.
+	ModifiedCount int64       // The number of documents modified by the operation.
+	UpsertedID    interface{} // The _id field of the upserted document, or nil if no upsert was done.
+}
+
+// ClientDeleteResult is the result of an individual delete in a client-level BulkWrite operation.
+type ClientDeleteResult struct {
+	DeletedCount int64 // The number of documents deleted.
+}
+
 // InsertOneResult is the result type returned by an InsertOne operation.
 type InsertOneResult struct {
 	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
@@ -170,6 +219,15 @@ type IndexSpecification struct {
 	// If true, the collection will not accept insertion or update of documents where the index key value matches an
 	// existing value in the index. The default is false.
 	Unique *bool
+
+	// If true, the index exists but is not used by the query planner. The default is false.
+	Hidden *bool
+
+	// The filter document for a partial index. This will be nil if the index is not a partial index.
+	PartialFilterExpression bson.Raw
+
+	// The collation document for the index. This will be nil if the index uses simple binary comparison.
+	Collation bson.Raw
 }
 
 var _ bson.Unmarshaler = (*IndexSpecification)(nil)
@@ -182,6 +240,9 @@ type unmarshalIndexSpecification struct {
 	ExpireAfterSeconds *int32   `bson:"expireAfterSeconds"`
 	Sparse             *bool    `bson:"sparse"`
 	Unique             *bool    `bson:"unique"`
+	Hidden             *bool    `bson:"hidden"`
+	PartialFilter      bson.Raw `bson:"partialFilterExpression"`
+	Collation          bson.Raw `bson:"collation"`
 }
 
 // UnmarshalBSON implements the bson.Unmarshaler interface.
@@ -198,6 +259,9 @@ func (i *IndexSpecification) UnmarshalBSON(data []byte) error {
 	i.ExpireAfterSeconds = temp.ExpireAfterSeconds
 	i.Sparse = temp.Sparse
 	i.Unique = temp.Unique
+	i.Hidden = temp.Hidden
+	i.PartialFilterExpression = temp.PartialFilter
+	i.Collation = temp.Collation
 	return nil
 }
 
@@ -223,6 +287,42 @@ type CollectionSpecification struct {
 	// An IndexSpecification instance with details about the collection's _id index. This will be nil if the NameOnly
 	// option is used and for MongoDB versions < 3.4.
 	IDIndex *IndexSpecification
+
+	// The default collation of the collection or view. This will be nil if the collection does not have a collation.
+	Collation bson.Raw
+
+	// The name of the collection or view on which a view is defined. This will be empty if Type is not "view".
+	ViewOn string
+
+	// The aggregation pipeline that defines a view. This will be nil if Type is not "view".
+	Pipeline bson.Raw
+
+	// Details about the clustered index of a clustered collection. This will be nil if the collection is not
+	// clustered.
+	ClusteredIndex *ClusteredIndexSpecification
+
+	// Whether change streams opened against the collection can include pre- and post-images.
+	ChangeStreamPreAndPostImages bool
+
+	// The document describing the fields of the collection that are encrypted with Queryable Encryption. This will be
+	// nil if the collection does not use Queryable Encryption.
+	EncryptedFields bson.Raw
+}
+
+// ClusteredIndexSpecification represents the clustered index of a clustered collection. This type is part of the
+// CollectionSpecification returned by the Database.ListCollectionSpecifications function.
+type ClusteredIndexSpecification struct {
+	// The index name.
+	Name string
+
+	// The index version.
+	Version int32
+
+	// The keys specification document for the index.
+	KeysDocument bson.Raw
+
+	// Whether the index is unique. Clustered indexes are always unique.
+	Unique bool
 }
 
 var _ bson.Unmarshaler = (*CollectionSpecification)(nil)
@@ -240,6 +340,19 @@ type unmarshalCollectionSpecification struct {
 	IDIndex *IndexSpecification `bson:"idIndex"`
 }
 
+// unmarshalCollectionOptions is used to unmarshal the typed fields of a CollectionSpecification from the options
+// document returned by a listCollections command.
+type unmarshalCollectionOptions struct {
+	Collation                    bson.Raw      `bson:"collation"`
+	ViewOn                       string        `bson:"viewOn"`
+	Pipeline                     bson.Raw      `bson:"pipeline"`
+	ClusteredIndex               bson.RawValue `bson:"clusteredIndex"`
+	ChangeStreamPreAndPostImages *struct {
+		Enabled bool `bson:"enabled"`
+	} `bson:"changeStreamPreAndPostImages"`
+	EncryptedFields bson.Raw `bson:"encryptedFields"`
+}
+
 // UnmarshalBSON implements the bson.Unmarshaler interface.
 func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 	var temp unmarshalCollectionSpecification
@@ -260,5 +373,142 @@ func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 	}
 	cs.Options = temp.Options
 	cs.IDIndex = temp.IDIndex
+	if len(temp.Options) == 0 {
+		return nil
+	}
+
+	var opts unmarshalCollectionOptions
+	if err := bson.Unmarshal(temp.Options, &opts); err != nil {
+		return err
+	}
+	cs.Collation = opts.Collation
+	cs.ViewOn = opts.ViewOn
+	cs.Pipeline = opts.Pipeline
+	// Time-series collections report clusteredIndex as a boolean rather than a document.
+	if opts.ClusteredIndex.Type == bsontype.EmbeddedDocument {
+		var ci struct {
+			Name    string   `bson:"name"`
+			Version int32    `bson:"v"`
+			Key     bson.Raw `bson:"key"`
+			Unique  bool     `bson:"unique"`
+		}
+		if err := opts.ClusteredIndex.Unmarshal(&ci); err != nil {
+			return err
+		}
+		cs.ClusteredIndex = &ClusteredIndexSpecification{
+			Name:         ci.Name,
+			Version:      ci.Version,
+			KeysDocument: ci.Key,
+			Unique:       ci.Unique,
+		}
+	}
+	if opts.ChangeStreamPreAndPostImages != nil {
+		cs.ChangeStreamPreAndPostImages = opts.ChangeStreamPreAndPostImages.Enabled
+	}
+	cs.EncryptedFields = opts.EncryptedFields
+	return nil
+}
+
+// ExplainResult represents the output of an explain command. This type is returned by the Explain methods on
+// Collection (e.g. Collection.ExplainFind).
+type ExplainResult struct {
+	// Details about the plan selected by the query optimizer. For aggregations that the server explains stage by
+	// stage, this is taken from the $cursor stage at the start of the pipeline. This will be nil if the output does not
+	// contain a query plan.
+	QueryPlanner *ExplainQueryPlanner
+
+	// Statistics describing the execution of the selected plan. This will be nil if the command was explained with the
+	// options.ExplainQueryPlanner verbosity.
+	ExecutionStats *ExplainExecutionStats
+
+	// The explain output for each stage of an aggregation pipeline. This will be nil if the server explained the
+	// command as a single query plan.
+	Stages []bson.Raw
+
+	// The explained command as reported by the server. This will be nil for MongoDB versions < 4.4.
+	Command bson.Raw
+
+	// The full explain output, which can be used to access fields not exposed by this type, such as the per-shard
+	// output returned by a sharded cluster.
+	Raw bson.Raw
+}
+
+// ExplainQueryPlanner describes the plan selected by the query optimizer in the output of an explain command.
+type ExplainQueryPlanner struct {
+	// The namespace that was queried. This is a string in the format "databaseName.collectionName".
+	Namespace string `bson:"namespace"`
+
+	// The query filter after being parsed and normalized by the server.
+	ParsedQuery bson.Raw `bson:"parsedQuery"`
+
+	// The tree of stages for the plan that was selected.
+	WinningPlan bson.Raw `bson:"winningPlan"`
+
+	// The candidate plans that were considered and rejected by the query optimizer.
+	RejectedPlans []bson.Raw `bson:"rejectedPlans"`
+}
+
+// ExplainExecutionStats describes the execution of the selected plan in the output of an explain command.
+type ExplainExecutionStats struct {
+	// Whether or not the plan executed successfully.
+	ExecutionSuccess bool `bson:"executionSuccess"`
+
+	// The number of documents returned by the plan.
+	NReturned int64 `bson:"nReturned"`
+
+	// The time, in milliseconds, it took to select and execute the plan.
+	ExecutionTimeMillis int64 `bson:"executionTimeMillis"`
+
+	// The number of index keys scanned.
+	TotalKeysExamined int64 `bson:"totalKeysExamined"`
+
+	// The number of documents examined.
+	TotalDocsExamined int64 `bson:"totalDocsExamined"`
+
+	// The tree of stages for the plan with per-stage execution statistics.
+	ExecutionStages bson.Raw `bson:"executionStages"`
+
+	// Partial execution statistics for the candidate plans. This will only be set if the command was explained with
+	// the options.ExplainAllPlansExecution verbosity.
+	AllPlansExecution []bson.Raw `bson:"allPlansExecution"`
+}
+
+var _ bson.Unmarshaler = (*ExplainResult)(nil)
+
+// unmarshalExplainResult is used to unmarshal the response of an explain command into an ExplainResult.
+type unmarshalExplainResult struct {
+	QueryPlanner   *ExplainQueryPlanner   `bson:"queryPlanner"`
+	ExecutionStats *ExplainExecutionStats `bson:"executionStats"`
+	Stages         []bson.Raw             `bson:"stages"`
+	Command        bson.Raw               `bson:"command"`
+}
+
+// UnmarshalBSON implements the bson.Unmarshaler interface.
+func (er *ExplainResult) UnmarshalBSON(data []byte) error {
+	var temp unmarshalExplainResult
+	if err := bson.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	er.QueryPlanner = temp.QueryPlanner
+	er.ExecutionStats = temp.ExecutionStats
+	er.Stages = temp.Stages
+	er.Command = temp.Command
+	er.Raw = make(bson.Raw, len(data))
+	copy(er.Raw, data)
+
+	// Aggregations that are explained stage by stage report the query plan in the initial $cursor stage.
+	if er.QueryPlanner == nil && len(er.Stages) > 0 {
+		var cursorStage struct {
+			Cursor *unmarshalExplainResult `bson:"$cursor"`
+		}
+		if err := bson.Unmarshal(er.Stages[0], &cursorStage); err != nil {
+			return err
+		}
+		if cursorStage.Cursor != nil {
+			er.QueryPlanner = cursorStage.Cursor.QueryPlanner
+			er.ExecutionStats = cursorStage.Cursor.ExecutionStats
+		}
+	}
 	return nil
 }
@@ -76,4 +76,137 @@ func TestResults(t *testing.T) {
 			assert.Equal(t, int32(3), upsertedID, "expected upsertedID 3, got %v", upsertedID)
 		})
 	})
+	t.Run("collection specification", func(t *testing.T) {
+		key := bson.D{{"_id", int32(1)}}
+		encryptedFields := bson.D{{"fields", bson.A{}}}
+		pipeline := bson.A{bson.D{{"$match", bson.D{{"x", int32(1)}}}}}
+		testCases := []struct {
+			name     string
+			options  bson.D
+			expected CollectionSpecification
+		}{
+			{"clustered collection", bson.D{
+				{"clusteredIndex", bson.D{{"v", int32(2)}, {"key", key}, {"name", "_id_"}, {"unique", true}}},
+				{"changeStreamPreAndPostImages", bson.D{{"enabled", true}}},
+				{"encryptedFields", encryptedFields},
+			}, CollectionSpecification{
+				ClusteredIndex: &ClusteredIndexSpecification{
+					Name:         "_id_",
+					Version:      2,
+					KeysDocument: marshalRaw(t, key),
+					Unique:       true,
+				},
+				ChangeStreamPreAndPostImages: true,
+				EncryptedFields:              marshalRaw(t, encryptedFields),
+			}},
+			{"time-series collection", bson.D{
+				{"timeseries", bson.D{{"timeField", "t"}}},
+				{"clusteredIndex", true},
+			}, CollectionSpecification{}},
+			{"view", bson.D{
+				{"viewOn", "source"},
+				{"pipeline", pipeline},
+			}, CollectionSpecification{
+				ViewOn:   "source",
+				Pipeline: marshalRawArray(t, pipeline),
+			}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				b, err := bson.Marshal(bson.D{{"name", "coll"}, {"options", tc.options}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var spec CollectionSpecification
+				err = bson.Unmarshal(b, &spec)
+				assert.Nil(t, err, "Unmarshal error: %v", err)
+
+				assert.Equal(t, tc.expected.ClusteredIndex, spec.ClusteredIndex, "expected clustered index %v, got %v",
+					tc.expected.ClusteredIndex, spec.ClusteredIndex)
+				assert.Equal(t, tc.expected.ChangeStreamPreAndPostImages, spec.ChangeStreamPreAndPostImages,
+					"expected changeStreamPreAndPostImages %v, got %v", tc.expected.ChangeStreamPreAndPostImages,
+					spec.ChangeStreamPreAndPostImages)
+				assert.Equal(t, tc.expected.EncryptedFields, spec.EncryptedFields, "expected encryptedFields %v, got %v",
+					tc.expected.EncryptedFields, spec.EncryptedFields)
+				assert.Equal(t, tc.expected.ViewOn, spec.ViewOn, "expected viewOn %v, got %v", tc.expected.ViewOn,
+					spec.ViewOn)
+				assert.Equal(t, tc.expected.Pipeline, spec.Pipeline, "expected pipeline %v, got %v",
+					tc.expected.Pipeline, spec.Pipeline)
+			})
+		}
+	})
+	t.Run("explain result", func(t *testing.T) {
+		winningPlan := bson.D{{"stage", "COLLSCAN"}}
+		queryPlanner := bson.D{
+			{"namespace", "db.coll"},
+			{"winningPlan", winningPlan},
+			{"rejectedPlans", bson.A{}},
+		}
+		executionStats := bson.D{
+			{"executionSuccess", true},
+			{"nReturned", int32(3)},
+			{"executionTimeMillis", int32(1)},
+			{"totalKeysExamined", int32(0)},
+			{"totalDocsExamined", int32(10)},
+		}
+		expectedPlan, err := bson.Marshal(winningPlan)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		testCases := []struct {
+			name string
+			doc  bson.D
+		}{
+			{"top-level query plan", bson.D{
+				{"queryPlanner", queryPlanner},
+				{"executionStats", executionStats},
+				{"ok", 1.0},
+			}},
+			{"aggregation stages", bson.D{
+				{"stages", bson.A{
+					bson.D{{"$cursor", bson.D{
+						{"queryPlanner", queryPlanner},
+						{"executionStats", executionStats},
+					}}},
+					bson.D{{"$group", bson.D{{"_id", "$x"}}}},
+				}},
+				{"ok", 1.0},
+			}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				b, err := bson.Marshal(tc.doc)
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				var result ExplainResult
+				err = bson.Unmarshal(b, &result)
+				assert.Nil(t, err, "Unmarshal error: %v", err)
+				assert.Equal(t, bson.Raw(b), result.Raw, "expected Raw %v, got %v", bson.Raw(b), result.Raw)
+				assert.NotNil(t, result.QueryPlanner, "expected QueryPlanner to be set")
+				assert.Equal(t, "db.coll", result.QueryPlanner.Namespace, "expected namespace db.coll, got %v",
+					result.QueryPlanner.Namespace)
+				assert.Equal(t, bson.Raw(expectedPlan), result.QueryPlanner.WinningPlan,
+					"expected winning plan %v, got %v", bson.Raw(expectedPlan), result.QueryPlanner.WinningPlan)
+				assert.NotNil(t, result.ExecutionStats, "expected ExecutionStats to be set")
+				assert.Equal(t, int64(3), result.ExecutionStats.NReturned, "expected nReturned 3, got %v",
+					result.ExecutionStats.NReturned)
+				assert.Equal(t, int64(10), result.ExecutionStats.TotalDocsExamined,
+					"expected totalDocsExamined 10, got %v", result.ExecutionStats.TotalDocsExamined)
+			})
+		}
+	})
+}
+
+func marshalRaw(t *testing.T, doc bson.D) bson.Raw {
+	t.Helper()
+
+	b, err := bson.Marshal(doc)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return b
+}
+
+func marshalRawArray(t *testing.T, arr bson.A) bson.Raw {
+	t.Helper()
+
+	_, b, err := bson.MarshalValue(arr)
+	assert.Nil(t, err, "MarshalValue error: %v", err)
+	return b
 }
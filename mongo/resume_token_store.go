@@ -0,0 +1,61 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CollectionResumeTokenStore is an options.ResumeTokenStore that saves change stream resume tokens in a MongoDB
+// collection. The token is kept in a single document identified by the store's ID, so several change streams can
+// share a collection as long as each uses a different ID. The document has the form
+// {_id: <id>, token: <resume token>, updatedAt: <time of the last save>}.
+type CollectionResumeTokenStore struct {
+	coll *Collection
+	id   interface{}
+}
+
+var _ options.ResumeTokenStore = (*CollectionResumeTokenStore)(nil)
+
+// NewCollectionResumeTokenStore creates a new CollectionResumeTokenStore that saves resume tokens in the document with
+// the given ID in coll. The write concern of coll is used when saving tokens, so a majority write concern is
+// recommended to ensure that saved tokens survive a failover.
+func NewCollectionResumeTokenStore(coll *Collection, id interface{}) *CollectionResumeTokenStore {
+	return &CollectionResumeTokenStore{
+		coll: coll,
+		id:   id,
+	}
+}
+
+// Load implements the options.ResumeTokenStore interface. It returns (nil, nil) if no token has been saved.
+func (s *CollectionResumeTokenStore) Load(ctx context.Context) (bson.Raw, error) {
+	var doc struct {
+		Token bson.Raw `bson:"token"`
+	}
+	err := s.coll.FindOne(ctx, bson.D{{"_id", s.id}}).Decode(&doc)
+	if err == ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Token, nil
+}
+
+// Save implements the options.ResumeTokenStore interface.
+func (s *CollectionResumeTokenStore) Save(ctx context.Context, token bson.Raw) error {
+	update := bson.D{{"$set", bson.D{
+		{"token", token},
+		{"updatedAt", time.Now()},
+	}}}
+	_, err := s.coll.UpdateOne(ctx, bson.D{{"_id", s.id}}, update, options.Update().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,221 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
+)
+
+// tokenStoreConn is a driver.Connection that serves the update and find commands sent by a
+// CollectionResumeTokenStore from an in-memory map of token documents keyed by _id. Aggregate commands are answered
+// with an empty cursor and recorded in aggregates.
+type tokenStoreConn struct {
+	*drivertest.ChannelConn
+	tokens     map[string]bson.Raw
+	aggregates []bsoncore.Document
+	reply      []byte
+}
+
+func newTokenStoreConn() *tokenStoreConn {
+	return &tokenStoreConn{
+		ChannelConn: &drivertest.ChannelConn{
+			Desc: description.Server{
+				WireVersion:     &description.VersionRange{Max: 13},
+				MaxBatchCount:   100000,
+				MaxDocumentSize: 16 * 1024 * 1024,
+				MaxMessageSize:  48 * 1000 * 1000,
+			},
+		},
+		tokens: make(map[string]bson.Raw),
+	}
+}
+
+func (c *tokenStoreConn) WriteWireMessage(_ context.Context, wm []byte) error {
+	_, _, _, _, wm, ok := wiremessage.ReadHeader(wm)
+	if !ok {
+		return errors.New("could not read header")
+	}
+	_, wm, ok = wiremessage.ReadMsgFlags(wm)
+	if !ok {
+		return errors.New("could not read flags")
+	}
+	var cmd bsoncore.Document
+	var seq []bsoncore.Document
+	for len(wm) > 0 {
+		var stype wiremessage.SectionType
+		stype, wm, ok = wiremessage.ReadMsgSectionType(wm)
+		if !ok {
+			return errors.New("could not read section type")
+		}
+		if stype == wiremessage.SingleDocument {
+			cmd, wm, ok = wiremessage.ReadMsgSectionSingleDocument(wm)
+		} else {
+			_, seq, wm, ok = wiremessage.ReadMsgSectionDocumentSequence(wm)
+		}
+		if !ok {
+			return errors.New("could not read section")
+		}
+	}
+
+	var reply bson.D
+	switch name := cmd.Index(0).Key(); name {
+	case "update":
+		update := seq[0]
+		id := update.Lookup("q", "_id").StringValue()
+		if upsert, ok := update.Lookup("upsert").BooleanOK(); !ok || !upsert {
+			if _, exists := c.tokens[id]; !exists {
+				reply = bson.D{{"ok", 1}, {"n", 0}, {"nModified", 0}}
+				break
+			}
+		}
+		c.tokens[id] = bson.Raw(update.Lookup("u", "$set", "token").Document())
+		reply = bson.D{{"ok", 1}, {"n", 1}, {"nModified", 1}}
+	case "find":
+		id := cmd.Lookup("filter", "_id").StringValue()
+		firstBatch := bson.A{}
+		if token, ok := c.tokens[id]; ok {
+			firstBatch = append(firstBatch, bson.D{{"_id", id}, {"token", token}})
+		}
+		reply = bson.D{
+			{"ok", 1},
+			{"cursor", bson.D{{"id", int64(0)}, {"ns", "db.tokens"}, {"firstBatch", firstBatch}}},
+		}
+	case "aggregate":
+		c.aggregates = append(c.aggregates, append(bsoncore.Document(nil), cmd...))
+		reply = bson.D{
+			{"ok", 1},
+			{"cursor", bson.D{{"id", int64(0)}, {"ns", "db.events"}, {"firstBatch", bson.A{}}}},
+		}
+	default:
+		return errors.New("unexpected command " + name)
+	}
+
+	doc, err := bson.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	c.reply = drivertest.MakeReply(doc)
+	return nil
+}
+
+func (c *tokenStoreConn) ReadWireMessage(context.Context, []byte) ([]byte, error) {
+	return c.reply, nil
+}
+
+func TestCollectionResumeTokenStore(t *testing.T) {
+	token := func(data string) bson.Raw {
+		b, err := bson.Marshal(bson.D{{"_data", data}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return b
+	}
+	newClient := func(conn *tokenStoreConn) *Client {
+		clientOpts := options.Client()
+		clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		client.sessionPool = &session.Pool{}
+		return client
+	}
+
+	t.Run("load without saved token", func(t *testing.T) {
+		client := newClient(newTokenStoreConn())
+		store := NewCollectionResumeTokenStore(client.Database("db").Collection("tokens"), "stream")
+
+		got, err := store.Load(bgCtx)
+		assert.Nil(t, err, "Load error: %v", err)
+		assert.Nil(t, got, "expected no token, got %v", got)
+	})
+	t.Run("save and load", func(t *testing.T) {
+		client := newClient(newTokenStoreConn())
+		store := NewCollectionResumeTokenStore(client.Database("db").Collection("tokens"), "stream")
+
+		err := store.Save(bgCtx, token("1"))
+		assert.Nil(t, err, "Save error: %v", err)
+		got, err := store.Load(bgCtx)
+		assert.Nil(t, err, "Load error: %v", err)
+		assert.Equal(t, token("1"), got, "expected token %v, got %v", token("1"), got)
+
+		err = store.Save(bgCtx, token("2"))
+		assert.Nil(t, err, "Save error: %v", err)
+		got, err = store.Load(bgCtx)
+		assert.Nil(t, err, "Load error: %v", err)
+		assert.Equal(t, token("2"), got, "expected overwritten token %v, got %v", token("2"), got)
+	})
+	t.Run("stores are keyed by id", func(t *testing.T) {
+		client := newClient(newTokenStoreConn())
+		coll := client.Database("db").Collection("tokens")
+
+		err := NewCollectionResumeTokenStore(coll, "a").Save(bgCtx, token("1"))
+		assert.Nil(t, err, "Save error: %v", err)
+		got, err := NewCollectionResumeTokenStore(coll, "b").Load(bgCtx)
+		assert.Nil(t, err, "Load error: %v", err)
+		assert.Nil(t, got, "expected no token for a different id, got %v", got)
+	})
+	t.Run("change stream starts after stored token", func(t *testing.T) {
+		conn := newTokenStoreConn()
+		client := newClient(conn)
+		store := NewCollectionResumeTokenStore(client.Database("db").Collection("tokens"), "stream")
+		err := store.Save(bgCtx, token("1"))
+		assert.Nil(t, err, "Save error: %v", err)
+
+		cs, err := client.Database("db").Collection("events").Watch(bgCtx, Pipeline{},
+			options.ChangeStream().SetResumeTokenStore(store))
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		assert.Equal(t, 1, len(conn.aggregates), "expected 1 aggregate, got %v", len(conn.aggregates))
+		startAfter, err := conn.aggregates[0].LookupErr("pipeline", "0", "$changeStream", "startAfter")
+		assert.Nil(t, err, "expected startAfter in command %v", conn.aggregates[0])
+		assert.Equal(t, token("1"), bson.Raw(startAfter.Document()), "expected startAfter %v, got %v",
+			token("1"), startAfter)
+		assert.Equal(t, token("1"), cs.ResumeToken(), "expected resume token %v, got %v", token("1"),
+			cs.ResumeToken())
+	})
+	t.Run("change stream without stored token", func(t *testing.T) {
+		conn := newTokenStoreConn()
+		client := newClient(conn)
+		store := NewCollectionResumeTokenStore(client.Database("db").Collection("tokens"), "stream")
+
+		cs, err := client.Database("db").Collection("events").Watch(bgCtx, Pipeline{},
+			options.ChangeStream().SetResumeTokenStore(store))
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		_, err = conn.aggregates[0].LookupErr("pipeline", "0", "$changeStream", "startAfter")
+		assert.NotNil(t, err, "expected no startAfter in command %v", conn.aggregates[0])
+	})
+	t.Run("explicit start is not overridden", func(t *testing.T) {
+		conn := newTokenStoreConn()
+		client := newClient(conn)
+		store := NewCollectionResumeTokenStore(client.Database("db").Collection("tokens"), "stream")
+		err := store.Save(bgCtx, token("1"))
+		assert.Nil(t, err, "Save error: %v", err)
+
+		cs, err := client.Database("db").Collection("events").Watch(bgCtx, Pipeline{},
+			options.ChangeStream().SetResumeTokenStore(store).SetResumeAfter(token("0")))
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		stage := conn.aggregates[0].Lookup("pipeline", "0", "$changeStream").Document()
+		_, err = stage.LookupErr("startAfter")
+		assert.NotNil(t, err, "expected no startAfter in stage %v", stage)
+		resumeAfter := bson.Raw(stage.Lookup("resumeAfter").Document())
+		assert.Equal(t, token("0"), resumeAfter, "expected resumeAfter %v, got %v", token("0"), resumeAfter)
+	})
+}
@@ -8,9 +8,12 @@ package mongo
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonoptions"
 	"go.mongodb.org/mongo-driver/internal/testutil/assert"
 )
 
@@ -34,6 +37,27 @@ func TestSingleResult(t *testing.T) {
 			assert.Equal(t, firstDecode, secondDecode, "expected contents %v, got %v", firstDecode, secondDecode)
 			assert.Equal(t, firstDecode, decodeBytes, "expected contents %v, got %v", firstDecode, decodeBytes)
 		})
+		t.Run("decode with registry disallowing unknown fields", func(t *testing.T) {
+			sc, err := bsoncodec.NewStructCodec(bsoncodec.DefaultStructTagParser,
+				bsonoptions.StructCodec().SetDisallowUnknownFields(true))
+			assert.Nil(t, err, "NewStructCodec error: %v", err)
+			reg := bson.NewRegistryBuilder().RegisterDefaultDecoder(reflect.Struct, sc).Build()
+			c, err := newCursor(newTestBatchCursor(1, 1), reg)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			sr := &SingleResult{cur: c, reg: reg}
+			var res struct{ Bar int32 }
+			err = sr.Decode(&res)
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.Equal(t, "error decoding key foo: unknown field", err.Error(), "unexpected error %v", err)
+			err = c.Decode(&res)
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.Equal(t, "error decoding key foo: unknown field", err.Error(), "unexpected error %v", err)
+
+			var doc struct{ Foo int32 }
+			err = sr.Decode(&doc)
+			assert.Nil(t, err, "Decode error: %v", err)
+		})
 		t.Run("decode with error", func(t *testing.T) {
 			r := []byte("foo")
 			sr := &SingleResult{rdr: r, err: errors.New("DecodeBytes error")}
@@ -0,0 +1,390 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package update provides builders for update and replacement documents. An *Update can be passed anywhere the driver
+// accepts an update document, such as Collection.UpdateOne:
+//
+//	u := update.New().Set("status", "A").Inc("qty", 1)
+//	if note != "" {
+//		u.Push("notes", note)
+//	}
+//	result, err := coll.UpdateOne(ctx, filter.Eq("_id", id), u)
+//
+// Updates that use the filtered positional operator ($[<identifier>]) can declare their array filters with
+// ArrayFilter and pass them to the operation with ArrayFilters:
+//
+//	u := update.New().Set("grades.$[g].passed", true).ArrayFilter(filter.Gte("g.score", 60))
+//	opts := options.Update().SetArrayFilters(u.ArrayFilters())
+//	result, err := coll.UpdateMany(ctx, bson.D{}, u, opts)
+//
+// The builders check the structure of the update client-side, such as conflicting paths or array filter identifiers
+// that are never declared. Such an error is returned when the Update is marshalled, so it is reported by the
+// collection method the Update is passed to. Update values are marshalled with bson.DefaultRegistry.
+package update // import "go.mongodb.org/mongo-driver/mongo/update"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrEmptyUpdate is the error for an Update without any operators.
+var ErrEmptyUpdate = errors.New("update must contain at least one operator")
+
+var identifierRegex = regexp.MustCompile(`\$\[([a-z][a-zA-Z0-9]*)\]`)
+
+// Update is an update document. An Update must be created with New. The methods that add operators return the Update
+// so calls can be chained.
+type Update struct {
+	ops          bson.D // each element maps an operator to a bson.D of fields
+	paths        []string
+	arrayFilters []interface{}
+	err          error
+}
+
+// New creates a new, empty Update.
+func New() *Update {
+	return &Update{}
+}
+
+// Err returns the first error encountered while building the Update, if any.
+func (u *Update) Err() error {
+	return u.err
+}
+
+// Document returns the update document. An error is returned if the Update is empty, if an error was encountered while
+// building it, or if it uses array filter identifiers that do not match the filters declared with ArrayFilter.
+func (u *Update) Document() (bson.D, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	if len(u.ops) == 0 {
+		return nil, ErrEmptyUpdate
+	}
+	if err := u.checkArrayFilters(); err != nil {
+		return nil, err
+	}
+	return u.ops, nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (u *Update) MarshalBSON() ([]byte, error) {
+	d, err := u.Document()
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(d)
+}
+
+// add adds {op: {field: val}} to the update.
+func (u *Update) add(op, field string, val interface{}) *Update {
+	if u.err != nil {
+		return u
+	}
+	if err := u.addPath(field); err != nil {
+		u.err = err
+		return u
+	}
+
+	for i, elem := range u.ops {
+		if elem.Key == op {
+			u.ops[i].Value = append(elem.Value.(bson.D), bson.E{field, val})
+			return u
+		}
+	}
+	u.ops = append(u.ops, bson.E{op, bson.D{{field, val}}})
+	return u
+}
+
+// addPath records a path modified by the update. The server rejects updates in which a path is modified more than
+// once or in which one modified path is a prefix of another.
+func (u *Update) addPath(path string) error {
+	if path == "" {
+		return errors.New("update field name cannot be empty")
+	}
+	if strings.HasPrefix(path, "$") {
+		return fmt.Errorf("update field name %q cannot begin with '$'", path)
+	}
+	for _, existing := range u.paths {
+		if pathsConflict(existing, path) {
+			return fmt.Errorf("updating the path %q would create a conflict at %q", path, existing)
+		}
+	}
+	u.paths = append(u.paths, path)
+	return nil
+}
+
+func pathsConflict(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b || strings.HasPrefix(b, a+".")
+}
+
+// Merge adds the operators of other to the update.
+func (u *Update) Merge(other *Update) *Update {
+	if u.err != nil {
+		return u
+	}
+	if other.err != nil {
+		u.err = other.err
+		return u
+	}
+	for _, op := range other.ops {
+		for _, field := range op.Value.(bson.D) {
+			if op.Key == "$rename" {
+				u.Rename(field.Key, field.Value.(string))
+				continue
+			}
+			u.add(op.Key, field.Key, field.Value)
+		}
+	}
+	u.arrayFilters = append(u.arrayFilters, other.arrayFilters...)
+	return u
+}
+
+// Field update operators.
+
+// Set adds {$set: {field: val}} to the update.
+func (u *Update) Set(field string, val interface{}) *Update {
+	return u.add("$set", field, val)
+}
+
+// SetOnInsert adds {$setOnInsert: {field: val}} to the update. The field is only set if the update inserts a new
+// document.
+func (u *Update) SetOnInsert(field string, val interface{}) *Update {
+	return u.add("$setOnInsert", field, val)
+}
+
+// Unset adds {$unset: {field: ""}} to the update for each of the given fields.
+func (u *Update) Unset(fields ...string) *Update {
+	for _, field := range fields {
+		u.add("$unset", field, "")
+	}
+	return u
+}
+
+// Inc adds {$inc: {field: amount}} to the update.
+func (u *Update) Inc(field string, amount interface{}) *Update {
+	return u.add("$inc", field, amount)
+}
+
+// Mul adds {$mul: {field: factor}} to the update.
+func (u *Update) Mul(field string, factor interface{}) *Update {
+	return u.add("$mul", field, factor)
+}
+
+// Min adds {$min: {field: val}} to the update. The field is only updated if val is less than its current value.
+func (u *Update) Min(field string, val interface{}) *Update {
+	return u.add("$min", field, val)
+}
+
+// Max adds {$max: {field: val}} to the update. The field is only updated if val is greater than its current value.
+func (u *Update) Max(field string, val interface{}) *Update {
+	return u.add("$max", field, val)
+}
+
+// Rename adds {$rename: {field: newName}} to the update.
+func (u *Update) Rename(field, newName string) *Update {
+	if u.err == nil {
+		u.err = u.addPath(newName)
+	}
+	return u.add("$rename", field, newName)
+}
+
+// CurrentDate adds {$currentDate: {field: true}} to the update, which sets the field to the current date.
+func (u *Update) CurrentDate(field string) *Update {
+	return u.add("$currentDate", field, true)
+}
+
+// CurrentTimestamp adds {$currentDate: {field: {$type: "timestamp"}}} to the update, which sets the field to the
+// current timestamp.
+func (u *Update) CurrentTimestamp(field string) *Update {
+	return u.add("$currentDate", field, bson.D{{"$type", "timestamp"}})
+}
+
+// Array update operators.
+
+// Push adds {$push: {field: val}} to the update.
+func (u *Update) Push(field string, val interface{}) *Update {
+	return u.add("$push", field, val)
+}
+
+// PushOptions specifies modifiers for PushEach. Nil fields are omitted.
+type PushOptions struct {
+	// The index at which the values are inserted.
+	Position *int32
+
+	// The number of elements to keep after the values are added. A negative number keeps the last elements.
+	Slice *int32
+
+	// The sort order of the array after the values are added, such as 1, -1, or a document like bson.D{{"score", -1}}.
+	Sort interface{}
+}
+
+// PushEach adds {$push: {field: {$each: vals}}} to the update with the given modifiers. The opts parameter can be nil.
+func (u *Update) PushEach(field string, vals []interface{}, opts *PushOptions) *Update {
+	spec := bson.D{{"$each", array(vals)}}
+	if opts != nil {
+		if opts.Position != nil {
+			spec = append(spec, bson.E{"$position", *opts.Position})
+		}
+		if opts.Slice != nil {
+			spec = append(spec, bson.E{"$slice", *opts.Slice})
+		}
+		if opts.Sort != nil {
+			spec = append(spec, bson.E{"$sort", opts.Sort})
+		}
+	}
+	return u.add("$push", field, spec)
+}
+
+// AddToSet adds {$addToSet: {field: val}} to the update.
+func (u *Update) AddToSet(field string, val interface{}) *Update {
+	return u.add("$addToSet", field, val)
+}
+
+// AddToSetEach adds {$addToSet: {field: {$each: vals}}} to the update.
+func (u *Update) AddToSetEach(field string, vals ...interface{}) *Update {
+	return u.add("$addToSet", field, bson.D{{"$each", array(vals)}})
+}
+
+// PopFirst adds {$pop: {field: -1}} to the update, which removes the first element of the array.
+func (u *Update) PopFirst(field string) *Update {
+	return u.add("$pop", field, int32(-1))
+}
+
+// PopLast adds {$pop: {field: 1}} to the update, which removes the last element of the array.
+func (u *Update) PopLast(field string) *Update {
+	return u.add("$pop", field, int32(1))
+}
+
+// Pull adds {$pull: {field: cond}} to the update, which removes the elements that equal or match cond.
+func (u *Update) Pull(field string, cond interface{}) *Update {
+	return u.add("$pull", field, cond)
+}
+
+// PullAll adds {$pullAll: {field: vals}} to the update, which removes the elements that equal any of the values.
+func (u *Update) PullAll(field string, vals ...interface{}) *Update {
+	return u.add("$pullAll", field, array(vals))
+}
+
+// Bitwise update operators.
+
+// BitAnd adds {$bit: {field: {and: val}}} to the update.
+func (u *Update) BitAnd(field string, val int64) *Update {
+	return u.add("$bit", field, bson.D{{"and", val}})
+}
+
+// BitOr adds {$bit: {field: {or: val}}} to the update.
+func (u *Update) BitOr(field string, val int64) *Update {
+	return u.add("$bit", field, bson.D{{"or", val}})
+}
+
+// BitXor adds {$bit: {field: {xor: val}}} to the update.
+func (u *Update) BitXor(field string, val int64) *Update {
+	return u.add("$bit", field, bson.D{{"xor", val}})
+}
+
+// ArrayFilter declares an array filter for the filtered positional operator. The top-level keys of the filter must
+// begin with the identifier used in the update paths, such as "elem" in {"elem.grade": {$gte: 85}}.
+func (u *Update) ArrayFilter(filter interface{}) *Update {
+	if u.err != nil {
+		return u
+	}
+	if filter == nil {
+		u.err = errors.New("array filter cannot be nil")
+		return u
+	}
+	u.arrayFilters = append(u.arrayFilters, filter)
+	return u
+}
+
+// ArrayFilters returns the array filters declared with ArrayFilter, for use with the SetArrayFilters method of the
+// update options.
+func (u *Update) ArrayFilters() options.ArrayFilters {
+	return options.ArrayFilters{Filters: u.arrayFilters}
+}
+
+// checkArrayFilters checks that every identifier used in the update paths is declared by an array filter and that every
+// array filter is used. It is a no-op if no array filters were declared, because the filters may be passed to the
+// operation separately.
+func (u *Update) checkArrayFilters() error {
+	if len(u.arrayFilters) == 0 {
+		return nil
+	}
+
+	declared := make(map[string]bool)
+	for _, f := range u.arrayFilters {
+		raw, err := bson.Marshal(f)
+		if err != nil {
+			return err
+		}
+		elems, err := bson.Raw(raw).Elements()
+		if err != nil {
+			return err
+		}
+		if len(elems) == 0 {
+			return errors.New("array filter cannot be empty")
+		}
+		for _, elem := range elems {
+			declared[strings.SplitN(elem.Key(), ".", 2)[0]] = true
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, path := range u.paths {
+		for _, match := range identifierRegex.FindAllStringSubmatch(path, -1) {
+			if !declared[match[1]] {
+				return fmt.Errorf("no array filter found for identifier %q in path %q", match[1], path)
+			}
+			used[match[1]] = true
+		}
+	}
+	for id := range declared {
+		if !used[id] {
+			return fmt.Errorf("the array filter for identifier %q was not used in the update", id)
+		}
+	}
+	return nil
+}
+
+// Replacement marshals a replacement document for Collection.ReplaceOne or Collection.FindOneAndReplace. An error is
+// returned if doc is nil or if any of its top-level keys begins with '$', which would make it an update document.
+func Replacement(doc interface{}) (bson.Raw, error) {
+	if doc == nil {
+		return nil, errors.New("replacement document cannot be nil")
+	}
+	if _, ok := doc.(*Update); ok {
+		return nil, errors.New("replacement document cannot be an *Update")
+	}
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	elems, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		if strings.HasPrefix(elem.Key(), "$") {
+			return nil, fmt.Errorf("replacement document cannot contain the operator %q at the top level", elem.Key())
+		}
+	}
+	return raw, nil
+}
+
+// array converts vals to a bson.A so that no values are marshalled as a BSON null.
+func array(vals []interface{}) bson.A {
+	if vals == nil {
+		return bson.A{}
+	}
+	return bson.A(vals)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package update
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/filter"
+)
+
+func TestUpdate(t *testing.T) {
+	slice := int32(-5)
+	testCases := []struct {
+		name     string
+		update   *Update
+		expected string
+	}{
+		{
+			"field operators",
+			New().Set("status", "A").Inc("qty", 2).Set("size.uom", "cm").Unset("tmp", "old").CurrentTimestamp("ts"),
+			`{"$set":{"status":"A","size.uom":"cm"},"$inc":{"qty":2},"$unset":{"tmp":"","old":""},` +
+				`"$currentDate":{"ts":{"$type":"timestamp"}}}`,
+		},
+		{
+			"array operators",
+			New().PushEach("scores", []interface{}{90, 92}, &PushOptions{Slice: &slice, Sort: -1}).
+				AddToSetEach("tags", "a", "b").PopFirst("queue").Pull("items", filter.Gt("price", 10)),
+			`{"$push":{"scores":{"$each":[90,92],"$slice":-5,"$sort":-1}},"$addToSet":{"tags":{"$each":["a","b"]}},` +
+				`"$pop":{"queue":-1},"$pull":{"items":{"price":{"$gt":10}}}}`,
+		},
+		{
+			"merge",
+			New().Set("a", 1).Merge(New().Set("b", 2).Rename("c", "d")),
+			`{"$set":{"a":1,"b":2},"$rename":{"c":"d"}}`,
+		},
+		{
+			"array filters",
+			New().Set("grades.$[g].passed", true).Inc("grades.$[].attempts", 1).ArrayFilter(filter.Gte("g.score", 60)),
+			`{"$set":{"grades.$[g].passed":true},"$inc":{"grades.$[].attempts":1}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := bson.MarshalExtJSON(tc.update, false, false)
+			assert.Nil(t, err, "MarshalExtJSON error: %v", err)
+			assert.Equal(t, tc.expected, string(b), "expected %v, got %v", tc.expected, string(b))
+		})
+	}
+
+	errorCases := []struct {
+		name   string
+		update *Update
+	}{
+		{"empty", New()},
+		{"empty field name", New().Set("", 1)},
+		{"operator field name", New().Set("$set", 1)},
+		{"duplicate path", New().Set("a", 1).Inc("a", 1)},
+		{"prefix path", New().Set("a.b", 1).Unset("a")},
+		{"rename conflict", New().Set("b", 1).Rename("a", "b")},
+		{"undeclared identifier", New().Set("a.$[x]", 1).ArrayFilter(bson.D{{"y", 1}})},
+		{"unused array filter", New().Set("a.$[x]", 1).ArrayFilter(bson.D{{"x", 1}}).ArrayFilter(bson.D{{"y.z", 1}})},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := bson.Marshal(tc.update)
+			assert.NotNil(t, err, "expected Marshal error, got nil")
+		})
+	}
+
+	t.Run("array filters option", func(t *testing.T) {
+		af := New().Set("a.$[x]", 1).ArrayFilter(bson.D{{"x", 1}}).ArrayFilters()
+		arr, err := af.ToArray()
+		assert.Nil(t, err, "ToArray error: %v", err)
+		assert.Equal(t, 1, len(arr), "expected 1 array filter, got %v", len(arr))
+	})
+}
+
+func TestReplacement(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		raw, err := Replacement(bson.D{{"name", "x"}, {"nested", bson.D{{"$keep", 1}}}})
+		assert.Nil(t, err, "Replacement error: %v", err)
+		assert.Equal(t, "x", raw.Lookup("name").StringValue(), "expected name x, got %v", raw.Lookup("name"))
+	})
+	t.Run("top-level operator", func(t *testing.T) {
+		_, err := Replacement(bson.D{{"name", "x"}, {"$set", bson.D{{"a", 1}}}})
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+	t.Run("update", func(t *testing.T) {
+		_, err := Replacement(New().Set("a", 1))
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+}
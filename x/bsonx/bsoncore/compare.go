@@ -0,0 +1,359 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// canonicalType returns the sort order of values of type t. Values of different canonical types are compared by their
+// canonical types alone.
+func canonicalType(t bsontype.Type) int {
+	switch t {
+	case bsontype.MinKey:
+		return -1
+	case bsontype.Undefined:
+		return 0
+	case bsontype.Null:
+		return 5
+	case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return 10
+	case bsontype.String, bsontype.Symbol:
+		return 15
+	case bsontype.EmbeddedDocument:
+		return 20
+	case bsontype.Array:
+		return 25
+	case bsontype.Binary:
+		return 30
+	case bsontype.ObjectID:
+		return 35
+	case bsontype.Boolean:
+		return 40
+	case bsontype.DateTime:
+		return 45
+	case bsontype.Timestamp:
+		return 47
+	case bsontype.Regex:
+		return 50
+	case bsontype.DBPointer:
+		return 55
+	case bsontype.JavaScript:
+		return 60
+	case bsontype.CodeWithScope:
+		return 65
+	case bsontype.MaxKey:
+		return 127
+	}
+	return 0
+}
+
+// CompareValues compares two BSON values using the order in which MongoDB sorts them. It returns -1 if a sorts before
+// b, 0 if they are equal, and 1 if a sorts after b.
+//
+// Values of different types are ordered by type: MinKey, undefined, null, numbers, strings and symbols, documents,
+// arrays, binary data, ObjectIDs, booleans, dates, timestamps, regular expressions, DBPointers, JavaScript code,
+// JavaScript code with scope, and MaxKey. Numbers of different types are compared by their numeric values, and NaN
+// sorts before every other number. Strings are compared by their UTF-8 bytes, as they are on the server when no
+// collation is specified. Documents and arrays are compared element by element.
+func CompareValues(a, b Value) int {
+	ca, cb := canonicalType(a.Type), canonicalType(b.Type)
+	if ca != cb {
+		return compareInts(int64(ca), int64(cb))
+	}
+
+	switch a.Type {
+	case bsontype.MinKey, bsontype.MaxKey, bsontype.Undefined, bsontype.Null:
+		return 0
+	case bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return compareNumbers(a, b)
+	case bsontype.String, bsontype.Symbol:
+		return strings.Compare(stringOf(a), stringOf(b))
+	case bsontype.EmbeddedDocument:
+		return compareElements(a.Document(), b.Document())
+	case bsontype.Array:
+		return compareElements(Document(a.Array()), Document(b.Array()))
+	case bsontype.Binary:
+		sa, da := a.Binary()
+		sb, db := b.Binary()
+		if c := compareInts(int64(len(da)), int64(len(db))); c != 0 {
+			return c
+		}
+		if c := compareInts(int64(sa), int64(sb)); c != 0 {
+			return c
+		}
+		return bytes.Compare(da, db)
+	case bsontype.ObjectID:
+		oa, ob := a.ObjectID(), b.ObjectID()
+		return bytes.Compare(oa[:], ob[:])
+	case bsontype.Boolean:
+		return compareBools(a.Boolean(), b.Boolean())
+	case bsontype.DateTime:
+		return compareInts(a.DateTime(), b.DateTime())
+	case bsontype.Timestamp:
+		ta, ia := a.Timestamp()
+		tb, ib := b.Timestamp()
+		if c := compareInts(int64(ta), int64(tb)); c != 0 {
+			return c
+		}
+		return compareInts(int64(ia), int64(ib))
+	case bsontype.Regex:
+		pa, oa := a.Regex()
+		pb, ob := b.Regex()
+		if c := strings.Compare(pa, pb); c != 0 {
+			return c
+		}
+		return strings.Compare(oa, ob)
+	case bsontype.DBPointer:
+		nsa, oida := a.DBPointer()
+		nsb, oidb := b.DBPointer()
+		if c := compareInts(int64(len(nsa)), int64(len(nsb))); c != 0 {
+			return c
+		}
+		if c := strings.Compare(nsa, nsb); c != 0 {
+			return c
+		}
+		return bytes.Compare(oida[:], oidb[:])
+	case bsontype.JavaScript:
+		return strings.Compare(a.JavaScript(), b.JavaScript())
+	case bsontype.CodeWithScope:
+		codeA, scopeA := a.CodeWithScope()
+		codeB, scopeB := b.CodeWithScope()
+		if c := strings.Compare(codeA, codeB); c != 0 {
+			return c
+		}
+		return compareElements(scopeA, scopeB)
+	}
+	return 0
+}
+
+// compareElements compares the elements of a and b in order. Each pair of elements is compared by the canonical types
+// of their values, then by their keys, and then by their values. A document that is a prefix of another is smaller.
+func compareElements(a, b Document) int {
+	elemsA, _ := a.Elements()
+	elemsB, _ := b.Elements()
+	for i := 0; i < len(elemsA) && i < len(elemsB); i++ {
+		va, vb := elemsA[i].Value(), elemsB[i].Value()
+		if c := compareInts(int64(canonicalType(va.Type)), int64(canonicalType(vb.Type))); c != 0 {
+			return c
+		}
+		if c := strings.Compare(elemsA[i].Key(), elemsB[i].Key()); c != 0 {
+			return c
+		}
+		if c := CompareValues(va, vb); c != 0 {
+			return c
+		}
+	}
+	return compareInts(int64(len(elemsA)), int64(len(elemsB)))
+}
+
+func stringOf(v Value) string {
+	if v.Type == bsontype.Symbol {
+		return v.Symbol()
+	}
+	return v.StringValue()
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	}
+	return 1
+}
+
+// number is a numeric value decomposed for comparison. NaN sorts before every other number.
+type number struct {
+	nan bool
+	inf int      // -1 or 1 for infinities
+	i   int64    // the value if rat is nil and the number is an integer
+	rat *big.Rat // the value if the number is not an int32 or int64
+}
+
+func toNumber(v Value) number {
+	switch v.Type {
+	case bsontype.Int32:
+		return number{i: int64(v.Int32())}
+	case bsontype.Int64:
+		return number{i: v.Int64()}
+	case bsontype.Double:
+		f := v.Double()
+		switch {
+		case math.IsNaN(f):
+			return number{nan: true}
+		case math.IsInf(f, 1):
+			return number{inf: 1}
+		case math.IsInf(f, -1):
+			return number{inf: -1}
+		}
+		return number{rat: new(big.Rat).SetFloat64(f)}
+	case bsontype.Decimal128:
+		d := v.Decimal128()
+		if d.IsNaN() {
+			return number{nan: true}
+		}
+		if inf := d.IsInf(); inf != 0 {
+			return number{inf: inf}
+		}
+		bi, exp, err := d.BigInt()
+		if err != nil {
+			return number{nan: true}
+		}
+		r := new(big.Rat).SetInt(bi)
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+		if exp >= 0 {
+			return number{rat: r.Mul(r, new(big.Rat).SetInt(scale))}
+		}
+		return number{rat: r.Quo(r, new(big.Rat).SetInt(scale))}
+	}
+	return number{nan: true}
+}
+
+func (n number) toRat() *big.Rat {
+	if n.rat != nil {
+		return n.rat
+	}
+	return new(big.Rat).SetInt64(n.i)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// compareNumbers compares two numeric values exactly, regardless of their types.
+func compareNumbers(a, b Value) int {
+	if a.Type == bsontype.Double && b.Type == bsontype.Double {
+		fa, fb := a.Double(), b.Double()
+		if !math.IsNaN(fa) && !math.IsNaN(fb) {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	na, nb := toNumber(a), toNumber(b)
+	switch {
+	case na.nan || nb.nan:
+		return compareBools(!na.nan, !nb.nan)
+	case na.inf != 0 || nb.inf != 0:
+		return compareInts(int64(na.inf), int64(nb.inf))
+	case na.rat == nil && nb.rat == nil:
+		return compareInts(na.i, nb.i)
+	}
+	return na.toRat().Cmp(nb.toRat())
+}
+
+// CompareDocuments compares two documents using the order in which MongoDB sorts them for the given sort
+// specification, such as {a: 1, "b.c": -1}. It returns -1 if a sorts before b, 0 if they are equal, and 1 if a sorts
+// after b. If sortSpec is empty, the documents are compared as a whole, as described in CompareValues.
+//
+// The sort key of a document for each field of the sort specification is the value at that dotted path, where a
+// missing value sorts as null. If the path refers to an array or traverses one, the sort key is the smallest element
+// for an ascending sort and the largest element for a descending sort, and an empty array sorts before null. An error
+// is returned if the sort specification contains a value other than 1 or -1.
+func CompareDocuments(a, b, sortSpec Document) (int, error) {
+	if len(sortSpec) == 0 {
+		return compareElements(a, b), nil
+	}
+
+	elems, err := sortSpec.Elements()
+	if err != nil {
+		return 0, err
+	}
+	for _, elem := range elems {
+		direction, ok := elem.Value().AsInt64OK()
+		if !ok || (direction != 1 && direction != -1) {
+			return 0, fmt.Errorf("sort direction for field %q must be 1 or -1, but got %v", elem.Key(), elem.Value())
+		}
+		path := strings.Split(elem.Key(), ".")
+		c := CompareValues(sortKey(a, path, direction), sortKey(b, path, direction))
+		if c != 0 {
+			return c * int(direction), nil
+		}
+	}
+	return 0, nil
+}
+
+var (
+	nullValue      = Value{Type: bsontype.Null}
+	undefinedValue = Value{Type: bsontype.Undefined}
+)
+
+// sortKey returns the value of doc used to sort by the field at path in the given direction.
+func sortKey(doc Document, path []string, direction int64) Value {
+	var values []Value
+	var found bool
+	collectSortValues(Value{Type: bsontype.EmbeddedDocument, Data: doc}, path, &values, &found)
+	if len(values) == 0 {
+		// An empty array sorts before a missing field.
+		if found {
+			return undefinedValue
+		}
+		return nullValue
+	}
+
+	key := values[0]
+	for _, v := range values[1:] {
+		if c := CompareValues(v, key); c*int(direction) < 0 {
+			key = v
+		}
+	}
+	return key
+}
+
+// collectSortValues appends the values at path in val to values, expanding arrays. A missing value in an array
+// element is appended as null. found is set if a value was found at the path, even if it is an empty array.
+func collectSortValues(val Value, path []string, values *[]Value, found *bool) {
+	switch {
+	case len(path) == 0 && val.Type == bsontype.Array:
+		*found = true
+		elems, _ := val.Array().Values()
+		*values = append(*values, elems...)
+	case len(path) == 0:
+		*found = true
+		*values = append(*values, val)
+	case val.Type == bsontype.EmbeddedDocument:
+		next, err := val.Document().LookupErr(path[0])
+		if err != nil {
+			*values = append(*values, nullValue)
+			return
+		}
+		collectSortValues(next, path[1:], values, found)
+	case val.Type == bsontype.Array:
+		elems, _ := val.Array().Values()
+		for _, elem := range elems {
+			collectSortValues(elem, path, values, found)
+		}
+	default:
+		*values = append(*values, nullValue)
+	}
+}
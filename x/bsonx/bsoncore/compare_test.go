@@ -0,0 +1,209 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncore
+
+import (
+	"math"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+)
+
+func decimalValue(t *testing.T, s string) Value {
+	t.Helper()
+
+	d, err := primitive.ParseDecimal128(s)
+	assert.Nil(t, err, "ParseDecimal128 error: %v", err)
+	return Value{Type: bsontype.Decimal128, Data: AppendDecimal128(nil, d)}
+}
+
+func TestCompareValues(t *testing.T) {
+	int32Value := func(i int32) Value { return Value{Type: bsontype.Int32, Data: AppendInt32(nil, i)} }
+	int64Value := func(i int64) Value { return Value{Type: bsontype.Int64, Data: AppendInt64(nil, i)} }
+	doubleValue := func(f float64) Value { return Value{Type: bsontype.Double, Data: AppendDouble(nil, f)} }
+	stringValue := func(s string) Value { return Value{Type: bsontype.String, Data: AppendString(nil, s)} }
+	docValue := func(elems ...[]byte) Value { return BuildDocumentValue(elems...) }
+	arrayValue := func(vals ...Value) Value { return Value{Type: bsontype.Array, Data: BuildArray(nil, vals...)} }
+	oid := primitive.NewObjectID()
+
+	// Each value sorts strictly before the next one.
+	ordered := []Value{
+		{Type: bsontype.MinKey},
+		{Type: bsontype.Undefined},
+		{Type: bsontype.Null},
+		doubleValue(math.NaN()),
+		doubleValue(math.Inf(-1)),
+		int64Value(math.MinInt64),
+		int32Value(-1),
+		decimalValue(t, "-0.5"),
+		int32Value(0),
+		decimalValue(t, "0.1"),
+		doubleValue(0.1),
+		int64Value(1 << 53),
+		doubleValue(1<<53 + 2),
+		int64Value(1<<53 + 3),
+		decimalValue(t, "1E+30"),
+		doubleValue(math.Inf(1)),
+		stringValue(""),
+		stringValue("B"),
+		{Type: bsontype.Symbol, Data: AppendSymbol(nil, "a")},
+		stringValue("ab"),
+		docValue(),
+		docValue(AppendInt32Element(nil, "a", 1)),
+		docValue(AppendInt32Element(nil, "a", 2)),
+		docValue(AppendInt32Element(nil, "b", 1)),
+		docValue(AppendInt32Element(nil, "b", 1), AppendInt32Element(nil, "c", 1)),
+		// The types of the values are compared before the keys.
+		docValue(AppendStringElement(nil, "a", "")),
+		arrayValue(),
+		arrayValue(int32Value(1)),
+		{Type: bsontype.Binary, Data: AppendBinary(nil, 5, []byte{0xFF})},
+		{Type: bsontype.Binary, Data: AppendBinary(nil, 0, []byte{0x00, 0x00})},
+		{Type: bsontype.ObjectID, Data: AppendObjectID(nil, oid)},
+		{Type: bsontype.Boolean, Data: AppendBoolean(nil, false)},
+		{Type: bsontype.Boolean, Data: AppendBoolean(nil, true)},
+		{Type: bsontype.DateTime, Data: AppendDateTime(nil, -1)},
+		{Type: bsontype.DateTime, Data: AppendDateTime(nil, 1)},
+		{Type: bsontype.Timestamp, Data: AppendTimestamp(nil, 1, 2)},
+		{Type: bsontype.Timestamp, Data: AppendTimestamp(nil, 2, 1)},
+		{Type: bsontype.Regex, Data: AppendRegex(nil, "a", "i")},
+		{Type: bsontype.JavaScript, Data: AppendJavaScript(nil, "x")},
+		{Type: bsontype.MaxKey},
+	}
+	for i := range ordered {
+		for j := range ordered {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			got := CompareValues(ordered[i], ordered[j])
+			assert.Equal(t, expected, got, "expected CompareValues(%v, %v) to be %v, got %v", ordered[i], ordered[j],
+				expected, got)
+		}
+	}
+
+	equal := [][2]Value{
+		{int32Value(1), doubleValue(1)},
+		{int64Value(-3), decimalValue(t, "-3.000")},
+		{doubleValue(0.5), decimalValue(t, "5E-1")},
+		{doubleValue(math.NaN()), decimalValue(t, "NaN")},
+		{stringValue("a"), {Type: bsontype.Symbol, Data: AppendSymbol(nil, "a")}},
+		{docValue(AppendInt32Element(nil, "a", 1)), docValue(AppendDoubleElement(nil, "a", 1))},
+	}
+	for _, pair := range equal {
+		got := CompareValues(pair[0], pair[1])
+		assert.Equal(t, 0, got, "expected %v and %v to be equal, got %v", pair[0], pair[1], got)
+	}
+}
+
+func TestCompareDocuments(t *testing.T) {
+	doc := func(elems ...[]byte) Document { return BuildDocument(nil, elems...) }
+	array := func(vals ...int32) []byte {
+		values := make([]Value, 0, len(vals))
+		for _, v := range vals {
+			values = append(values, Value{Type: bsontype.Int32, Data: AppendInt32(nil, v)})
+		}
+		return BuildArray(nil, values...)
+	}
+	asc := func(key string) []byte { return AppendInt32Element(nil, key, 1) }
+	desc := func(key string) []byte { return AppendInt32Element(nil, key, -1) }
+
+	testCases := []struct {
+		name     string
+		a, b     Document
+		sortSpec Document
+		expected int
+	}{
+		{
+			"whole documents",
+			doc(AppendInt32Element(nil, "a", 1)),
+			doc(AppendInt32Element(nil, "a", 2)),
+			nil,
+			-1,
+		},
+		{
+			"ascending",
+			doc(AppendInt32Element(nil, "a", 2), AppendInt32Element(nil, "b", 1)),
+			doc(AppendInt32Element(nil, "a", 1), AppendInt32Element(nil, "b", 2)),
+			doc(asc("a")),
+			1,
+		},
+		{
+			"descending",
+			doc(AppendInt32Element(nil, "a", 2)),
+			doc(AppendInt64Element(nil, "a", 1)),
+			doc(desc("a")),
+			-1,
+		},
+		{
+			"second key breaks tie",
+			doc(AppendInt32Element(nil, "a", 1), AppendStringElement(nil, "b", "x")),
+			doc(AppendDoubleElement(nil, "a", 1), AppendStringElement(nil, "b", "y")),
+			doc(asc("a"), desc("b")),
+			1,
+		},
+		{
+			"missing sorts as null",
+			doc(AppendNullElement(nil, "a")),
+			doc(AppendInt32Element(nil, "b", 1)),
+			doc(asc("a")),
+			0,
+		},
+		{
+			"missing before value",
+			doc(AppendInt32Element(nil, "b", 1)),
+			doc(AppendStringElement(nil, "a", "")),
+			doc(asc("a")),
+			-1,
+		},
+		{
+			"dotted path",
+			doc(AppendDocumentElement(nil, "a", doc(AppendInt32Element(nil, "b", 5)))),
+			doc(AppendDocumentElement(nil, "a", doc(AppendInt32Element(nil, "b", 3)))),
+			doc(asc("a.b")),
+			1,
+		},
+		{
+			"ascending array uses minimum",
+			doc(AppendArrayElement(nil, "a", array(5, 1, 9))),
+			doc(AppendInt32Element(nil, "a", 2)),
+			doc(asc("a")),
+			-1,
+		},
+		{
+			"descending array uses maximum",
+			doc(AppendArrayElement(nil, "a", array(5, 1, 9))),
+			doc(AppendInt32Element(nil, "a", 8)),
+			doc(desc("a")),
+			-1,
+		},
+		{
+			"empty array before missing",
+			doc(AppendArrayElement(nil, "a", array())),
+			doc(),
+			doc(asc("a")),
+			-1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CompareDocuments(tc.a, tc.b, tc.sortSpec)
+			assert.Nil(t, err, "CompareDocuments error: %v", err)
+			assert.Equal(t, tc.expected, got, "expected %v, got %v", tc.expected, got)
+		})
+	}
+
+	t.Run("invalid sort direction", func(t *testing.T) {
+		_, err := CompareDocuments(doc(), doc(), doc(AppendInt32Element(nil, "a", 2)))
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+}
@@ -34,6 +34,7 @@ type BatchCursor struct {
 	postBatchResumeToken bsoncore.Document
 	crypt                Crypt
 	serverAPI            *ServerAPIOptions
+	timeout              *time.Duration
 
 	// legacy server (< 3.2) fields
 	legacy      bool // This field is provided for ListCollectionsBatchCursor.
@@ -132,6 +133,7 @@ type CursorOptions struct {
 	CommandMonitor *event.CommandMonitor
 	Crypt          Crypt
 	ServerAPI      *ServerAPIOptions
+	Timeout        *time.Duration
 }
 
 // NewBatchCursor creates a new BatchCursor from the provided parameters.
@@ -153,6 +155,7 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		postBatchResumeToken: cr.postBatchResumeToken,
 		crypt:                opts.Crypt,
 		serverAPI:            opts.ServerAPI,
+		timeout:              opts.Timeout,
 	}
 
 	if ds != nil {
@@ -356,6 +359,10 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		CommandMonitor: bc.cmdMonitor,
 		Crypt:          bc.crypt,
 		ServerAPI:      bc.serverAPI,
+		Timeout:        bc.timeout,
+		// The server does not allow maxTimeMS on getMore for non-tailable cursors, so only the
+		// explicitly configured maxTimeMS is sent.
+		OmitMaxTimeMS: true,
 	}.Execute(ctx, nil)
 
 	// Once the cursor has been drained, we can unpin the connection if one is currently pinned.
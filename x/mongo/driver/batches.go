@@ -17,6 +17,11 @@ type Batches struct {
 	Documents  []bsoncore.Document
 	Current    []bsoncore.Document
 	Ordered    *bool
+
+	// SplitByMessageSize specifies that batches should be split using the server's maxMessageSizeBytes rather than
+	// its maxBsonObjectSize. This should only be set for commands whose documents wrap user documents, such as the
+	// operations of a client-level bulkWrite command.
+	SplitByMessageSize bool
 }
 
 // Valid returns true if Batches contains both an identifier and the length of Documents is greater
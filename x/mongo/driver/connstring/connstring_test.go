@@ -537,6 +537,33 @@ func TestSocketTimeout(t *testing.T) {
 	}
 }
 
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected time.Duration
+		err      bool
+	}{
+		{s: "timeoutMS=0", expected: time.Duration(0)},
+		{s: "timeoutMS=100", expected: time.Duration(100) * time.Millisecond},
+		{s: "timeoutMS=-2", err: true},
+		{s: "timeoutMS=gsdge", err: true},
+	}
+
+	for _, test := range tests {
+		s := fmt.Sprintf("mongodb://localhost/?%s", test.s)
+		t.Run(s, func(t *testing.T) {
+			cs, err := connstring.ParseAndValidate(s)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, cs.Timeout)
+				require.True(t, cs.TimeoutSet)
+			}
+		})
+	}
+}
+
 func TestWTimeout(t *testing.T) {
 	tests := []struct {
 		s        string
@@ -41,6 +41,9 @@ var (
 const (
 	// maximum BSON object size when client side encryption is enabled
 	cryptMaxBsonObjectSize uint32 = 2097152
+	// extra space allowed beyond the maximum BSON object size for the command document and for each document in a
+	// batch that is split by message size, which wraps user documents with additional fields
+	messageSizeBatchOverhead uint32 = 16 * 1024
 	// minimum wire version necessary to use automatic encryption
 	cryptMinWireVersion int32 = 8
 	// minimum wire version necessary to use read snapshots
@@ -206,6 +209,22 @@ type Operation struct {
 	// read preference will not be added to the command on wire versions < 13.
 	IsOutputAggregate bool
 
+	// Timeout is the amount of time that the whole operation, including server selection, connection
+	// checkout, any retries, and the network round trips, may take. If the provided Context does not
+	// already have a deadline, one will be created using this value. While a Timeout is set, a
+	// maxTimeMS value derived from the remaining time is added to every command that doesn't
+	// already contain one. A nil or zero Timeout disables this behavior.
+	Timeout *time.Duration
+
+	// OmitMaxTimeMS prevents the maxTimeMS value derived from Timeout from being added to the
+	// command. This should be set for commands that the server does not allow maxTimeMS on, such as
+	// getMore for non-tailable cursors.
+	OmitMaxTimeMS bool
+
+	// maxTimeMS is only set while Timeout is in effect and is used internally when creating the
+	// wire message.
+	maxTimeMS int64
+
 	// cmdName is only set when serializing OP_MSG and is used internally in readWireMessage.
 	cmdName string
 }
@@ -305,6 +324,16 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 		}
 	}
 
+	// If a Timeout is set and the Context doesn't already have a deadline, create a Context that
+	// bounds server selection, connection checkout, and every round trip of this operation.
+	if op.timeoutEnabled() {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *op.Timeout)
+			defer cancel()
+		}
+	}
+
 	srvr, conn, err := op.getServerAndConnection(ctx)
 	if err != nil {
 		return err
@@ -367,6 +396,11 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				retries = -1
 			}
 		}
+
+		// When a Timeout is set, retry for as long as the deadline allows.
+		if op.timeoutEnabled() && retries != 0 {
+			retries = -1
+		}
 	}
 	batching := op.Batches.Valid()
 	retryEnabled := op.RetryMode != nil && op.RetryMode.Enabled()
@@ -381,6 +415,11 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				// 2MiB but max document size to 16MiB. This will allow the AdvanceBatch call to create a batch
 				// with a single large document.
 				targetBatchSize = cryptMaxBsonObjectSize
+			} else if op.Batches.SplitByMessageSize && desc.MaxMessageSize > desc.MaxDocumentSize {
+				// The batch is sent as a document sequence next to the command document, so it can use the space
+				// in the message that isn't reserved for the command document itself.
+				targetBatchSize = desc.MaxMessageSize - desc.MaxDocumentSize - messageSizeBatchOverhead
+				maxDocSize = desc.MaxDocumentSize + messageSizeBatchOverhead
 			}
 
 			err = op.Batches.AdvanceBatch(int(desc.MaxBatchCount), int(targetBatchSize), int(maxDocSize))
@@ -390,6 +429,12 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 			}
 		}
 
+		// calculate the maxTimeMS value to send based on the time remaining before the deadline
+		op.maxTimeMS, err = op.calculateMaxTimeMS(ctx, srvr.MinRTT())
+		if err != nil {
+			return err
+		}
+
 		// convert to wire message
 		if len(scratch) > 0 {
 			scratch = scratch[:0]
@@ -634,7 +679,8 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 				if *op.RetryMode > RetryNone {
 					op.Client.IncrementTxnNumber()
 				}
-				if *op.RetryMode == RetryOncePerCommand {
+				// When a Timeout is set, the remaining batches keep retrying for as long as the deadline allows.
+				if *op.RetryMode == RetryOncePerCommand && !op.timeoutEnabled() {
 					retries = 1
 				}
 			}
@@ -650,6 +696,53 @@ func (op Operation) Execute(ctx context.Context, scratch []byte) error {
 	return nil
 }
 
+// timeoutEnabled returns true if a non-zero Timeout has been set for this operation.
+func (op Operation) timeoutEnabled() bool {
+	return op.Timeout != nil && *op.Timeout > 0
+}
+
+// calculateMaxTimeMS returns the maxTimeMS value to send with the command, computed from the time
+// remaining before the Context deadline minus the minimum round-trip time to the server. It returns
+// 0 if no value should be sent and an error if the deadline would be exceeded before the server
+// could respond.
+func (op Operation) calculateMaxTimeMS(ctx context.Context, rtt time.Duration) (int64, error) {
+	if !op.timeoutEnabled() || op.OmitMaxTimeMS {
+		return 0, nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, nil
+	}
+
+	remaining := time.Until(deadline) - rtt
+	// Always round up so that a positive remaining time never results in maxTimeMS: 0, which
+	// the server interprets as no time limit.
+	maxTimeMS := int64((remaining + time.Millisecond - 1) / time.Millisecond)
+	if maxTimeMS <= 0 {
+		return 0, op.networkError(context.DeadlineExceeded)
+	}
+	return maxTimeMS, nil
+}
+
+// addMaxTimeMS appends the maxTimeMS value calculated from the operation's Timeout to dst unless
+// the command elements in cmd already contain a maxTimeMS field.
+func (op Operation) addMaxTimeMS(dst []byte, cmd []byte) []byte {
+	if op.maxTimeMS <= 0 {
+		return dst
+	}
+	for len(cmd) > 0 {
+		elem, rem, ok := bsoncore.ReadElement(cmd)
+		if !ok {
+			break
+		}
+		if elem.Key() == "maxTimeMS" {
+			return dst
+		}
+		cmd = rem
+	}
+	return bsoncore.AppendInt64Element(dst, "maxTimeMS", op.maxTimeMS)
+}
+
 // Retryable writes are supported if the server supports sessions, the operation is not
 // within a transaction, and the write is acknowledged
 func (op Operation) retryable(desc description.Server) bool {
@@ -857,6 +950,7 @@ func (op Operation) createQueryWireMessage(dst []byte, desc description.Selected
 	if err != nil {
 		return dst, info, err
 	}
+	dst = op.addMaxTimeMS(dst, dst[idx+4:])
 
 	if op.Batches != nil && len(op.Batches.Current) > 0 {
 		dst = op.addBatchArray(dst)
@@ -925,6 +1019,7 @@ func (op Operation) createMsgWireMessage(ctx context.Context, dst []byte, desc d
 	if err != nil {
 		return dst, info, err
 	}
+	dst = op.addMaxTimeMS(dst, dst[idx+4:])
 	dst, err = op.addReadConcern(dst, desc)
 	if err != nil {
 		return dst, info, err
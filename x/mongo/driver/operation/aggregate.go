@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -44,6 +45,7 @@ type Aggregate struct {
 	writeConcern             *writeconcern.WriteConcern
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
 	let                      bsoncore.Document
 	hasOutputStage           bool
 
@@ -87,6 +89,12 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		return errors.New("the Aggregate operation must have a Deployment set before Execute can be called")
 	}
 
+	return a.driverOperation().Execute(ctx, nil)
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (a *Aggregate) driverOperation() driver.Operation {
 	return driver.Operation{
 		CommandFn:         a.command,
 		ProcessResponseFn: a.processResponse,
@@ -105,9 +113,9 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Crypt:                          a.crypt,
 		MinimumWriteConcernWireVersion: 5,
 		ServerAPI:                      a.serverAPI,
+		Timeout:                        a.timeout,
 		IsOutputAggregate:              a.hasOutputStage,
-	}.Execute(ctx, nil)
-
+	}
 }
 
 func (a *Aggregate) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -373,6 +381,16 @@ func (a *Aggregate) ServerAPI(serverAPI *driver.ServerAPIOptions) *Aggregate {
 	return a
 }
 
+// Timeout sets the timeout for this operation.
+func (a *Aggregate) Timeout(timeout *time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.timeout = timeout
+	return a
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (a *Aggregate) Let(let bsoncore.Document) *Aggregate {
 	if a == nil {
@@ -0,0 +1,368 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// ClientBulkWrite performs a client-level bulkWrite operation, which can write to multiple namespaces in a single
+// command. Each operation document must reference its namespace by index into the namespaces passed to
+// NewClientBulkWrite.
+type ClientBulkWrite struct {
+	bypassDocumentValidation *bool
+	comment                  bsoncore.Value
+	errorsOnly               *bool
+	let                      bsoncore.Document
+	namespaces               []string
+	operations               []bsoncore.Document
+	ordered                  *bool
+	session                  *session.Client
+	clock                    *session.ClusterClock
+	monitor                  *event.CommandMonitor
+	crypt                    driver.Crypt
+	database                 string
+	deployment               driver.Deployment
+	selector                 description.ServerSelector
+	writeConcern             *writeconcern.WriteConcern
+	retry                    *driver.RetryMode
+	result                   ClientBulkWriteResult
+	cursors                  []ClientBulkWriteCursorResponse
+	batches                  *driver.Batches
+	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
+}
+
+// ClientBulkWriteResult represents a bulkWrite result returned by the server. The counts are summed across all of the
+// batches the operations were split into.
+type ClientBulkWriteResult struct {
+	// Number of operations that resulted in an error.
+	NErrors int64
+	// Number of documents inserted.
+	NInserted int64
+	// Number of documents matched by update and replace operations.
+	NMatched int64
+	// Number of documents modified by update and replace operations.
+	NModified int64
+	// Number of documents upserted by update and replace operations.
+	NUpserted int64
+	// Number of documents deleted.
+	NDeleted int64
+}
+
+// ClientBulkWriteCursorResponse is the cursor over the individual operation results returned for one batch of a
+// bulkWrite command.
+type ClientBulkWriteCursorResponse struct {
+	// The index of the first operation of the batch within all of the operations of the ClientBulkWrite. The "idx"
+	// field of each result document is relative to the batch, so Offset must be added to it to find the operation the
+	// result refers to.
+	Offset int
+	// The cursor response that can be used to create a BatchCursor over the result documents.
+	Response driver.CursorResponse
+}
+
+func buildClientBulkWriteResult(response bsoncore.Document) (ClientBulkWriteResult, error) {
+	elements, err := response.Elements()
+	if err != nil {
+		return ClientBulkWriteResult{}, err
+	}
+	cbwr := ClientBulkWriteResult{}
+	for _, element := range elements {
+		var dst *int64
+		switch element.Key() {
+		case "nErrors":
+			dst = &cbwr.NErrors
+		case "nInserted":
+			dst = &cbwr.NInserted
+		case "nMatched":
+			dst = &cbwr.NMatched
+		case "nModified":
+			dst = &cbwr.NModified
+		case "nUpserted":
+			dst = &cbwr.NUpserted
+		case "nDeleted":
+			dst = &cbwr.NDeleted
+		default:
+			continue
+		}
+		var ok bool
+		*dst, ok = element.Value().AsInt64OK()
+		if !ok {
+			return cbwr, fmt.Errorf("response field '%s' is type int64, but received BSON type %s",
+				element.Key(), element.Value().Type)
+		}
+	}
+	return cbwr, nil
+}
+
+// NewClientBulkWrite constructs and returns a new ClientBulkWrite. The namespaces parameter contains the full
+// "database.collection" namespaces that the operation documents refer to by index.
+func NewClientBulkWrite(namespaces []string, operations ...bsoncore.Document) *ClientBulkWrite {
+	return &ClientBulkWrite{
+		namespaces: namespaces,
+		operations: operations,
+		database:   "admin",
+	}
+}
+
+// Result returns the result of executing this operation.
+func (bw *ClientBulkWrite) Result() ClientBulkWriteResult { return bw.result }
+
+// ResultCursorResponses returns the cursor responses for the individual operation results of each batch that was
+// executed, in the order that the batches were sent.
+func (bw *ClientBulkWrite) ResultCursorResponses() []ClientBulkWriteCursorResponse { return bw.cursors }
+
+func (bw *ClientBulkWrite) processResponse(info driver.ResponseInfo) error {
+	res, err := buildClientBulkWriteResult(info.ServerResponse)
+	bw.result.NErrors += res.NErrors
+	bw.result.NInserted += res.NInserted
+	bw.result.NMatched += res.NMatched
+	bw.result.NModified += res.NModified
+	bw.result.NUpserted += res.NUpserted
+	bw.result.NDeleted += res.NDeleted
+	if err != nil {
+		return err
+	}
+
+	// The server stops executing an ordered bulkWrite after the first error, so any remaining batches must not be
+	// sent either.
+	if res.NErrors > 0 && (bw.ordered == nil || *bw.ordered) && bw.batches != nil {
+		bw.batches.Documents = nil
+	}
+
+	cr, err := driver.NewCursorResponse(info)
+	if err != nil {
+		return err
+	}
+	bw.cursors = append(bw.cursors, ClientBulkWriteCursorResponse{Offset: info.CurrentIndex, Response: cr})
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operaiton did not execute successfully.
+func (bw *ClientBulkWrite) Execute(ctx context.Context) error {
+	if bw.deployment == nil {
+		return errors.New("the ClientBulkWrite operation must have a Deployment set before Execute can be called")
+	}
+	bw.batches = &driver.Batches{
+		Identifier:         "ops",
+		Documents:          bw.operations,
+		Ordered:            bw.ordered,
+		SplitByMessageSize: true,
+	}
+
+	return driver.Operation{
+		CommandFn:         bw.command,
+		ProcessResponseFn: bw.processResponse,
+		Batches:           bw.batches,
+		RetryMode:         bw.retry,
+		Type:              driver.Write,
+		Client:            bw.session,
+		Clock:             bw.clock,
+		CommandMonitor:    bw.monitor,
+		Crypt:             bw.crypt,
+		Database:          bw.database,
+		Deployment:        bw.deployment,
+		Selector:          bw.selector,
+		WriteConcern:      bw.writeConcern,
+		ServerAPI:         bw.serverAPI,
+		Timeout:           bw.timeout,
+	}.Execute(ctx, nil)
+
+}
+
+func (bw *ClientBulkWrite) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst = bsoncore.AppendInt32Element(dst, "bulkWrite", 1)
+	if bw.errorsOnly != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "errorsOnly", *bw.errorsOnly)
+	}
+	if bw.ordered != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "ordered", *bw.ordered)
+	}
+	if bw.bypassDocumentValidation != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "bypassDocumentValidation", *bw.bypassDocumentValidation)
+	}
+	if bw.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", bw.comment)
+	}
+	if bw.let != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "let", bw.let)
+	}
+
+	// Every batch includes all of the namespaces so the indexes in the operation documents stay valid regardless of
+	// where the operations are split.
+	aidx, dst := bsoncore.AppendArrayElementStart(dst, "nsInfo")
+	for i, ns := range bw.namespaces {
+		dst = bsoncore.BuildDocumentElement(dst, fmt.Sprintf("%d", i), bsoncore.AppendStringElement(nil, "ns", ns))
+	}
+	dst, _ = bsoncore.AppendArrayEnd(dst, aidx)
+	return dst, nil
+}
+
+// BypassDocumentValidation allows the operation to opt-out of document level validation.
+func (bw *ClientBulkWrite) BypassDocumentValidation(bypassDocumentValidation bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.bypassDocumentValidation = &bypassDocumentValidation
+	return bw
+}
+
+// Comment sets a value to help trace an operation.
+func (bw *ClientBulkWrite) Comment(comment bsoncore.Value) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.comment = comment
+	return bw
+}
+
+// ErrorsOnly specifies whether the server should only return results for operations that failed. If false, a result is
+// returned for every operation.
+func (bw *ClientBulkWrite) ErrorsOnly(errorsOnly bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.errorsOnly = &errorsOnly
+	return bw
+}
+
+// Let specifies the let document to use. The variables in it can be accessed by the filters and updates of every
+// operation.
+func (bw *ClientBulkWrite) Let(let bsoncore.Document) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.let = let
+	return bw
+}
+
+// Ordered sets ordered. If true, when a write fails, the operation will return the error, when
+// false write failures do not stop execution of the operation.
+func (bw *ClientBulkWrite) Ordered(ordered bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.ordered = &ordered
+	return bw
+}
+
+// Session sets the session for this operation.
+func (bw *ClientBulkWrite) Session(session *session.Client) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.session = session
+	return bw
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (bw *ClientBulkWrite) ClusterClock(clock *session.ClusterClock) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.clock = clock
+	return bw
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (bw *ClientBulkWrite) CommandMonitor(monitor *event.CommandMonitor) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.monitor = monitor
+	return bw
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (bw *ClientBulkWrite) Crypt(crypt driver.Crypt) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.crypt = crypt
+	return bw
+}
+
+// Deployment sets the deployment to use for this operation.
+func (bw *ClientBulkWrite) Deployment(deployment driver.Deployment) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.deployment = deployment
+	return bw
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (bw *ClientBulkWrite) ServerSelector(selector description.ServerSelector) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.selector = selector
+	return bw
+}
+
+// WriteConcern sets the write concern for this operation.
+func (bw *ClientBulkWrite) WriteConcern(writeConcern *writeconcern.WriteConcern) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.writeConcern = writeConcern
+	return bw
+}
+
+// Retry enables retryable mode for this operation. Retries are handled automatically in driver.Operation.Execute based
+// on how the operation is set.
+func (bw *ClientBulkWrite) Retry(retry driver.RetryMode) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.retry = &retry
+	return bw
+}
+
+// ServerAPI sets the server API version for this operation.
+func (bw *ClientBulkWrite) ServerAPI(serverAPI *driver.ServerAPIOptions) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.serverAPI = serverAPI
+	return bw
+}
+
+// Timeout sets the timeout for this operation.
+func (bw *ClientBulkWrite) Timeout(timeout *time.Duration) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.timeout = timeout
+	return bw
+}
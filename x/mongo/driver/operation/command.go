@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -34,6 +35,7 @@ type Command struct {
 	resultCursor   *driver.BatchCursor
 	crypt          driver.Crypt
 	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
 	createCursor   bool
 	cursorOpts     driver.CursorOptions
 }
@@ -103,6 +105,7 @@ func (c *Command) Execute(ctx context.Context) error {
 		Selector:       c.selector,
 		Crypt:          c.crypt,
 		ServerAPI:      c.serverAPI,
+		Timeout:        c.timeout,
 	}.Execute(ctx, nil)
 }
 
@@ -205,3 +208,13 @@ func (c *Command) ServerAPI(serverAPI *driver.ServerAPIOptions) *Command {
 	c.serverAPI = serverAPI
 	return c
 }
+
+// Timeout sets the timeout for this operation.
+func (c *Command) Timeout(timeout *time.Duration) *Command {
+	if c == nil {
+		c = new(Command)
+	}
+
+	c.timeout = timeout
+	return c
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -37,6 +38,7 @@ type Count struct {
 	retry          *driver.RetryMode
 	result         CountResult
 	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
 }
 
 // CountResult represents a count result returned by the server.
@@ -105,7 +107,22 @@ func (c *Count) Execute(ctx context.Context) error {
 		return errors.New("the Count operation must have a Deployment set before Execute can be called")
 	}
 
-	err := driver.Operation{
+	err := c.driverOperation().Execute(ctx, nil)
+
+	// Swallow error if NamespaceNotFound(26) is returned from aggregate on non-existent namespace
+	if err != nil {
+		dErr, ok := err.(driver.Error)
+		if ok && dErr.Code == 26 {
+			err = nil
+		}
+	}
+	return err
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (c *Count) driverOperation() driver.Operation {
+	return driver.Operation{
 		CommandFn:         c.command,
 		ProcessResponseFn: c.processResponse,
 		RetryMode:         c.retry,
@@ -120,16 +137,8 @@ func (c *Count) Execute(ctx context.Context) error {
 		ReadPreference:    c.readPreference,
 		Selector:          c.selector,
 		ServerAPI:         c.serverAPI,
-	}.Execute(ctx, nil)
-
-	// Swallow error if NamespaceNotFound(26) is returned from aggregate on non-existent namespace
-	if err != nil {
-		dErr, ok := err.(driver.Error)
-		if ok && dErr.Code == 26 {
-			err = nil
-		}
+		Timeout:           c.timeout,
 	}
-	return err
 }
 
 func (c *Count) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -313,3 +322,13 @@ func (c *Count) ServerAPI(serverAPI *driver.ServerAPIOptions) *Count {
 	c.serverAPI = serverAPI
 	return c
 }
+
+// Timeout sets the timeout for this operation.
+func (c *Count) Timeout(timeout *time.Duration) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.timeout = timeout
+	return c
+}
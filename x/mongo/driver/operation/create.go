@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -41,8 +42,13 @@ type Create struct {
 	selector            description.ServerSelector
 	writeConcern        *writeconcern.WriteConcern
 	serverAPI           *driver.ServerAPIOptions
+	timeout             *time.Duration
 	expireAfterSeconds  *int64
 	timeSeries          bsoncore.Document
+
+	clusteredIndex               bsoncore.Document
+	changeStreamPreAndPostImages bsoncore.Document
+	encryptedFields              bsoncore.Document
 }
 
 // NewCreate constructs and returns a new Create.
@@ -74,6 +80,7 @@ func (c *Create) Execute(ctx context.Context) error {
 		Selector:          c.selector,
 		WriteConcern:      c.writeConcern,
 		ServerAPI:         c.serverAPI,
+		Timeout:           c.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -124,6 +131,15 @@ func (c *Create) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	if c.timeSeries != nil {
 		dst = bsoncore.AppendDocumentElement(dst, "timeseries", c.timeSeries)
 	}
+	if c.clusteredIndex != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "clusteredIndex", c.clusteredIndex)
+	}
+	if c.changeStreamPreAndPostImages != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "changeStreamPreAndPostImages", c.changeStreamPreAndPostImages)
+	}
+	if c.encryptedFields != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "encryptedFields", c.encryptedFields)
+	}
 	return dst, nil
 }
 
@@ -338,6 +354,16 @@ func (c *Create) ServerAPI(serverAPI *driver.ServerAPIOptions) *Create {
 	return c
 }
 
+// Timeout sets the timeout for this operation.
+func (c *Create) Timeout(timeout *time.Duration) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.timeout = timeout
+	return c
+}
+
 // ExpireAfterSeconds sets the seconds to wait before deleting old time-series data.
 func (c *Create) ExpireAfterSeconds(eas int64) *Create {
 	if c == nil {
@@ -357,3 +383,36 @@ func (c *Create) TimeSeries(timeSeries bsoncore.Document) *Create {
 	c.timeSeries = timeSeries
 	return c
 }
+
+// ClusteredIndex sets the clustered index specification for the collection. This option is only valid for server
+// versions 5.3 and above.
+func (c *Create) ClusteredIndex(clusteredIndex bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.clusteredIndex = clusteredIndex
+	return c
+}
+
+// ChangeStreamPreAndPostImages sets the document that specifies whether change streams opened against the collection
+// can include pre- and post-images. This option is only valid for server versions 6.0 and above.
+func (c *Create) ChangeStreamPreAndPostImages(changeStreamPreAndPostImages bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.changeStreamPreAndPostImages = changeStreamPreAndPostImages
+	return c
+}
+
+// EncryptedFields sets the document describing the encrypted fields of a collection used with Queryable Encryption.
+// This option is only valid for server versions 6.0 and above.
+func (c *Create) EncryptedFields(encryptedFields bsoncore.Document) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.encryptedFields = encryptedFields
+	return c
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -36,6 +37,7 @@ type Delete struct {
 	hint         *bool
 	result       DeleteResult
 	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 }
 
 // DeleteResult represents a delete result returned by the server.
@@ -84,6 +86,13 @@ func (d *Delete) Execute(ctx context.Context) error {
 	if d.deployment == nil {
 		return errors.New("the Delete operation must have a Deployment set before Execute can be called")
 	}
+
+	return d.driverOperation().Execute(ctx, nil)
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (d *Delete) driverOperation() driver.Operation {
 	batches := &driver.Batches{
 		Identifier: "deletes",
 		Documents:  d.deletes,
@@ -105,8 +114,8 @@ func (d *Delete) Execute(ctx context.Context) error {
 		Selector:          d.selector,
 		WriteConcern:      d.writeConcern,
 		ServerAPI:         d.serverAPI,
-	}.Execute(ctx, nil)
-
+		Timeout:           d.timeout,
+	}
 }
 
 func (d *Delete) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -270,3 +279,13 @@ func (d *Delete) ServerAPI(serverAPI *driver.ServerAPIOptions) *Delete {
 	d.serverAPI = serverAPI
 	return d
 }
+
+// Timeout sets the timeout for this operation.
+func (d *Delete) Timeout(timeout *time.Duration) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.timeout = timeout
+	return d
+}
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -38,6 +39,7 @@ type Distinct struct {
 	retry          *driver.RetryMode
 	result         DistinctResult
 	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
 }
 
 // DistinctResult represents a distinct result returned by the server.
@@ -84,6 +86,12 @@ func (d *Distinct) Execute(ctx context.Context) error {
 		return errors.New("the Distinct operation must have a Deployment set before Execute can be called")
 	}
 
+	return d.driverOperation().Execute(ctx, nil)
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (d *Distinct) driverOperation() driver.Operation {
 	return driver.Operation{
 		CommandFn:         d.command,
 		ProcessResponseFn: d.processResponse,
@@ -99,8 +107,8 @@ func (d *Distinct) Execute(ctx context.Context) error {
 		ReadPreference:    d.readPreference,
 		Selector:          d.selector,
 		ServerAPI:         d.serverAPI,
-	}.Execute(ctx, nil)
-
+		Timeout:           d.timeout,
+	}
 }
 
 func (d *Distinct) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -283,3 +291,13 @@ func (d *Distinct) ServerAPI(serverAPI *driver.ServerAPIOptions) *Distinct {
 	d.serverAPI = serverAPI
 	return d
 }
+
+// Timeout sets the timeout for this operation.
+func (d *Distinct) Timeout(timeout *time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.timeout = timeout
+	return d
+}
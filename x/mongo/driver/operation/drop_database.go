@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -29,6 +30,7 @@ type DropDatabase struct {
 	selector     description.ServerSelector
 	writeConcern *writeconcern.WriteConcern
 	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 }
 
 // NewDropDatabase constructs and returns a new DropDatabase.
@@ -53,6 +55,7 @@ func (dd *DropDatabase) Execute(ctx context.Context) error {
 		Selector:       dd.selector,
 		WriteConcern:   dd.writeConcern,
 		ServerAPI:      dd.serverAPI,
+		Timeout:        dd.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -152,3 +155,13 @@ func (dd *DropDatabase) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropDatab
 	dd.serverAPI = serverAPI
 	return dd
 }
+
+// Timeout sets the timeout for this operation.
+func (dd *DropDatabase) Timeout(timeout *time.Duration) *DropDatabase {
+	if dd == nil {
+		dd = new(DropDatabase)
+	}
+
+	dd.timeout = timeout
+	return dd
+}
@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+)
+
+// Explainable is implemented by operations that can be wrapped in an explain command. The Aggregate, Count, Delete,
+// Distinct, Find, and Update operations implement this interface.
+type Explainable interface {
+	driverOperation() driver.Operation
+}
+
+// Explain runs the explain command (https://docs.mongodb.com/manual/reference/command/explain/) for another operation.
+// The explained command is built by the wrapped operation, so it is identical to the command that operation would send
+// when executed directly.
+type Explain struct {
+	explainable Explainable
+	verbosity   string
+	result      bsoncore.Document
+}
+
+// NewExplain constructs and returns a new Explain that explains op with the given verbosity. The session, deployment,
+// server selector, and other settings are taken from op.
+func NewExplain(verbosity string, op Explainable) *Explain {
+	return &Explain{
+		explainable: op,
+		verbosity:   verbosity,
+	}
+}
+
+// Result returns the server's explain output.
+func (e *Explain) Result() bsoncore.Document { return e.result }
+
+// Execute runs this operations and returns an error if the operaiton did not execute successfully.
+func (e *Explain) Execute(ctx context.Context) error {
+	if e.explainable == nil {
+		return errors.New("the Explain operation must have an operation to explain set before Execute can be called")
+	}
+
+	op := e.explainable.driverOperation()
+	if op.Deployment == nil {
+		return errors.New("the Explain operation must have a Deployment set before Execute can be called")
+	}
+
+	commandFn, batches := op.CommandFn, op.Batches
+	op.CommandFn = func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+		return e.command(dst, desc, commandFn, batches)
+	}
+	op.ProcessResponseFn = e.processResponse
+	// The explain command never writes, and the server rejects write concerns and read concerns on it. Any batch is
+	// embedded as an array in the explained command rather than sent as a document sequence.
+	op.Type = driver.Read
+	op.Batches = nil
+	op.WriteConcern = nil
+	op.ReadConcern = nil
+	op.Legacy = driver.LegacyNone
+	op.IsOutputAggregate = false
+
+	return op.Execute(ctx, nil)
+}
+
+func (e *Explain) command(dst []byte, desc description.SelectedServer,
+	commandFn func([]byte, description.SelectedServer) ([]byte, error), batches *driver.Batches) ([]byte, error) {
+
+	dst = bsoncore.AppendHeader(dst, bsontype.EmbeddedDocument, "explain")
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	dst, err := commandFn(dst, desc)
+	if err != nil {
+		return dst, err
+	}
+	if batches != nil && len(batches.Documents) > 0 {
+		var aidx int32
+		aidx, dst = bsoncore.AppendArrayElementStart(dst, batches.Identifier)
+		for i, doc := range batches.Documents {
+			dst = bsoncore.AppendDocumentElement(dst, strconv.Itoa(i), doc)
+		}
+		dst, err = bsoncore.AppendArrayEnd(dst, aidx)
+		if err != nil {
+			return dst, err
+		}
+	}
+	dst, err = bsoncore.AppendDocumentEnd(dst, idx)
+	if err != nil {
+		return dst, err
+	}
+
+	if e.verbosity != "" {
+		dst = bsoncore.AppendStringElement(dst, "verbosity", e.verbosity)
+	}
+	return dst, nil
+}
+
+func (e *Explain) processResponse(info driver.ResponseInfo) error {
+	e.result = info.ServerResponse
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/internal/testutil/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+)
+
+func TestExplain(t *testing.T) {
+	reply := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "queryPlanner", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "namespace", "db.coll"),
+		)),
+		bsoncore.AppendInt32Element(nil, "ok", 1),
+	))
+	newConn := func() *drivertest.ChannelConn {
+		conn := &drivertest.ChannelConn{
+			Written:  make(chan []byte, 1),
+			ReadResp: make(chan []byte, 1),
+			Desc: description.Server{
+				Kind:        description.Standalone,
+				WireVersion: &description.VersionRange{Max: 13},
+			},
+		}
+		conn.ReadResp <- drivertest.MakeReply(reply)
+		return conn
+	}
+	filter := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", 1)))
+
+	t.Run("find", func(t *testing.T) {
+		conn := newConn()
+		find := NewFind(filter).Database("db").Collection("coll").Limit(5).
+			Deployment(driver.SingleConnectionDeployment{C: conn})
+
+		op := NewExplain("executionStats", find)
+		err := op.Execute(context.Background())
+		assert.Nil(t, err, "Execute error: %v", err)
+		assert.Equal(t, reply, op.Result(), "expected result %v, got %v", reply, op.Result())
+
+		cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+		assert.Nil(t, err, "error reading command: %v", err)
+		elems, err := cmd.Elements()
+		assert.Nil(t, err, "Elements error: %v", err)
+		assert.Equal(t, "explain", elems[0].Key(), "expected command name explain, got %v", elems[0].Key())
+
+		explained := cmd.Lookup("explain").Document()
+		assert.Equal(t, "coll", explained.Lookup("find").StringValue(), "expected find on coll, got %v", explained)
+		assert.Equal(t, filter, explained.Lookup("filter").Document(), "expected filter %v, got %v", filter,
+			explained.Lookup("filter"))
+		assert.Equal(t, int64(5), explained.Lookup("limit").Int64(), "expected limit 5, got %v",
+			explained.Lookup("limit"))
+		verbosity := cmd.Lookup("verbosity").StringValue()
+		assert.Equal(t, "executionStats", verbosity, "expected verbosity executionStats, got %v", verbosity)
+	})
+	t.Run("update", func(t *testing.T) {
+		conn := newConn()
+		updateDoc := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "q", filter),
+			bsoncore.AppendDocumentElement(nil, "u", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "$set", filter),
+			)),
+		))
+		update := NewUpdate(updateDoc).Database("db").Collection("coll").Ordered(true).
+			WriteConcern(writeconcern.New(writeconcern.WMajority())).
+			Deployment(driver.SingleConnectionDeployment{C: conn})
+
+		err := NewExplain("queryPlanner", update).Execute(context.Background())
+		assert.Nil(t, err, "Execute error: %v", err)
+
+		cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+		assert.Nil(t, err, "error reading command: %v", err)
+		_, err = cmd.LookupErr("writeConcern")
+		assert.NotNil(t, err, "expected no writeConcern in explain command %v", cmd)
+
+		explained := cmd.Lookup("explain").Document()
+		assert.Equal(t, "coll", explained.Lookup("update").StringValue(), "expected update on coll, got %v", explained)
+		updates, err := explained.Lookup("updates").Array().Values()
+		assert.Nil(t, err, "error reading updates array: %v", err)
+		assert.Equal(t, 1, len(updates), "expected 1 update, got %v", len(updates))
+		assert.Equal(t, updateDoc, updates[0].Document(), "expected update %v, got %v", updateDoc, updates[0])
+	})
+}
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/event"
@@ -57,6 +58,7 @@ type Find struct {
 	retry               *driver.RetryMode
 	result              driver.CursorResponse
 	serverAPI           *driver.ServerAPIOptions
+	timeout             *time.Duration
 }
 
 // NewFind constructs and returns a new Find.
@@ -84,6 +86,12 @@ func (f *Find) Execute(ctx context.Context) error {
 		return errors.New("the Find operation must have a Deployment set before Execute can be called")
 	}
 
+	return f.driverOperation().Execute(ctx, nil)
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (f *Find) driverOperation() driver.Operation {
 	return driver.Operation{
 		CommandFn:         f.command,
 		ProcessResponseFn: f.processResponse,
@@ -100,8 +108,8 @@ func (f *Find) Execute(ctx context.Context) error {
 		Selector:          f.selector,
 		Legacy:            driver.LegacyFind,
 		ServerAPI:         f.serverAPI,
-	}.Execute(ctx, nil)
-
+		Timeout:           f.timeout,
+	}
 }
 
 func (f *Find) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -521,3 +529,13 @@ func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
 	f.serverAPI = serverAPI
 	return f
 }
+
+// Timeout sets the timeout for this operation.
+func (f *Find) Timeout(timeout *time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.timeout = timeout
+	return f
+}
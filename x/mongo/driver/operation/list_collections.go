@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -34,6 +35,7 @@ type ListCollections struct {
 	result         driver.CursorResponse
 	batchSize      *int32
 	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
 }
 
 // NewListCollections constructs and returns a new ListCollections.
@@ -84,6 +86,7 @@ func (lc *ListCollections) Execute(ctx context.Context) error {
 		Selector:          lc.selector,
 		Legacy:            driver.LegacyListCollections,
 		ServerAPI:         lc.serverAPI,
+		Timeout:           lc.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -236,3 +239,13 @@ func (lc *ListCollections) ServerAPI(serverAPI *driver.ServerAPIOptions) *ListCo
 	lc.serverAPI = serverAPI
 	return lc
 }
+
+// Timeout sets the timeout for this operation.
+func (lc *ListCollections) Timeout(timeout *time.Duration) *ListCollections {
+	if lc == nil {
+		lc = new(ListCollections)
+	}
+
+	lc.timeout = timeout
+	return lc
+}
@@ -9,6 +9,7 @@ package operation
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -31,6 +32,7 @@ type ListIndexes struct {
 	retry      *driver.RetryMode
 	crypt      driver.Crypt
 	serverAPI  *driver.ServerAPIOptions
+	timeout    *time.Duration
 
 	result driver.CursorResponse
 }
@@ -79,6 +81,7 @@ func (li *ListIndexes) Execute(ctx context.Context) error {
 		RetryMode:      li.retry,
 		Type:           driver.Read,
 		ServerAPI:      li.serverAPI,
+		Timeout:        li.timeout,
 	}.Execute(ctx, nil)
 
 }
@@ -221,3 +224,13 @@ func (li *ListIndexes) ServerAPI(serverAPI *driver.ServerAPIOptions) *ListIndexe
 	li.serverAPI = serverAPI
 	return li
 }
+
+// Timeout sets the timeout for this operation.
+func (li *ListIndexes) Timeout(timeout *time.Duration) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.timeout = timeout
+	return li
+}
@@ -0,0 +1,259 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// ModifyCollection performs a collMod operation.
+type ModifyCollection struct {
+	collectionName     string
+	validator          bsoncore.Document
+	validationLevel    *string
+	validationAction   *string
+	expireAfterSeconds *int64
+	index              bsoncore.Document
+	timeSeries         bsoncore.Document
+	session            *session.Client
+	clock              *session.ClusterClock
+	monitor            *event.CommandMonitor
+	crypt              driver.Crypt
+	database           string
+	deployment         driver.Deployment
+	selector           description.ServerSelector
+	writeConcern       *writeconcern.WriteConcern
+	serverAPI          *driver.ServerAPIOptions
+	timeout            *time.Duration
+}
+
+// NewModifyCollection constructs and returns a new ModifyCollection.
+func NewModifyCollection(collectionName string) *ModifyCollection {
+	return &ModifyCollection{
+		collectionName: collectionName,
+	}
+}
+
+func (mc *ModifyCollection) processResponse(driver.ResponseInfo) error {
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operaiton did not execute successfully.
+func (mc *ModifyCollection) Execute(ctx context.Context) error {
+	if mc.deployment == nil {
+		return errors.New("the ModifyCollection operation must have a Deployment set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         mc.command,
+		ProcessResponseFn: mc.processResponse,
+		Client:            mc.session,
+		Clock:             mc.clock,
+		CommandMonitor:    mc.monitor,
+		Crypt:             mc.crypt,
+		Database:          mc.database,
+		Deployment:        mc.deployment,
+		Selector:          mc.selector,
+		WriteConcern:      mc.writeConcern,
+		ServerAPI:         mc.serverAPI,
+		Timeout:           mc.timeout,
+	}.Execute(ctx, nil)
+}
+
+func (mc *ModifyCollection) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst = bsoncore.AppendStringElement(dst, "collMod", mc.collectionName)
+	if mc.validator != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "validator", mc.validator)
+	}
+	if mc.validationLevel != nil {
+		dst = bsoncore.AppendStringElement(dst, "validationLevel", *mc.validationLevel)
+	}
+	if mc.validationAction != nil {
+		dst = bsoncore.AppendStringElement(dst, "validationAction", *mc.validationAction)
+	}
+	if mc.expireAfterSeconds != nil {
+		dst = bsoncore.AppendInt64Element(dst, "expireAfterSeconds", *mc.expireAfterSeconds)
+	}
+	if mc.index != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "index", mc.index)
+	}
+	if mc.timeSeries != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "timeseries", mc.timeSeries)
+	}
+	return dst, nil
+}
+
+// Validator sets the validation rules for the collection.
+func (mc *ModifyCollection) Validator(validator bsoncore.Document) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.validator = validator
+	return mc
+}
+
+// ValidationLevel sets how strictly the validation rules are applied to existing documents.
+func (mc *ModifyCollection) ValidationLevel(validationLevel string) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.validationLevel = &validationLevel
+	return mc
+}
+
+// ValidationAction sets whether invalid documents are rejected or only logged.
+func (mc *ModifyCollection) ValidationAction(validationAction string) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.validationAction = &validationAction
+	return mc
+}
+
+// ExpireAfterSeconds sets the number of seconds after which documents in a time-series or clustered collection
+// are deleted.
+func (mc *ModifyCollection) ExpireAfterSeconds(expireAfterSeconds int64) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.expireAfterSeconds = &expireAfterSeconds
+	return mc
+}
+
+// Index sets the index modification document. It must identify the index by either keyPattern or name and contain
+// the index properties to change.
+func (mc *ModifyCollection) Index(index bsoncore.Document) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.index = index
+	return mc
+}
+
+// TimeSeries sets the time-series options to change, such as the granularity.
+func (mc *ModifyCollection) TimeSeries(timeSeries bsoncore.Document) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.timeSeries = timeSeries
+	return mc
+}
+
+// Session sets the session for this operation.
+func (mc *ModifyCollection) Session(session *session.Client) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.session = session
+	return mc
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (mc *ModifyCollection) ClusterClock(clock *session.ClusterClock) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.clock = clock
+	return mc
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (mc *ModifyCollection) CommandMonitor(monitor *event.CommandMonitor) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.monitor = monitor
+	return mc
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (mc *ModifyCollection) Crypt(crypt driver.Crypt) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.crypt = crypt
+	return mc
+}
+
+// Database sets the database to run this operation against.
+func (mc *ModifyCollection) Database(database string) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.database = database
+	return mc
+}
+
+// Deployment sets the deployment to use for this operation.
+func (mc *ModifyCollection) Deployment(deployment driver.Deployment) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.deployment = deployment
+	return mc
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (mc *ModifyCollection) ServerSelector(selector description.ServerSelector) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.selector = selector
+	return mc
+}
+
+// WriteConcern sets the write concern for this operation.
+func (mc *ModifyCollection) WriteConcern(writeConcern *writeconcern.WriteConcern) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.writeConcern = writeConcern
+	return mc
+}
+
+// ServerAPI sets the server API version for this operation.
+func (mc *ModifyCollection) ServerAPI(serverAPI *driver.ServerAPIOptions) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.serverAPI = serverAPI
+	return mc
+}
+
+// Timeout sets the timeout for this operation.
+func (mc *ModifyCollection) Timeout(timeout *time.Duration) *ModifyCollection {
+	if mc == nil {
+		mc = new(ModifyCollection)
+	}
+
+	mc.timeout = timeout
+	return mc
+}
@@ -0,0 +1,181 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+)
+
+// RenameCollection performs a renameCollection operation.
+type RenameCollection struct {
+	from         string
+	to           string
+	dropTarget   *bool
+	session      *session.Client
+	clock        *session.ClusterClock
+	monitor      *event.CommandMonitor
+	crypt        driver.Crypt
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	writeConcern *writeconcern.WriteConcern
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
+}
+
+// NewRenameCollection constructs and returns a new RenameCollection. The from and to parameters must be full
+// namespaces in the form "databaseName.collectionName".
+func NewRenameCollection(from, to string) *RenameCollection {
+	return &RenameCollection{
+		from: from,
+		to:   to,
+	}
+}
+
+func (rc *RenameCollection) processResponse(driver.ResponseInfo) error {
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operaiton did not execute successfully.
+func (rc *RenameCollection) Execute(ctx context.Context) error {
+	if rc.deployment == nil {
+		return errors.New("the RenameCollection operation must have a Deployment set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         rc.command,
+		ProcessResponseFn: rc.processResponse,
+		Client:            rc.session,
+		Clock:             rc.clock,
+		CommandMonitor:    rc.monitor,
+		Crypt:             rc.crypt,
+		Database:          "admin",
+		Deployment:        rc.deployment,
+		Selector:          rc.selector,
+		WriteConcern:      rc.writeConcern,
+		ServerAPI:         rc.serverAPI,
+		Timeout:           rc.timeout,
+	}.Execute(ctx, nil)
+}
+
+func (rc *RenameCollection) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst = bsoncore.AppendStringElement(dst, "renameCollection", rc.from)
+	dst = bsoncore.AppendStringElement(dst, "to", rc.to)
+	if rc.dropTarget != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "dropTarget", *rc.dropTarget)
+	}
+	return dst, nil
+}
+
+// DropTarget specifies whether an existing collection with the target name should be dropped before the rename.
+// If false or unset, the rename fails if the target collection exists.
+func (rc *RenameCollection) DropTarget(dropTarget bool) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.dropTarget = &dropTarget
+	return rc
+}
+
+// Session sets the session for this operation.
+func (rc *RenameCollection) Session(session *session.Client) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.session = session
+	return rc
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (rc *RenameCollection) ClusterClock(clock *session.ClusterClock) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.clock = clock
+	return rc
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (rc *RenameCollection) CommandMonitor(monitor *event.CommandMonitor) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.monitor = monitor
+	return rc
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (rc *RenameCollection) Crypt(crypt driver.Crypt) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.crypt = crypt
+	return rc
+}
+
+// Deployment sets the deployment to use for this operation.
+func (rc *RenameCollection) Deployment(deployment driver.Deployment) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.deployment = deployment
+	return rc
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (rc *RenameCollection) ServerSelector(selector description.ServerSelector) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.selector = selector
+	return rc
+}
+
+// WriteConcern sets the write concern for this operation.
+func (rc *RenameCollection) WriteConcern(writeConcern *writeconcern.WriteConcern) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.writeConcern = writeConcern
+	return rc
+}
+
+// ServerAPI sets the server API version for this operation.
+func (rc *RenameCollection) ServerAPI(serverAPI *driver.ServerAPIOptions) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.serverAPI = serverAPI
+	return rc
+}
+
+// Timeout sets the timeout for this operation.
+func (rc *RenameCollection) Timeout(timeout *time.Duration) *RenameCollection {
+	if rc == nil {
+		rc = new(RenameCollection)
+	}
+
+	rc.timeout = timeout
+	return rc
+}
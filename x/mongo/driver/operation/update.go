@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/event"
@@ -39,6 +40,7 @@ type Update struct {
 	result                   UpdateResult
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
 }
 
 // Upsert contains the information for an upsert in an Update operation.
@@ -135,6 +137,13 @@ func (u *Update) Execute(ctx context.Context) error {
 	if u.deployment == nil {
 		return errors.New("the Update operation must have a Deployment set before Execute can be called")
 	}
+
+	return u.driverOperation().Execute(ctx, nil)
+}
+
+// driverOperation returns the driver.Operation used to run this operation. Explain uses it to build an explain command
+// that wraps the exact command this operation would send.
+func (u *Update) driverOperation() driver.Operation {
 	batches := &driver.Batches{
 		Identifier: "updates",
 		Documents:  u.updates,
@@ -156,8 +165,8 @@ func (u *Update) Execute(ctx context.Context) error {
 		WriteConcern:      u.writeConcern,
 		Crypt:             u.crypt,
 		ServerAPI:         u.serverAPI,
-	}.Execute(ctx, nil)
-
+		Timeout:           u.timeout,
+	}
 }
 
 func (u *Update) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
@@ -357,3 +366,13 @@ func (u *Update) ServerAPI(serverAPI *driver.ServerAPIOptions) *Update {
 	u.serverAPI = serverAPI
 	return u
 }
+
+// Timeout sets the timeout for this operation.
+func (u *Update) Timeout(timeout *time.Duration) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.timeout = timeout
+	return u
+}
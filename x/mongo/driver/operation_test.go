@@ -19,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"go.mongodb.org/mongo-driver/tag"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/uuid"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
@@ -244,6 +245,214 @@ func TestOperation(t *testing.T) {
 			t.Errorf("WriteConcern elements do not match. got %v; want %v", got, want)
 		}
 	})
+	t.Run("calculateMaxTimeMS", func(t *testing.T) {
+		timeout := 10 * time.Second
+		zero := time.Duration(0)
+		deadlineCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		expiredCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		testCases := []struct {
+			name    string
+			op      Operation
+			ctx     context.Context
+			rtt     time.Duration
+			wantMin int64
+			wantMax int64
+			wantErr bool
+		}{
+			{"no timeout", Operation{}, deadlineCtx, 0, 0, 0, false},
+			{"zero timeout", Operation{Timeout: &zero}, deadlineCtx, 0, 0, 0, false},
+			{"no deadline", Operation{Timeout: &timeout}, context.Background(), 0, 0, 0, false},
+			{"omitted", Operation{Timeout: &timeout, OmitMaxTimeMS: true}, deadlineCtx, 0, 0, 0, false},
+			{"remaining time", Operation{Timeout: &timeout}, deadlineCtx, 0, 4000, 5000, false},
+			{"subtracts rtt", Operation{Timeout: &timeout}, deadlineCtx, time.Second, 3000, 4000, false},
+			{"deadline exceeded", Operation{Timeout: &timeout}, expiredCtx, time.Second, 0, 0, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := tc.op.calculateMaxTimeMS(tc.ctx, tc.rtt)
+				if tc.wantErr {
+					assert.NotNil(t, err, "expected error, got nil")
+					derr, ok := err.(Error)
+					assert.True(t, ok, "expected error type %T, got %T", Error{}, err)
+					assert.Equal(t, context.DeadlineExceeded, derr.Wrapped, "expected wrapped error %v, got %v",
+						context.DeadlineExceeded, derr.Wrapped)
+					return
+				}
+				assert.Nil(t, err, "calculateMaxTimeMS error: %v", err)
+				assert.True(t, got >= tc.wantMin && got <= tc.wantMax,
+					"expected maxTimeMS in range [%d, %d], got %d", tc.wantMin, tc.wantMax, got)
+			})
+		}
+	})
+	t.Run("addMaxTimeMS", func(t *testing.T) {
+		cmd := bsoncore.AppendStringElement(nil, "find", "foo")
+		cmdWithMaxTime := bsoncore.AppendInt64Element(cmd[:len(cmd):len(cmd)], "maxTimeMS", 100)
+
+		got := Operation{}.addMaxTimeMS(nil, cmd)
+		assert.Equal(t, 0, len(got), "expected no elements to be added, got %v", got)
+
+		got = Operation{maxTimeMS: 500}.addMaxTimeMS(nil, cmd)
+		want := bsoncore.AppendInt64Element(nil, "maxTimeMS", 500)
+		assert.Equal(t, want, got, "expected %v, got %v", want, got)
+
+		got = Operation{maxTimeMS: 500}.addMaxTimeMS(nil, cmdWithMaxTime)
+		assert.Equal(t, 0, len(got), "expected existing maxTimeMS to be kept, got %v", got)
+	})
+	t.Run("Execute with Timeout", func(t *testing.T) {
+		timeout := 10 * time.Second
+		desc := description.Server{WireVersion: &description.VersionRange{Max: 13}}
+		okReply := createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 1),
+			bsoncore.AppendInt32Element(nil, "n", 1),
+		), false)
+		retryableErrReply := createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendInt32Element(nil, "ok", 0),
+			bsoncore.AppendInt32Element(nil, "code", 91),
+			bsoncore.AppendStringElement(nil, "errmsg", "shutdown in progress"),
+			bsoncore.AppendArrayElement(nil, "errorLabels", bsoncore.BuildArray(nil,
+				bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, RetryableWriteError)})),
+		), false)
+		command := func(name string) func([]byte, description.SelectedServer) ([]byte, error) {
+			return func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendStringElement(dst, name, "coll"), nil
+			}
+		}
+		// readMaxTimeMS returns the maxTimeMS value of the command in the OP_MSG wire message wm.
+		readMaxTimeMS := func(t *testing.T, wm []byte) (int64, bool) {
+			t.Helper()
+			cmd, err := drivertest.GetCommandFromMsgWireMessage(wm)
+			noerr(t, err)
+			val, err := cmd.LookupErr("maxTimeMS")
+			if err != nil {
+				return 0, false
+			}
+			return val.Int64(), true
+		}
+		execute := func(t *testing.T, ctx context.Context, op Operation) (int64, bool) {
+			t.Helper()
+			conn := &drivertest.ChannelConn{Written: make(chan []byte, 1), ReadResp: make(chan []byte, 1), Desc: desc}
+			conn.ReadResp <- okReply
+			op.Database = "db"
+			op.Deployment = SingleConnectionDeployment{conn}
+			err := op.Execute(ctx, nil)
+			noerr(t, err)
+			return readMaxTimeMS(t, <-conn.Written)
+		}
+
+		t.Run("deadline is applied when the context has none", func(t *testing.T) {
+			got, ok := execute(t, context.Background(), Operation{CommandFn: command("find"), Timeout: &timeout})
+			assert.True(t, ok, "expected maxTimeMS to be sent")
+			assert.True(t, got > 9000 && got <= 10000, "expected maxTimeMS in range (9000, 10000], got %d", got)
+		})
+		t.Run("context deadline is used when it is set", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			got, ok := execute(t, ctx, Operation{CommandFn: command("find"), Timeout: &timeout})
+			assert.True(t, ok, "expected maxTimeMS to be sent")
+			assert.True(t, got > 0 && got <= 1000, "expected maxTimeMS in range (0, 1000], got %d", got)
+		})
+		t.Run("maxTimeMS is not sent without a Timeout", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			got, ok := execute(t, ctx, Operation{CommandFn: command("find")})
+			assert.False(t, ok, "expected no maxTimeMS, got %d", got)
+		})
+		t.Run("maxTimeMS is omitted for getMore", func(t *testing.T) {
+			got, ok := execute(t, context.Background(),
+				Operation{CommandFn: command("getMore"), Timeout: &timeout, OmitMaxTimeMS: true})
+			assert.False(t, ok, "expected no maxTimeMS, got %d", got)
+		})
+		t.Run("retries stop at the deadline", func(t *testing.T) {
+			retry := RetryOnce
+			newOp := func(conn Connection) Operation {
+				return Operation{
+					CommandFn:  command("find"),
+					Database:   "db",
+					Deployment: SingleConnectionDeployment{conn},
+					Type:       Read,
+					RetryMode:  &retry,
+				}
+			}
+
+			// Without a Timeout, RetryOnce allows a single retry.
+			conn := &recordingConnection{mockConnection: mockConnection{rDesc: desc}, reply: func(int) []byte {
+				return retryableErrReply
+			}}
+			err := newOp(conn).Execute(context.Background(), nil)
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.Equal(t, 2, len(conn.written), "expected 2 attempts, got %d", len(conn.written))
+
+			// With a Timeout, the operation is retried until the deadline is reached.
+			conn.written = nil
+			op := newOp(conn)
+			shortTimeout := 50 * time.Millisecond
+			op.Timeout = &shortTimeout
+			start := time.Now()
+			err = op.Execute(context.Background(), nil)
+			elapsed := time.Since(start)
+			derr, ok := err.(Error)
+			assert.True(t, ok, "expected error type %T, got %T", Error{}, err)
+			assert.Equal(t, context.DeadlineExceeded, derr.Wrapped, "expected wrapped error %v, got %v",
+				context.DeadlineExceeded, derr.Wrapped)
+			assert.True(t, len(conn.written) > 2, "expected more than 2 attempts, got %d", len(conn.written))
+			assert.True(t, elapsed < time.Second, "expected retries to stop at the deadline, took %v", elapsed)
+		})
+		t.Run("batches share one deadline", func(t *testing.T) {
+			sess, err := session.NewClientSession(session.NewPool(nil), uuid.UUID{}, session.Explicit)
+			noerr(t, err)
+			retry := RetryOncePerCommand
+			batchTimeout := 200 * time.Millisecond
+
+			// The first batch succeeds after 50ms and the second batch always fails with a retryable error.
+			conn := &recordingConnection{
+				mockConnection: mockConnection{rDesc: description.Server{
+					WireVersion:           &description.VersionRange{Max: 13},
+					Kind:                  description.RSPrimary,
+					SessionTimeoutMinutes: 30,
+					MaxBatchCount:         1,
+					MaxDocumentSize:       16 * 1024 * 1024,
+					MaxMessageSize:        48 * 1000 * 1000,
+				}},
+				reply: func(n int) []byte {
+					if n == 1 {
+						time.Sleep(50 * time.Millisecond)
+						return okReply
+					}
+					return retryableErrReply
+				},
+			}
+			doc := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "x", 1))
+			op := Operation{
+				CommandFn:    command("insert"),
+				Database:     "db",
+				Deployment:   SingleConnectionDeployment{conn},
+				Type:         Write,
+				Client:       sess,
+				Clock:        &session.ClusterClock{},
+				WriteConcern: writeconcern.New(writeconcern.WMajority()),
+				RetryMode:    &retry,
+				Batches:      &Batches{Identifier: "documents", Documents: []bsoncore.Document{doc, doc}},
+				Timeout:      &batchTimeout,
+			}
+			err = op.Execute(context.Background(), nil)
+			assert.NotNil(t, err, "expected error, got nil")
+
+			// The second batch keeps being retried until the deadline instead of only once, and the maxTimeMS of
+			// every attempt is derived from the same deadline.
+			assert.True(t, len(conn.written) > 3, "expected more than 3 attempts, got %d", len(conn.written))
+			first, ok := readMaxTimeMS(t, conn.written[0])
+			assert.True(t, ok, "expected maxTimeMS to be sent")
+			assert.True(t, first > 150 && first <= 200, "expected maxTimeMS in range (150, 200], got %d", first)
+			for _, wm := range conn.written[1:] {
+				got, ok := readMaxTimeMS(t, wm)
+				assert.True(t, ok, "expected maxTimeMS to be sent")
+				assert.True(t, got <= 150, "expected maxTimeMS of the second batch to be at most 150, got %d", got)
+			}
+		})
+	})
 	t.Run("addSession", func(t *testing.T) { t.Skip("These tests should be covered by spec tests.") })
 	t.Run("addClusterTime", func(t *testing.T) {
 		t.Run("adds max cluster time", func(t *testing.T) {
@@ -628,6 +837,23 @@ func assertExhaustAllowedSet(t *testing.T, wm []byte, expected bool) {
 	assert.Equal(t, expected, actual, "expected exhaustAllowed set %v, got %v", expected, actual)
 }
 
+// recordingConnection is a mockConnection that records every wire message written to it and replies with the wire
+// message returned by reply for the number of messages written so far.
+type recordingConnection struct {
+	mockConnection
+	written [][]byte
+	reply   func(n int) []byte
+}
+
+func (c *recordingConnection) WriteWireMessage(_ context.Context, wm []byte) error {
+	c.written = append(c.written, append([]byte(nil), wm...))
+	return nil
+}
+
+func (c *recordingConnection) ReadWireMessage(context.Context, []byte) ([]byte, error) {
+	return c.reply(len(c.written)), nil
+}
+
 type mockDeployment struct {
 	params struct {
 		selector description.ServerSelector